@@ -17,7 +17,7 @@ resource "signalfx_time_chart" "host_charts" {
   color_by          = "Metric"
 
   program_text = <<-EOF
-A = data("cpu.idle", filter('host', '${each.key}').publish(label="CPU")
+A = data("cpu.idle", filter('host', '${each.key}')).publish(label="CPU")
         EOF
 }
 
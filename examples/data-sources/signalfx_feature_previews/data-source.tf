@@ -0,0 +1,13 @@
+# Lists the feature previews supported by the provider
+# and if they are enabled by the current provider configuration.
+data "signalfx_feature_previews" "current" {}
+
+output "enabled-previews" {
+  value = [for name, preview in data.signalfx_feature_previews.current.previews : name if preview.enabled]
+}
+
+# Modules can also check if a single preview is generally available
+# using the provider function, regardless of the provider configuration.
+output "provider-tags-available" {
+  value = provider::signalfx::feature_generally_available("provider.tags")
+}
@@ -0,0 +1,8 @@
+data "signalfx_metric_time_series" "checkout" {
+  query = "sf_metric:cpu.utilization AND service:checkout"
+  limit = 100
+}
+
+output "checkout-hosts" {
+  value = distinct([for mts in data.signalfx_metric_time_series.checkout.time_series : mts.dimensions["host"] if mts.active])
+}
@@ -0,0 +1,25 @@
+variable "metric_name" {
+  type    = string
+  default = "checkout.request.duration"
+}
+
+data "signalfx_metrics" "charted" {
+  query = "name:${var.metric_name}"
+
+  lifecycle {
+    # Fail the plan straight away when the metric has not been reported,
+    # rather than creating a chart that never shows any data.
+    postcondition {
+      condition     = length(self.metrics) > 0
+      error_message = "The metric ${var.metric_name} has not been reported."
+    }
+  }
+}
+
+resource "signalfx_time_chart" "latency" {
+  name = "Checkout latency"
+
+  program_text = <<-EOF
+A = data("${data.signalfx_metrics.charted.metrics[0].name}").mean().publish(label="Latency")
+        EOF
+}
@@ -0,0 +1,22 @@
+resource "signalfx_org_token" "ingest" {
+  name        = "Kubernetes ingest"
+  auth_scopes = ["INGEST"]
+
+  exclude_secret_from_state = true
+}
+
+ephemeral "signalfx_org_token_secret" "ingest" {
+  name = signalfx_org_token.ingest.name
+}
+
+resource "kubernetes_secret_v1" "ingest" {
+  metadata {
+    name      = "splunk-otel-collector"
+    namespace = "observability"
+  }
+
+  data_wo = {
+    "splunk_observability_access_token" = ephemeral.signalfx_org_token_secret.ingest.secret
+  }
+  data_wo_revision = 1
+}
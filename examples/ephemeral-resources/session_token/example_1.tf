@@ -0,0 +1,23 @@
+ephemeral "signalfx_session_token" "admin" {
+  email    = var.admin_email
+  password = var.admin_password
+}
+
+provider "helm" {
+  kubernetes = {
+    config_path = "~/.kube/config"
+  }
+}
+
+resource "helm_release" "otel_collector" {
+  name       = "splunk-otel-collector"
+  repository = "https://signalfx.github.io/splunk-otel-collector-chart"
+  chart      = "splunk-otel-collector"
+
+  set_wo = [
+    {
+      name  = "splunkObservability.accessToken"
+      value = ephemeral.signalfx_session_token.admin.access_token
+    },
+  ]
+}
@@ -0,0 +1,5 @@
+# Read the token, realm and organization from the `eu0` profile
+# defined in ~/.signalfx.conf, can also be set with SFX_PROFILE.
+provider "signalfx" {
+  profile = "eu0"
+}
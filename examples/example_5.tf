@@ -0,0 +1,6 @@
+# Derive the API and application URLs from the realm,
+# can also be set with SFX_REALM.
+provider "signalfx" {
+  auth_token = var.signalfx_auth_token
+  realm      = "us1"
+}
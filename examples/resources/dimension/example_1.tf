@@ -0,0 +1,13 @@
+# Sets the owning team and a tag on the host, any properties
+# or tags set by other tools on the host are left unchanged.
+resource "signalfx_dimension" "checkout_host" {
+  key   = "host"
+  value = "checkout-server-1"
+
+  custom_properties = {
+    team        = "checkout"
+    cost_center = "cc-1234"
+  }
+
+  tags = ["production"]
+}
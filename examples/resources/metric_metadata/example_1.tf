@@ -0,0 +1,15 @@
+resource "signalfx_metric_metadata" "checkout_latency" {
+  name        = "checkout.request.duration"
+  description = "Time taken to process a checkout request"
+  unit        = "Millisecond"
+
+  custom_properties = {
+    team = "checkout"
+  }
+
+  tags = ["checkout", "latency"]
+
+  # The metric is reported by a service deployed in the same run,
+  # so wait for the first data point before setting its metadata.
+  wait_for_metric_seconds = 300
+}
@@ -0,0 +1,17 @@
+resource "signalfx_org_token" "collectors" {
+  name        = "collectors"
+  auth_scopes = ["INGEST"]
+}
+
+resource "signalfx_org_token_rotation" "collectors" {
+  name = signalfx_org_token.collectors.name
+
+  # Rotate every 90 days, or whenever the release keeper changes,
+  # keeping the old secret valid for a day so collectors can be updated.
+  rotation_days        = 90
+  grace_period_seconds = 86400
+
+  keepers = {
+    release = "2024-03"
+  }
+}
@@ -0,0 +1,19 @@
+variable "engineers" {
+  type = map(object({
+    full_name = string
+    roles     = list(string)
+  }))
+}
+
+resource "signalfx_organization_member" "engineers" {
+  for_each = var.engineers
+
+  email     = each.key
+  full_name = each.value.full_name
+  roles     = each.value.roles
+}
+
+resource "signalfx_organization_member" "lead" {
+  email = "lead@example.com"
+  admin = true
+}
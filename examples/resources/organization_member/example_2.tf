@@ -0,0 +1,15 @@
+# Imports every current member of the organization,
+# requires Terraform 1.7 or later to use for_each with import blocks.
+data "signalfx_organization_members" "all" {}
+
+import {
+  for_each = data.signalfx_organization_members.all.members
+  to       = signalfx_organization_member.members[each.key]
+  id       = each.value
+}
+
+resource "signalfx_organization_member" "members" {
+  for_each = data.signalfx_organization_members.all.members
+
+  email = each.key
+}
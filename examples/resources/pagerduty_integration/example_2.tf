@@ -0,0 +1,8 @@
+resource "signalfx_pagerduty_integration" "pagerduty_myteam" {
+  name    = "PD - My Team"
+  enabled = true
+
+  # The key is never stored in state, increment the version to rotate it
+  api_key_wo         = var.pagerduty_api_key
+  api_key_wo_version = 1
+}
@@ -0,0 +1,16 @@
+resource "signalfx_team" "platform" {
+  name    = "Platform"
+  members = ["userid1"]
+
+  # Keep the members added by other configurations.
+  ignore_unmanaged_members = true
+}
+
+# Managed within another module, such as the on-call rotation.
+resource "signalfx_team_membership" "on_call" {
+  team_id = signalfx_team.platform.id
+  members = [
+    "userid2",
+    "userid3",
+  ]
+}
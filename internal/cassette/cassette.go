@@ -0,0 +1,96 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package cassette records the HTTP interactions made by the provider
+// so that acceptance tests can later replay them without access to a realm.
+package cassette
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Mode controls if interactions are recorded, replayed, or passed through unchanged.
+type Mode string
+
+const (
+	ModeDisabled Mode = ""
+	ModeRecord   Mode = "record"
+	ModeReplay   Mode = "replay"
+)
+
+// EnvMode is the environment variable used to select the cassette mode for acceptance tests.
+const EnvMode = "SFX_CASSETTE_MODE"
+
+// ModeFromEnv reads the cassette mode from the environment,
+// returning an error if the value is not a known mode.
+func ModeFromEnv() (Mode, error) {
+	switch m := Mode(os.Getenv(EnvMode)); m {
+	case ModeDisabled, ModeRecord, ModeReplay:
+		return m, nil
+	default:
+		return ModeDisabled, fmt.Errorf("unknown %s value %q, expected %q or %q", EnvMode, m, ModeRecord, ModeReplay)
+	}
+}
+
+// Path returns the location of the cassette for the named test within dir.
+func Path(dir, name string) string {
+	return filepath.Join(dir, strings.NewReplacer("/", "_", " ", "_").Replace(name)+".json")
+}
+
+// Request is the sanitized portion of the request that is stored.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is the sanitized portion of the response that is stored.
+type Response struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// Interaction is a single request and response pair.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is the file format used to store the interactions of a test.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Load reads the cassette stored at path.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("cassette %q does not exist, run the test with %s=%s to create it", path, EnvMode, ModeRecord)
+		}
+		return nil, err
+	}
+	c := &Cassette{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("cassette %q: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the cassette to path, creating any missing directories.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
@@ -0,0 +1,69 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cassette
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
+)
+
+// TestReplayOrgTokenLifecycle replays the cassette stored within `testdata/cassettes`,
+// running the test with SFX_CASSETTE_MODE=record records it again using the fake API.
+func TestReplayOrgTokenLifecycle(t *testing.T) {
+	mode, err := ModeFromEnv()
+	require.NoError(t, err, "Must have a valid cassette mode")
+	if mode == ModeDisabled {
+		mode = ModeReplay
+	}
+
+	tr, err := New(Path(filepath.Join("testdata", "cassettes"), t.Name()), mode)
+	require.NoError(t, err, "Must load the cassette")
+	t.Cleanup(func() {
+		assert.NoError(t, tr.Save(), "Must save the cassette")
+	})
+
+	// The host is not used to match interactions so an unreachable host is used when replaying.
+	var (
+		baseURL = "http://replay.invalid"
+		next    http.RoundTripper
+	)
+	if mode == ModeRecord {
+		s := httptest.NewServer(fakeapi.New(
+			fakeapi.WithSeed(1),
+			fakeapi.WithClock(func() time.Time { return time.UnixMilli(1700000000000) }),
+		))
+		t.Cleanup(s.Close)
+		baseURL, next = s.URL, s.Client().Transport
+	}
+	client := &http.Client{Transport: tr.Wrap(next)}
+
+	status, body := doRequest(t, client, http.MethodPost, baseURL+"/v2/token", `{"name":"ci","description":"Token used by CI"}`)
+	require.Equal(t, http.StatusOK, status, "Must create the token")
+
+	var token map[string]any
+	require.NoError(t, json.Unmarshal([]byte(body), &token), "Must return the token")
+	assert.Equal(t, "ci", token["name"], "Must return the token name")
+	if mode == ModeReplay {
+		assert.Equal(t, Redacted, token["secret"], "Must not have stored the token secret")
+	}
+
+	status, body = doRequest(t, client, http.MethodGet, baseURL+"/v2/token/ci", "")
+	assert.Equal(t, http.StatusOK, status, "Must read the token")
+	assert.Contains(t, body, `"description":"Token used by CI"`, "Must return the stored token")
+
+	status, _ = doRequest(t, client, http.MethodDelete, baseURL+"/v2/token/ci", "")
+	assert.Equal(t, http.StatusNoContent, status, "Must delete the token")
+
+	status, _ = doRequest(t, client, http.MethodGet, baseURL+"/v2/token/ci", "")
+	assert.Equal(t, http.StatusNotFound, status, "Must not find the deleted token")
+}
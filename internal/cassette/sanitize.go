@@ -0,0 +1,31 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cassette
+
+import (
+	"encoding/json"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// Redacted replaces any sensitive value before it is stored.
+const Redacted = tfext.RedactedValue
+
+// SanitizeBody redacts any credentials from a JSON body and
+// returns it in a canonical form so that it can be compared.
+// Bodies that are not JSON are returned unchanged.
+func SanitizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	data, err := json.Marshal(tfext.RedactJSON(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
@@ -0,0 +1,62 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cassette
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeBody(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		body   string
+		expect string
+	}{
+		{
+			name:   "empty body",
+			body:   "",
+			expect: "",
+		},
+		{
+			name:   "not json",
+			body:   "plain text",
+			expect: "plain text",
+		},
+		{
+			name:   "canonical form",
+			body:   `{ "b": 1, "a": 2 }`,
+			expect: `{"a":2,"b":1}`,
+		},
+		{
+			name:   "redacts credentials",
+			body:   `{"name":"aws","key":"AKIA","token":"t","secretKey":"s","clientPassword":"p","accessToken":"a"}`,
+			expect: `{"accessToken":"REDACTED","clientPassword":"REDACTED","key":"REDACTED","name":"aws","secretKey":"REDACTED","token":"REDACTED"}`,
+		},
+		{
+			name:   "keeps filter keys",
+			body:   `{"filters":[{"key":"host","value":"a"}]}`,
+			expect: `{"filters":[{"key":"host","value":"a"}]}`,
+		},
+		{
+			name:   "redacts nested values",
+			body:   `{"services":[{"credentials":{"password":"p","user":"u"}}]}`,
+			expect: `{"services":[{"credentials":{"password":"REDACTED","user":"u"}}]}`,
+		},
+		{
+			name:   "keeps non string values",
+			body:   `{"tokens":["a"],"secret":null}`,
+			expect: `{"secret":null,"tokens":["a"]}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expect, SanitizeBody([]byte(tc.body)), "Must match the expected body")
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Transport records or replays the interactions made by an HTTP client.
+//
+// Interactions are matched using the method, the path and query of the URL,
+// and the sanitized body so that the host of the API does not matter.
+// Requests that are made concurrently are replayed in the order they were
+// recorded for each match, once all recorded responses for a request have
+// been used the last one is repeated.
+type Transport struct {
+	mode Mode
+	path string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// New creates a transport that stores interactions at path.
+// When replaying, the cassette must already exist.
+func New(path string, mode Mode) (*Transport, error) {
+	t := &Transport{
+		mode:     mode,
+		path:     path,
+		cassette: &Cassette{},
+	}
+	if mode == ModeReplay {
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		t.cassette = c
+		t.used = make([]bool, len(c.Interactions))
+	}
+	return t, nil
+}
+
+// Mode returns the mode the transport was created with.
+func (t *Transport) Mode() Mode {
+	return t.mode
+}
+
+// Save writes the recorded interactions to disk,
+// it does nothing unless the transport is recording.
+func (t *Transport) Save() error {
+	if t.mode != ModeRecord {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cassette.Save(t.path)
+}
+
+// Wrap returns a round tripper that records the interactions made using next,
+// or replays the stored interactions without calling next.
+func (t *Transport) Wrap(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		switch t.mode {
+		case ModeRecord:
+			return t.record(next, req)
+		case ModeReplay:
+			return t.replay(req)
+		default:
+			return next.RoundTrip(req)
+		}
+	})
+}
+
+func (t *Transport) record(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cassette.Interactions = append(t.cassette.Interactions, Interaction{
+		Request: newRequest(req, reqBody),
+		Response: Response{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        SanitizeBody(respBody),
+		},
+	})
+	return resp, nil
+}
+
+func (t *Transport) replay(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	want := newRequest(req, body)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	match := -1
+	for i, in := range t.cassette.Interactions {
+		if in.Request != want {
+			continue
+		}
+		match = i
+		if !t.used[i] {
+			break
+		}
+	}
+	if match == -1 {
+		return nil, fmt.Errorf("cassette %q has no interaction for %s %s", t.path, want.Method, want.URL)
+	}
+	t.used[match] = true
+
+	stored := t.cassette.Interactions[match].Response
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", stored.StatusCode, http.StatusText(stored.StatusCode)),
+		StatusCode:    stored.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewBufferString(stored.Body)),
+		ContentLength: int64(len(stored.Body)),
+		Request:       req,
+	}
+	if stored.ContentType != "" {
+		resp.Header.Set("Content-Type", stored.ContentType)
+	}
+	return resp, nil
+}
+
+func newRequest(req *http.Request, body []byte) Request {
+	return Request{
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Body:   SanitizeBody(body),
+	}
+}
+
+// readBody reads the entire body and replaces it so it can be read again.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+	if err := (*body).Close(); err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
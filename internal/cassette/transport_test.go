@@ -0,0 +1,125 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doRequest(t *testing.T, client *http.Client, method, url, body string) (int, string) {
+	req, err := http.NewRequestWithContext(t.Context(), method, url, strings.NewReader(body))
+	require.NoError(t, err, "Must create request")
+	req.Header.Set("X-SF-Token", "super-secret-token")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err, "Must complete request")
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "Must read body")
+	return resp.StatusCode, string(data)
+}
+
+func TestTransportRecordReplay(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			_, _ = io.WriteString(w, `{"name":"my token","secret":"abcdef"}`)
+		default:
+			_, _ = io.WriteString(w, `{"name":"my token","disabled":false}`)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	path := filepath.Join(t.TempDir(), "cassettes", "example.json")
+
+	rec, err := New(path, ModeRecord)
+	require.NoError(t, err, "Must create recorder")
+	client := &http.Client{Transport: rec.Wrap(s.Client().Transport)}
+
+	status, body := doRequest(t, client, http.MethodPost, s.URL+"/v2/token", `{"name":"my token"}`)
+	assert.Equal(t, http.StatusOK, status, "Must return the server status")
+	assert.JSONEq(t, `{"name":"my token","secret":"abcdef"}`, body, "Must return the unmodified body while recording")
+
+	_, _ = doRequest(t, client, http.MethodGet, s.URL+"/v2/token/my%20token", "")
+	require.NoError(t, rec.Save(), "Must save the cassette")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err, "Must have written the cassette")
+	assert.NotContains(t, string(data), "abcdef", "Must redact secrets")
+	assert.NotContains(t, string(data), "super-secret-token", "Must not store the auth token")
+
+	play, err := New(path, ModeReplay)
+	require.NoError(t, err, "Must load the cassette")
+	client = &http.Client{Transport: play.Wrap(nil)}
+
+	// The host is not used to match interactions so an unreachable host is used.
+	status, body = doRequest(t, client, http.MethodPost, "http://replay.invalid/v2/token", `{"name":"my token"}`)
+	assert.Equal(t, http.StatusOK, status, "Must replay the recorded status")
+	assert.JSONEq(t, `{"name":"my token","secret":"REDACTED"}`, body, "Must replay the sanitized body")
+
+	for range 2 {
+		_, body = doRequest(t, client, http.MethodGet, "http://replay.invalid/v2/token/my%20token", "")
+		assert.JSONEq(t, `{"name":"my token","disabled":false}`, body, "Must repeat the last matching interaction")
+	}
+	assert.Equal(t, 2, calls, "Must not call the server while replaying")
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodDelete, "http://replay.invalid/v2/token/my%20token", http.NoBody)
+	require.NoError(t, err, "Must create request")
+	_, err = client.Do(req) //nolint:bodyclose // Errors do not return a body
+	assert.ErrorContains(t, err, "has no interaction for DELETE /v2/token/my%20token", "Must error for requests that were not recorded")
+}
+
+func TestTransportReplayMissingCassette(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "missing.json")
+	_, err := New(path, ModeReplay)
+	assert.EqualError(t, err, "cassette \""+path+"\" does not exist, run the test with SFX_CASSETTE_MODE=record to create it", "Must explain how to create the cassette")
+}
+
+func TestModeFromEnv(t *testing.T) {
+	for _, tc := range []struct {
+		value  string
+		mode   Mode
+		errVal string
+	}{
+		{value: "", mode: ModeDisabled},
+		{value: "record", mode: ModeRecord},
+		{value: "replay", mode: ModeReplay},
+		{value: "rewind", mode: ModeDisabled, errVal: "unknown SFX_CASSETTE_MODE value \"rewind\", expected \"record\" or \"replay\""},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Setenv(EnvMode, tc.value)
+
+			mode, err := ModeFromEnv()
+			assert.Equal(t, tc.mode, mode, "Must match the expected mode")
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				assert.NoError(t, err, "Must not error")
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, filepath.Join("testdata", "cassettes", "TestAccTeam_sub_test.json"), Path(filepath.Join("testdata", "cassettes"), "TestAccTeam/sub test"), "Must replace path separators and spaces")
+}
@@ -0,0 +1,38 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/signalflow"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// ProgramText performs offline validation of the SignalFlow program
+// so that syntax errors and undefined variables are reported before
+// any request is made to the API.
+func ProgramText() schema.SchemaValidateDiagFunc {
+	return func(i any, p cty.Path) (issues diag.Diagnostics) {
+		s, ok := i.(string)
+		if !ok {
+			return tfext.AsErrorDiagnostics(
+				fmt.Errorf("expected %v to be of type string", i),
+				p,
+			)
+		}
+		for _, d := range signalflow.Check(s) {
+			if d.Severity == signalflow.SeverityWarning {
+				issues = tfext.AppendDiagnostics(issues, tfext.AsWarnDiagnostics(d, p)...)
+			} else {
+				issues = tfext.AppendDiagnostics(issues, tfext.AsErrorDiagnostics(d, p)...)
+			}
+		}
+		return issues
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgramText(t *testing.T) {
+	t.Parallel()
+
+	// More thorough testing is done within signalflow
+	for _, tc := range []struct {
+		name   string
+		value  any
+		expect diag.Diagnostics
+	}{
+		{
+			name:  "no value provided",
+			value: nil,
+			expect: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected <nil> to be of type string"},
+			},
+		},
+		{
+			name:   "valid program",
+			value:  "A = data('cpu.utilization').publish(label='A')\ndetect(when(A > 10)).publish('CPU high')",
+			expect: nil,
+		},
+		{
+			name:  "syntax error",
+			value: "A = data('cpu.utilization'.publish(label='A')",
+			expect: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "line 1, column 9: \"(\" was never closed"},
+			},
+		},
+		{
+			name:  "undefined variable and unknown function",
+			value: "A = dat('cpu.utilization').publish(label='A')\ndetect(when(B > 10)).publish('CPU high')",
+			expect: diag.Diagnostics{
+				{Severity: diag.Warning, Summary: "line 1, column 5: unknown function \"dat\""},
+				{Severity: diag.Error, Summary: "line 2, column 13: undefined variable \"B\""},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := ProgramText()(tc.value, cty.Path{})
+			assert.Equal(t, tc.expect, actual, "Must match the expected values")
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/signalflow"
+)
+
+// PublishedLabels reads `program_text` from the raw resource configuration
+// and returns the labels it publishes. The value ok is false when the program text
+// is not yet known or the labels can not be determined offline.
+func PublishedLabels(config cty.Value) (labels []string, ok bool) {
+	if config.IsNull() || !config.IsKnown() {
+		return nil, false
+	}
+	program := config.GetAttr("program_text")
+	if program.IsNull() || !program.IsKnown() {
+		return nil, false
+	}
+	return signalflow.ProgramLabels(program.AsString())
+}
+
+// FormatLabels renders the labels as a readable list for diagnostics.
+func FormatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return "none"
+	}
+	quoted := make([]string, 0, len(labels))
+	for _, l := range labels {
+		quoted = append(quoted, fmt.Sprintf("%q", l))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// VisualizationLabels ensures that each `viz_options` block refers to a label
+// that is published within `program_text` so the styling is not silently ignored.
+func VisualizationLabels() schema.ValidateRawResourceConfigFunc {
+	return func(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+		labels, ok := PublishedLabels(req.RawConfig)
+		if !ok {
+			return
+		}
+		opts := req.RawConfig.GetAttr("viz_options")
+		if opts.IsNull() || !opts.IsKnown() {
+			return
+		}
+		for it := opts.ElementIterator(); it.Next(); {
+			_, opt := it.Element()
+			if opt.IsNull() || !opt.IsKnown() {
+				continue
+			}
+			label := opt.GetAttr("label")
+			if label.IsNull() || !label.IsKnown() || slices.Contains(labels, label.AsString()) {
+				continue
+			}
+			resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("viz_options label %q is not published within program_text", label.AsString()),
+				Detail:        fmt.Sprintf("The available labels are: %s", FormatLabels(labels)),
+				AttributePath: cty.GetAttrPath("viz_options").Index(opt).GetAttr("label"),
+			})
+		}
+	}
+}
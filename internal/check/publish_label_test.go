@@ -0,0 +1,86 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatLabels(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "none", FormatLabels(nil), "Must report no labels")
+	assert.Equal(t, `"A", "B"`, FormatLabels([]string{"A", "B"}), "Must quote each label")
+}
+
+func TestVisualizationLabels(t *testing.T) {
+	t.Parallel()
+
+	viz := func(label string) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"label": cty.StringVal(label),
+			"color": cty.NullVal(cty.String),
+		})
+	}
+
+	for _, tc := range []struct {
+		name   string
+		config cty.Value
+		expect diag.Diagnostics
+	}{
+		{
+			name: "no viz options",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("data('cpu').publish('A')"),
+				"viz_options":  cty.NullVal(cty.Set(viz("").Type())),
+			}),
+			expect: nil,
+		},
+		{
+			name: "matching label",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("data('cpu').publish('A')"),
+				"viz_options":  cty.SetVal([]cty.Value{viz("A")}),
+			}),
+			expect: nil,
+		},
+		{
+			name: "invalid program text is reported elsewhere",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("data('cpu'.publish('A')"),
+				"viz_options":  cty.SetVal([]cty.Value{viz("B")}),
+			}),
+			expect: nil,
+		},
+		{
+			name: "mismatched label",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("A = data('cpu').publish('A')\nB = data('mem').publish(label='B')"),
+				"viz_options":  cty.SetVal([]cty.Value{viz("A"), viz("C")}),
+			}),
+			expect: diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "viz_options label \"C\" is not published within program_text",
+					Detail:        "The available labels are: \"A\", \"B\"",
+					AttributePath: cty.GetAttrPath("viz_options").Index(viz("C")).GetAttr("label"),
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			VisualizationLabels()(context.Background(), schema.ValidateResourceConfigFuncRequest{RawConfig: tc.config}, resp)
+			assert.Equal(t, tc.expect, resp.Diagnostics, "Must match the expected diagnostics")
+		})
+	}
+}
@@ -5,6 +5,8 @@ package common
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -14,12 +16,51 @@ import (
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
+// ResponseError is returned for the unsuccessful responses of the API requests
+// that are not made using the go-sdk, it provides the same details as [signalfx.ResponseError].
+type ResponseError struct {
+	code    int
+	route   string
+	details string
+}
+
+// NewResponseError returns the error for the response code of the route.
+func NewResponseError(code int, route, details string) *ResponseError {
+	return &ResponseError{code: code, route: route, details: details}
+}
+
+func (re *ResponseError) Error() string {
+	return fmt.Sprintf("route %q had issues with status code %d", re.route, re.code)
+}
+
+func (re *ResponseError) Code() int       { return re.code }
+func (re *ResponseError) Route() string   { return re.route }
+func (re *ResponseError) Details() string { return re.details }
+
+// apiError is implemented by both [signalfx.ResponseError] and [ResponseError].
+type apiError interface {
+	Code() int
+	Route() string
+	Details() string
+}
+
+func asAPIError(err error) (apiError, bool) {
+	if re, ok := signalfx.AsResponseError(err); ok {
+		return re, true
+	}
+	var re *ResponseError
+	if errors.As(err, &re) {
+		return re, true
+	}
+	return nil, false
+}
+
 // HandleError handles the general case when the signalfx api returns
 // an error, and it uses that information to determine what needs to happen.
 // This will ensure that the state is cleaned up given the error condition.
 // To help simplify error handling, it will always return the error provided.
 func HandleError(ctx context.Context, err error, data *schema.ResourceData) error {
-	re, ok := signalfx.AsResponseError(err)
+	re, ok := asAPIError(err)
 	if !ok {
 		// Not a response error, pass it back
 		return err
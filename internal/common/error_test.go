@@ -6,6 +6,8 @@ package common
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -36,6 +38,16 @@ func TestOnError(t *testing.T) {
 			err:    &signalfx.ResponseError{},
 			expect: "id",
 		},
+		{
+			name:   "direct request not found",
+			err:    NewResponseError(http.StatusNotFound, "/v2/token/example/rotate", "Not found"),
+			expect: "",
+		},
+		{
+			name:   "direct request failed",
+			err:    fmt.Errorf("rotate: %w", NewResponseError(http.StatusBadRequest, "/v2/token/example/rotate", "Bad request")),
+			expect: "id",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
@@ -0,0 +1,448 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go/notification"
+)
+
+const (
+	// NotificationBlockField is the attribute name used for typed notification blocks
+	// that are an alternative to the comma encoded notification strings.
+	NotificationBlockField = "notification"
+	// NotificationStringField is the attribute name used for comma encoded notification strings.
+	NotificationStringField = "notifications"
+)
+
+// notificationBlockTypes maps the nested block name onto the notification type it represents.
+var notificationBlockTypes = map[string]string{
+	"amazon_eventbridge": AmazonEventBrigeNotificationType,
+	"bigpanda":           BigPandaNotificationType,
+	"email":              EmailNotificationType,
+	"jira":               JiraNotificationType,
+	"office365":          Office365NotificationType,
+	"opsgenie":           OpsgenieNotificationType,
+	"pagerduty":          PagerDutyNotificationType,
+	"service_now":        ServiceNowNotificationType,
+	"slack":              SlackNotificationType,
+	"splunk_platform":    SplunkPlatformNotificationType,
+	"team":               TeamNotificationType,
+	"team_email":         TeamEmailNotificationType,
+	"victor_ops":         VictorOpsNotificationType,
+	"webhook":            WebhookNotificationType,
+	"xmatters":           XMattersNotificationType,
+}
+
+// NewNotificationBlockSchema returns the typed notification block schema,
+// each block must define exactly one of the notification types.
+func NewNotificationBlockSchema(description string) *schema.Schema {
+	credential := func(required bool) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    required,
+			Optional:    !required,
+			Description: "ID of the integration credential to use",
+		}
+	}
+	credentialOnly := func(name string) *schema.Schema {
+		return notificationTypeBlock(fmt.Sprintf("Send notifications using the %s integration", name), map[string]*schema.Schema{
+			"credential_id": credential(true),
+		})
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"amazon_eventbridge": credentialOnly(AmazonEventBrigeNotificationType),
+				"bigpanda":           credentialOnly(BigPandaNotificationType),
+				"email": notificationTypeBlock("Send notifications by email", map[string]*schema.Schema{
+					"email": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Email address to send notifications to",
+					},
+					"cc": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "Email addresses to carbon copy on notifications",
+					},
+					"bcc": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "Email addresses to blind carbon copy on notifications",
+					},
+				}),
+				"jira":      credentialOnly(JiraNotificationType),
+				"office365": credentialOnly(Office365NotificationType),
+				"opsgenie": notificationTypeBlock("Send notifications using the Opsgenie integration", map[string]*schema.Schema{
+					"credential_id": credential(true),
+					"responder_name": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Name of the Opsgenie responder",
+					},
+					"responder_id": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "ID of the Opsgenie responder",
+					},
+					"responder_type": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Type of the Opsgenie responder, for example `Team` or `User`",
+					},
+				}),
+				"pagerduty":   credentialOnly(PagerDutyNotificationType),
+				"service_now": credentialOnly(ServiceNowNotificationType),
+				"slack": notificationTypeBlock("Send notifications using the Slack integration", map[string]*schema.Schema{
+					"credential_id": credential(true),
+					"channel": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Name of the Slack channel, excluding the leading `#`",
+					},
+				}),
+				"splunk_platform": credentialOnly(SplunkPlatformNotificationType),
+				"team": notificationTypeBlock("Send notifications to the team's configured notifications", map[string]*schema.Schema{
+					"team": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "ID of the team",
+					},
+				}),
+				"team_email": notificationTypeBlock("Send notifications to the team's members by email", map[string]*schema.Schema{
+					"team": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "ID of the team",
+					},
+				}),
+				"victor_ops": notificationTypeBlock("Send notifications using the VictorOps integration", map[string]*schema.Schema{
+					"credential_id": credential(true),
+					"routing_key": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "VictorOps routing key",
+					},
+				}),
+				"webhook": notificationTypeBlock("Send notifications to a webhook, using either `credential_id` or `url`", map[string]*schema.Schema{
+					"credential_id": credential(false),
+					"secret": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "Secret sent along with the webhook request",
+					},
+					"url": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "URL to send the webhook request to",
+					},
+				}),
+				"xmatters": credentialOnly(XMattersNotificationType),
+			},
+		},
+	}
+}
+
+func notificationTypeBlock(description string, fields map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem:        &schema.Resource{Schema: fields},
+	}
+}
+
+// NewNotificationFromBlock converts a typed notification block into the API type.
+func NewNotificationFromBlock(block map[string]any) (*notification.Notification, error) {
+	var (
+		name   string
+		fields map[string]any
+	)
+	for _, key := range slices.Sorted(maps.Keys(notificationBlockTypes)) {
+		items, ok := block[key].([]any)
+		if !ok || len(items) == 0 {
+			continue
+		}
+		if name != "" {
+			return nil, fmt.Errorf("notification block must only define one type, found %q and %q", name, key)
+		}
+		name, fields = key, map[string]any{}
+		if items[0] != nil {
+			fields = items[0].(map[string]any)
+		}
+	}
+	if name == "" {
+		return nil, errors.New("notification block must define one type")
+	}
+
+	var (
+		nt    = notificationBlockTypes[name]
+		value any
+	)
+	field := func(key string) string {
+		s, _ := fields[key].(string)
+		return s
+	}
+	switch nt {
+	case AmazonEventBrigeNotificationType:
+		value = &notification.AmazonEventBrigeNotification{Type: nt, CredentialId: field("credential_id")}
+	case BigPandaNotificationType:
+		value = &notification.BigPandaNotification{Type: nt, CredentialId: field("credential_id")}
+	case EmailNotificationType:
+		email, err := newEmailNotificationFromBlock(field("email"), fields["cc"], fields["bcc"])
+		if err != nil {
+			return nil, err
+		}
+		value = email
+	case JiraNotificationType:
+		value = &notification.JiraNotification{Type: nt, CredentialId: field("credential_id")}
+	case Office365NotificationType:
+		value = &notification.Office365Notification{Type: nt, CredentialId: field("credential_id")}
+	case OpsgenieNotificationType:
+		value = &notification.OpsgenieNotification{
+			Type:          nt,
+			CredentialId:  field("credential_id"),
+			ResponderName: field("responder_name"),
+			ResponderId:   field("responder_id"),
+			ResponderType: field("responder_type"),
+		}
+	case PagerDutyNotificationType:
+		value = &notification.PagerDutyNotification{Type: nt, CredentialId: field("credential_id")}
+	case ServiceNowNotificationType:
+		value = &notification.ServiceNowNotification{Type: nt, CredentialId: field("credential_id")}
+	case SlackNotificationType:
+		if strings.Contains(field("channel"), "#") {
+			return nil, fmt.Errorf("exclude the # from channel names in %q", field("channel"))
+		}
+		value = &notification.SlackNotification{Type: nt, CredentialId: field("credential_id"), Channel: field("channel")}
+	case SplunkPlatformNotificationType:
+		value = &notification.SplunkPlatformNotification{Type: nt, CredentialId: field("credential_id")}
+	case TeamNotificationType:
+		value = &notification.TeamNotification{Type: nt, Team: field("team")}
+	case TeamEmailNotificationType:
+		value = &notification.TeamEmailNotification{Type: nt, Team: field("team")}
+	case VictorOpsNotificationType:
+		value = &notification.VictorOpsNotification{Type: nt, CredentialId: field("credential_id"), RoutingKey: field("routing_key")}
+	case WebhookNotificationType:
+		switch {
+		case field("credential_id") != "" && field("url") != "":
+			return nil, errors.New("webhook notification must only set one of credential_id or url")
+		case field("credential_id") != "":
+			// Do nothing, credentialId is set
+		case field("url") != "":
+			if _, err := url.ParseRequestURI(field("url")); err != nil {
+				return nil, fmt.Errorf("invalid Webhook URL %q", field("url"))
+			}
+		default:
+			return nil, errors.New("webhook notification must set one of credential_id or url")
+		}
+		value = &notification.WebhookNotification{
+			Type:         nt,
+			CredentialId: field("credential_id"),
+			Secret:       field("secret"),
+			Url:          field("url"),
+		}
+	case XMattersNotificationType:
+		value = &notification.XMattersNotification{Type: nt, CredentialId: field("credential_id")}
+	}
+
+	return &notification.Notification{Type: nt, Value: value}, nil
+}
+
+func newEmailNotificationFromBlock(addr string, cc, bcc any) (*notification.EmailNotification, error) {
+	if _, err := mail.ParseAddress(addr); err != nil {
+		return nil, err
+	}
+	email := &notification.EmailNotification{
+		Type:  EmailNotificationType,
+		Email: addr,
+	}
+	for _, list := range []struct {
+		values any
+		field  *[]string
+	}{
+		{values: cc, field: &email.Cc},
+		{values: bcc, field: &email.Bcc},
+	} {
+		items, _ := list.values.([]any)
+		for _, v := range items {
+			s, _ := v.(string)
+			if _, err := mail.ParseAddress(s); err != nil {
+				return nil, err
+			}
+			*list.field = append(*list.field, s)
+		}
+	}
+	return email, nil
+}
+
+// NewNotificationBlockFromAPI converts the API type into a typed notification block.
+func NewNotificationBlockFromAPI(n *notification.Notification) (map[string]any, error) {
+	if n == nil {
+		return nil, errors.New("nil value provided")
+	}
+	var (
+		name   string
+		fields map[string]any
+	)
+	switch v := n.Value.(type) {
+	case *notification.AmazonEventBrigeNotification:
+		name, fields = "amazon_eventbridge", map[string]any{"credential_id": v.CredentialId}
+	case *notification.BigPandaNotification:
+		name, fields = "bigpanda", map[string]any{"credential_id": v.CredentialId}
+	case *notification.EmailNotification:
+		name, fields = "email", map[string]any{
+			"email": v.Email,
+			"cc":    slices.Clone(v.Cc),
+			"bcc":   slices.Clone(v.Bcc),
+		}
+	case *notification.JiraNotification:
+		name, fields = "jira", map[string]any{"credential_id": v.CredentialId}
+	case *notification.Office365Notification:
+		name, fields = "office365", map[string]any{"credential_id": v.CredentialId}
+	case *notification.OpsgenieNotification:
+		name, fields = "opsgenie", map[string]any{
+			"credential_id":  v.CredentialId,
+			"responder_name": v.ResponderName,
+			"responder_id":   v.ResponderId,
+			"responder_type": v.ResponderType,
+		}
+	case *notification.PagerDutyNotification:
+		name, fields = "pagerduty", map[string]any{"credential_id": v.CredentialId}
+	case *notification.ServiceNowNotification:
+		name, fields = "service_now", map[string]any{"credential_id": v.CredentialId}
+	case *notification.SlackNotification:
+		name, fields = "slack", map[string]any{"credential_id": v.CredentialId, "channel": v.Channel}
+	case *notification.SplunkPlatformNotification:
+		name, fields = "splunk_platform", map[string]any{"credential_id": v.CredentialId}
+	case *notification.TeamNotification:
+		name, fields = "team", map[string]any{"team": v.Team}
+	case *notification.TeamEmailNotification:
+		name, fields = "team_email", map[string]any{"team": v.Team}
+	case *notification.VictorOpsNotification:
+		name, fields = "victor_ops", map[string]any{"credential_id": v.CredentialId, "routing_key": v.RoutingKey}
+	case *notification.WebhookNotification:
+		name, fields = "webhook", map[string]any{
+			"credential_id": v.CredentialId,
+			"secret":        v.Secret,
+			"url":           v.Url,
+		}
+	case *notification.XMattersNotification:
+		name, fields = "xmatters", map[string]any{"credential_id": v.CredentialId}
+	default:
+		return nil, fmt.Errorf("unknown type %T provided", n.Value)
+	}
+	return map[string]any{name: []any{fields}}, nil
+}
+
+// NewNotificationListFromBlocks converts the typed notification blocks into the API type.
+func NewNotificationListFromBlocks(items []any) ([]*notification.Notification, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	values := make([]*notification.Notification, len(items))
+	for i, v := range items {
+		block, _ := v.(map[string]any)
+		n, err := NewNotificationFromBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = n
+	}
+	return values, nil
+}
+
+// NewNotificationBlockList converts the API type into typed notification blocks.
+func NewNotificationBlockList(items []*notification.Notification) ([]map[string]any, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	values := make([]map[string]any, len(items))
+	for i, v := range items {
+		var err error
+		values[i], err = NewNotificationBlockFromAPI(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// NewNotificationListFromEither converts either the notification strings or
+// the typed notification blocks into the API type, it is an error to set both.
+func NewNotificationListFromEither(strs, blocks []any) ([]*notification.Notification, error) {
+	switch {
+	case len(strs) > 0 && len(blocks) > 0:
+		return nil, fmt.Errorf("only one of %q or %q can be set", NotificationStringField, NotificationBlockField)
+	case len(blocks) > 0:
+		return NewNotificationListFromBlocks(blocks)
+	default:
+		return NewNotificationList(strs)
+	}
+}
+
+// UsesNotificationBlocks reports if the previously stored value
+// was written using typed notification blocks.
+func UsesNotificationBlocks(prior any) bool {
+	blocks, ok := prior.([]any)
+	return ok && len(blocks) > 0
+}
+
+// EncodeNotificationFields sets both the notification strings and typed blocks within item,
+// only the form selected by blocks is populated so that either form can round trip.
+func EncodeNotificationFields(item map[string]any, notifys []*notification.Notification, blocks bool) error {
+	item[NotificationStringField], item[NotificationBlockField] = nil, nil
+	if blocks {
+		values, err := NewNotificationBlockList(notifys)
+		if err != nil {
+			return err
+		}
+		item[NotificationBlockField] = values
+		return nil
+	}
+	values, err := NewNotificationStringList(notifys)
+	if err != nil {
+		return err
+	}
+	item[NotificationStringField] = values
+	return nil
+}
+
+// NotificationHashValues returns a sorted representation of both notification forms,
+// typed blocks are distinguished from strings so changing forms alters the hash.
+func NotificationHashValues(strs, blocks []any) []string {
+	values := make([]string, 0, len(strs)+len(blocks))
+	for _, s := range strs {
+		if s != nil {
+			values = append(values, s.(string))
+		}
+	}
+	for _, b := range blocks {
+		block, _ := b.(map[string]any)
+		s := fmt.Sprint(block)
+		if n, err := NewNotificationFromBlock(block); err == nil {
+			s, _ = NewNotificationStringFromAPI(n)
+		}
+		values = append(values, NotificationBlockField+":"+s)
+	}
+	slices.Sort(values)
+	return values
+}
@@ -0,0 +1,240 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNotificationBlockSchema(t *testing.T) {
+	t.Parallel()
+
+	s := NewNotificationBlockSchema("example")
+	assert.Equal(t, "example", s.Description, "Must use the provided description")
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			NotificationBlockField: s,
+		},
+	}
+	assert.NoError(t, resource.InternalValidate(nil, true), "Must be a valid schema")
+}
+
+func TestNewNotificationFromBlock(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		block  map[string]any
+		expect *notification.Notification
+		errVal string
+	}{
+		{
+			name:   "no type defined",
+			block:  map[string]any{},
+			errVal: "notification block must define one type",
+		},
+		{
+			name: "multiple types defined",
+			block: map[string]any{
+				"jira":  []any{map[string]any{"credential_id": "aaa"}},
+				"slack": []any{map[string]any{"credential_id": "aaa", "channel": "alerts"}},
+			},
+			errVal: "notification block must only define one type, found \"jira\" and \"slack\"",
+		},
+		{
+			name: "slack",
+			block: map[string]any{
+				"slack": []any{map[string]any{"credential_id": "aaa", "channel": "alerts"}},
+			},
+			expect: &notification.Notification{
+				Type:  SlackNotificationType,
+				Value: &notification.SlackNotification{Type: SlackNotificationType, CredentialId: "aaa", Channel: "alerts"},
+			},
+		},
+		{
+			name: "slack channel with hash",
+			block: map[string]any{
+				"slack": []any{map[string]any{"credential_id": "aaa", "channel": "#alerts"}},
+			},
+			errVal: "exclude the # from channel names in \"#alerts\"",
+		},
+		{
+			name: "email with recipients",
+			block: map[string]any{
+				"email": []any{map[string]any{
+					"email": "user@example.com",
+					"cc":    []any{"a@example.com"},
+					"bcc":   []any{"b@example.com"},
+				}},
+			},
+			expect: &notification.Notification{
+				Type: EmailNotificationType,
+				Value: &notification.EmailNotification{
+					Type:  EmailNotificationType,
+					Email: "user@example.com",
+					Cc:    []string{"a@example.com"},
+					Bcc:   []string{"b@example.com"},
+				},
+			},
+		},
+		{
+			name: "invalid email",
+			block: map[string]any{
+				"email": []any{map[string]any{"email": "not an address"}},
+			},
+			errVal: "mail: no angle-addr",
+		},
+		{
+			name: "opsgenie",
+			block: map[string]any{
+				"opsgenie": []any{map[string]any{
+					"credential_id":  "aaa",
+					"responder_name": "ops",
+					"responder_id":   "bbb",
+					"responder_type": "Team",
+				}},
+			},
+			expect: &notification.Notification{
+				Type: OpsgenieNotificationType,
+				Value: &notification.OpsgenieNotification{
+					Type:          OpsgenieNotificationType,
+					CredentialId:  "aaa",
+					ResponderName: "ops",
+					ResponderId:   "bbb",
+					ResponderType: "Team",
+				},
+			},
+		},
+		{
+			name: "webhook without destination",
+			block: map[string]any{
+				"webhook": []any{map[string]any{"secret": "shh"}},
+			},
+			errVal: "webhook notification must set one of credential_id or url",
+		},
+		{
+			name: "webhook with both destinations",
+			block: map[string]any{
+				"webhook": []any{map[string]any{"credential_id": "aaa", "url": "https://example.com"}},
+			},
+			errVal: "webhook notification must only set one of credential_id or url",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := NewNotificationFromBlock(tc.block)
+			assert.Equal(t, tc.expect, actual, "Must match the expected notification")
+			if tc.errVal != "" {
+				require.EqualError(t, err, tc.errVal, "Must match the expected error message")
+			} else {
+				require.NoError(t, err, "Must not error parsing block")
+			}
+		})
+	}
+}
+
+func TestNotificationBlockRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, str := range []string{
+		"AmazonEventBridge,aaa",
+		"BigPanda,aaa",
+		"Email,user@example.com",
+		"Jira,aaa",
+		"Office365,aaa",
+		"Opsgenie,aaa,ops,bbb,Team",
+		"PagerDuty,aaa",
+		"ServiceNow,aaa",
+		"Slack,aaa,alerts",
+		"SplunkPlatform,aaa",
+		"Team,aaa",
+		"TeamEmail,aaa",
+		"VictorOps,aaa,routing",
+		"Webhook,,secret,https://example.com",
+		"XMatters,aaa",
+	} {
+		t.Run(str, func(t *testing.T) {
+			t.Parallel()
+
+			expect, err := NewNotificationFromString(str)
+			require.NoError(t, err, "Must not error parsing string")
+
+			block, err := NewNotificationBlockFromAPI(expect)
+			require.NoError(t, err, "Must not error converting to block")
+
+			actual, err := NewNotificationFromBlock(block)
+			require.NoError(t, err, "Must not error parsing block")
+			assert.Equal(t, expect, actual, "Must match the notification parsed from the string")
+		})
+	}
+}
+
+func TestNewNotificationListFromEither(t *testing.T) {
+	t.Parallel()
+
+	blocks := []any{
+		map[string]any{"team": []any{map[string]any{"team": "aaa"}}},
+	}
+	expect := []*notification.Notification{
+		{Type: TeamNotificationType, Value: &notification.TeamNotification{Type: TeamNotificationType, Team: "aaa"}},
+	}
+
+	actual, err := NewNotificationListFromEither(nil, blocks)
+	assert.NoError(t, err, "Must not error with only blocks")
+	assert.Equal(t, expect, actual, "Must match the expected notifications")
+
+	actual, err = NewNotificationListFromEither([]any{"Team,aaa"}, nil)
+	assert.NoError(t, err, "Must not error with only strings")
+	assert.Equal(t, expect, actual, "Must match the expected notifications")
+
+	actual, err = NewNotificationListFromEither(nil, nil)
+	assert.NoError(t, err, "Must not error with no values")
+	assert.Nil(t, actual, "Must not return any notifications")
+
+	_, err = NewNotificationListFromEither([]any{"Team,aaa"}, blocks)
+	assert.EqualError(t, err, "only one of \"notifications\" or \"notification\" can be set", "Must error when both forms are set")
+}
+
+func TestEncodeNotificationFields(t *testing.T) {
+	t.Parallel()
+
+	notifys := []*notification.Notification{
+		{Type: TeamNotificationType, Value: &notification.TeamNotification{Type: TeamNotificationType, Team: "aaa"}},
+	}
+
+	item := map[string]any{}
+	require.NoError(t, EncodeNotificationFields(item, notifys, false), "Must not error encoding strings")
+	assert.Equal(t, map[string]any{
+		NotificationStringField: []string{"Team,aaa"},
+		NotificationBlockField:  nil,
+	}, item, "Must only set the string form")
+
+	item = map[string]any{}
+	require.NoError(t, EncodeNotificationFields(item, notifys, true), "Must not error encoding blocks")
+	assert.Equal(t, map[string]any{
+		NotificationStringField: nil,
+		NotificationBlockField: []map[string]any{
+			{"team": []any{map[string]any{"team": "aaa"}}},
+		},
+	}, item, "Must only set the block form")
+}
+
+func TestNotificationHashValues(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		[]string{"Team,bbb", "notification:Team,aaa"},
+		NotificationHashValues(
+			[]any{"Team,bbb"},
+			[]any{map[string]any{"team": []any{map[string]any{"team": "aaa"}}}},
+		),
+		"Must return sorted values with blocks distinguished",
+	)
+}
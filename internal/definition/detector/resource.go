@@ -13,9 +13,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/signalfx/signalfx-go/detector"
 
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/rule"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/signalflow"
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
@@ -39,6 +42,10 @@ func NewResource() *schema.Resource {
 			{Type: v0state().CoreConfigSchema().ImpliedType(), Upgrade: v0stateMigration, Version: 0},
 		},
 		CustomizeDiff: customdiff.If(resourceValidateCond, resourceValidateFunc),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			rule.ValidateDetectLabels(),
+			check.VisualizationLabels(),
+		},
 	}
 }
 
@@ -194,6 +201,10 @@ func resourceValidateCond(ctx context.Context, diff *schema.ResourceDiff, _ any)
 }
 
 func resourceValidateFunc(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
+	if err := signalflow.Validate(diff.Get("program_text").(string)); err != nil {
+		return err
+	}
+
 	var rules []*detector.Rule
 	for _, v := range diff.Get("rule").(*schema.Set).List() {
 		data := v.(map[string]any)
@@ -24,10 +24,13 @@ func newSchema() map[string]*schema.Schema {
 			Description: "Name of the detector",
 		},
 		"program_text": {
-			Type:         schema.TypeString,
-			Required:     true,
-			Description:  "Signalflow program text for the detector. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
-			ValidateFunc: validation.StringLenBetween(1, 50000),
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Signalflow program text for the detector. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
+			ValidateDiagFunc: validation.AllDiag(
+				validation.ToDiagFunc(validation.StringLenBetween(1, 50000)),
+				check.ProgramText(),
+			),
 		},
 		"description": {
 			Type:        schema.TypeString,
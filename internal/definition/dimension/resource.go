@@ -0,0 +1,228 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dimension
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+const (
+	ResourceName = "signalfx_dimension"
+)
+
+// NewResource manages the custom properties and tags of a single dimension value.
+//
+// A dimension can not be created or deleted using the API, so the resource only owns
+// the custom properties and tags that are defined, leaving any values set by other
+// tools unchanged. Destroying the resource removes only the values it owns.
+func NewResource() *schema.Resource {
+	return &schema.Resource{
+		SchemaFunc:    newResourceSchema,
+		CreateContext: resourceCreate,
+		ReadContext:   resourceRead,
+		UpdateContext: resourceUpdate,
+		DeleteContext: resourceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceImport,
+		},
+		Description: "Manages the custom properties and tags of a dimension value, such as `host:my-host`, without changing the values it does not define.",
+	}
+}
+
+// NewResourceID returns the ID used by the resource, which is also used to import it.
+func NewResourceID(key, value string) string {
+	return key + ":" + value
+}
+
+func resourceImport(ctx context.Context, data *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	key, value, ok := strings.Cut(data.Id(), ":")
+	if !ok || key == "" || value == "" {
+		return nil, fmt.Errorf("invalid import id %q, expected the format key:value", data.Id())
+	}
+	if err := data.Set("key", key); err != nil {
+		return nil, err
+	}
+	if err := data.Set("value", value); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{data}, nil
+}
+
+func resourceCreate(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	desired, err := decodeTerraform(data)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+	// Any existing values are adopted on create, since the dimension
+	// is expected to already exist with values set by other tools.
+	return applyDimension(ctx, data, meta, &metrics_metadata.Dimension{}, desired)
+}
+
+func resourceRead(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	owned, err := decodeTerraform(data)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	dim, err := client.GetDimension(ctx, owned.Key, owned.Value)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, data))
+	}
+
+	return tfext.AsErrorDiagnostics(encodeTerraform(ownedValues(dim, owned), data))
+}
+
+func resourceUpdate(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	desired, err := decodeTerraform(data)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	previous := &metrics_metadata.Dimension{Key: desired.Key, Value: desired.Value}
+	if props, _ := data.GetChange("custom_properties"); props != nil {
+		for k, v := range props.(map[string]any) {
+			if previous.CustomProperties == nil {
+				previous.CustomProperties = make(map[string]string)
+			}
+			previous.CustomProperties[k] = v.(string)
+		}
+	}
+	if tags, _ := data.GetChange("tags"); tags != nil {
+		for _, tag := range tags.(*schema.Set).List() {
+			previous.Tags = append(previous.Tags, tag.(string))
+		}
+	}
+
+	return applyDimension(ctx, data, meta, previous, desired)
+}
+
+func resourceDelete(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	previous, err := decodeTerraform(data)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	dim, err := client.GetDimension(ctx, previous.Key, previous.Value)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, data))
+	}
+
+	tflog.Debug(ctx, "Removing owned dimension values", tfext.NewLogFields().
+		Field("key", previous.Key).
+		Field("value", previous.Value),
+	)
+
+	mergeDimension(dim, previous, &metrics_metadata.Dimension{})
+	_, err = client.UpdateDimension(ctx, previous.Key, previous.Value, dim)
+	return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, data))
+}
+
+// applyDimension reads the current dimension so that only the owned values are
+// changed from previous to desired, then updates the dimension with the result.
+func applyDimension(ctx context.Context, data *schema.ResourceData, meta any, previous, desired *metrics_metadata.Dimension) diag.Diagnostics {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	dim, err := client.GetDimension(ctx, desired.Key, desired.Value)
+	if re, ok := signalfx.AsResponseError(err); ok && re.Code() == http.StatusNotFound {
+		// The dimension is created by the update if it has not been reported yet.
+		dim, err = &metrics_metadata.Dimension{Key: desired.Key, Value: desired.Value}, nil
+	}
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	mergeDimension(dim, previous, desired)
+
+	tflog.Debug(ctx, "Updating dimension", tfext.NewLogFields().
+		Field("key", desired.Key).
+		Field("value", desired.Value).
+		Field("custom_properties", len(dim.CustomProperties)).
+		Field("tags", len(dim.Tags)),
+	)
+
+	updated, err := client.UpdateDimension(ctx, desired.Key, desired.Value, dim)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	return tfext.AsErrorDiagnostics(encodeTerraform(ownedValues(updated, desired), data))
+}
+
+// mergeDimension removes the values of previous that are no longer in desired,
+// and sets the values of desired, any other values of dim are left unchanged.
+func mergeDimension(dim, previous, desired *metrics_metadata.Dimension) {
+	props := maps.Clone(dim.CustomProperties)
+	if props == nil {
+		props = make(map[string]string)
+	}
+	for k := range previous.CustomProperties {
+		if _, ok := desired.CustomProperties[k]; !ok {
+			delete(props, k)
+		}
+	}
+	maps.Copy(props, desired.CustomProperties)
+	dim.CustomProperties = props
+
+	tags := slices.DeleteFunc(slices.Clone(dim.Tags), func(tag string) bool {
+		return slices.Contains(previous.Tags, tag) && !slices.Contains(desired.Tags, tag)
+	})
+	for _, tag := range desired.Tags {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	dim.Tags = tags
+}
+
+// ownedValues returns a copy of dim that only includes
+// the custom properties and tags that are defined by owned.
+func ownedValues(dim, owned *metrics_metadata.Dimension) *metrics_metadata.Dimension {
+	values := &metrics_metadata.Dimension{
+		Key:   owned.Key,
+		Value: owned.Value,
+	}
+	for k, v := range dim.CustomProperties {
+		if _, ok := owned.CustomProperties[k]; ok {
+			if values.CustomProperties == nil {
+				values.CustomProperties = make(map[string]string)
+			}
+			values.CustomProperties[k] = v
+		}
+	}
+	for _, tag := range dim.Tags {
+		if slices.Contains(owned.Tags, tag) {
+			values.Tags = append(values.Tags, tag)
+		}
+	}
+	slices.Sort(values.Tags)
+	return values
+}
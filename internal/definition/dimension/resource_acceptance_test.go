@@ -0,0 +1,57 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dimension_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/dimension"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+)
+
+func TestResourceAcceptance(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		steps []resource.TestStep
+	}{
+		{
+			name: "manage dimension properties",
+			steps: []resource.TestStep{
+				{
+					Config: tftest.LoadConfig("testdata/resource_dimension.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("signalfx_dimension.host", "id", "host:terraform-acceptance-host"),
+						resource.TestCheckResourceAttr("signalfx_dimension.host", "custom_properties.team", "observability"),
+						resource.TestCheckResourceAttr("signalfx_dimension.host", "tags.#", "1"),
+					),
+				},
+				{
+					Config: tftest.LoadConfig("testdata/resource_dimension_updated.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("signalfx_dimension.host", "custom_properties.%", "2"),
+						resource.TestCheckResourceAttr("signalfx_dimension.host", "custom_properties.team", "platform"),
+						resource.TestCheckResourceAttr("signalfx_dimension.host", "tags.#", "0"),
+					),
+				},
+				{
+					ResourceName:      "signalfx_dimension.host",
+					ImportState:       true,
+					ImportStateId:     "host:terraform-acceptance-host",
+					ImportStateVerify: false,
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tftest.NewAcceptanceHandler(
+				tftest.WithAcceptanceResources(map[string]*schema.Resource{
+					dimension.ResourceName: dimension.NewResource(),
+				}),
+			).Test(t, tc.steps)
+		})
+	}
+}
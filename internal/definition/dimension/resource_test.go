@@ -0,0 +1,333 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dimension
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+)
+
+func TestNewResource(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, NewResource(), "Must return a valid value")
+}
+
+func TestResourceImport(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		id     string
+		key    string
+		value  string
+		errVal string
+	}{
+		{name: "valid id", id: "host:server-1", key: "host", value: "server-1"},
+		{name: "value with separator", id: "url:http://localhost", key: "url", value: "http://localhost"},
+		{name: "missing separator", id: "host", errVal: "invalid import id \"host\", expected the format key:value"},
+		{name: "missing value", id: "host:", errVal: "invalid import id \"host:\", expected the format key:value"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rd := NewResource().TestResourceData()
+			rd.SetId(tc.id)
+
+			actual, err := resourceImport(context.Background(), rd, nil)
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+				return
+			}
+			require.NoError(t, err, "Must not error importing resource")
+			require.Len(t, actual, 1, "Must return the imported resource")
+			assert.Equal(t, tc.key, actual[0].Get("key"), "Must match the expected key")
+			assert.Equal(t, tc.value, actual[0].Get("value"), "Must match the expected value")
+		})
+	}
+}
+
+func TestMergeDimension(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		dim      *metrics_metadata.Dimension
+		previous *metrics_metadata.Dimension
+		desired  *metrics_metadata.Dimension
+		expect   *metrics_metadata.Dimension
+	}{
+		{
+			name:     "empty dimension",
+			dim:      &metrics_metadata.Dimension{},
+			previous: &metrics_metadata.Dimension{},
+			desired: &metrics_metadata.Dimension{
+				CustomProperties: map[string]string{"team": "infra"},
+				Tags:             []string{"prod"},
+			},
+			expect: &metrics_metadata.Dimension{
+				CustomProperties: map[string]string{"team": "infra"},
+				Tags:             []string{"prod"},
+			},
+		},
+		{
+			name: "keeps unmanaged values",
+			dim: &metrics_metadata.Dimension{
+				CustomProperties: map[string]string{"team": "infra", "region": "us0"},
+				Tags:             []string{"external"},
+			},
+			previous: &metrics_metadata.Dimension{
+				CustomProperties: map[string]string{"team": "infra"},
+			},
+			desired: &metrics_metadata.Dimension{
+				CustomProperties: map[string]string{"team": "platform"},
+				Tags:             []string{"prod"},
+			},
+			expect: &metrics_metadata.Dimension{
+				CustomProperties: map[string]string{"team": "platform", "region": "us0"},
+				Tags:             []string{"external", "prod"},
+			},
+		},
+		{
+			name: "removes values no longer managed",
+			dim: &metrics_metadata.Dimension{
+				CustomProperties: map[string]string{"team": "infra", "region": "us0"},
+				Tags:             []string{"external", "prod"},
+			},
+			previous: &metrics_metadata.Dimension{
+				CustomProperties: map[string]string{"team": "infra"},
+				Tags:             []string{"prod"},
+			},
+			desired: &metrics_metadata.Dimension{},
+			expect: &metrics_metadata.Dimension{
+				CustomProperties: map[string]string{"region": "us0"},
+				Tags:             []string{"external"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mergeDimension(tc.dim, tc.previous, tc.desired)
+			assert.Equal(t, tc.expect, tc.dim, "Must match the expected dimension")
+		})
+	}
+}
+
+func TestResourceCreate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[metrics_metadata.Dimension]{
+		{
+			Name: "No provider",
+			Meta: func(_ testing.TB) any {
+				return nil
+			},
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input:    &metrics_metadata.Dimension{Key: "host", Value: "server-1"},
+			Expect:   &metrics_metadata.Dimension{Key: "host", Value: "server-1"},
+			Issues: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected to implement type Meta"},
+			},
+		},
+		{
+			Name: "Dimension not reported",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/dimension/host/server-1": func(w http.ResponseWriter, _ *http.Request) {
+					http.Error(w, "Not found", http.StatusNotFound)
+				},
+				"PUT /v2/dimension/host/server-1": func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(w, r.Body)
+					_ = r.Body.Close()
+				},
+			}),
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input: &metrics_metadata.Dimension{
+				Key:              "host",
+				Value:            "server-1",
+				CustomProperties: map[string]string{"team": "infra"},
+			},
+			Expect: &metrics_metadata.Dimension{
+				Key:              "host",
+				Value:            "server-1",
+				CustomProperties: map[string]string{"team": "infra"},
+			},
+			Issues: nil,
+		},
+		{
+			Name: "Keeps unmanaged values",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/dimension/host/server-1": func(w http.ResponseWriter, _ *http.Request) {
+					_ = json.NewEncoder(w).Encode(&metrics_metadata.Dimension{
+						Key:              "host",
+						Value:            "server-1",
+						CustomProperties: map[string]string{"region": "us0"},
+						Tags:             []string{"external"},
+					})
+				},
+				"PUT /v2/dimension/host/server-1": func(w http.ResponseWriter, r *http.Request) {
+					var dim metrics_metadata.Dimension
+					if err := json.NewDecoder(r.Body).Decode(&dim); err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+					_ = r.Body.Close()
+					if dim.CustomProperties["region"] != "us0" {
+						http.Error(w, "unmanaged property was removed", http.StatusBadRequest)
+						return
+					}
+					_ = json.NewEncoder(w).Encode(&dim)
+				},
+			}),
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input: &metrics_metadata.Dimension{
+				Key:              "host",
+				Value:            "server-1",
+				CustomProperties: map[string]string{"team": "infra"},
+				Tags:             []string{"prod"},
+			},
+			Expect: &metrics_metadata.Dimension{
+				Key:              "host",
+				Value:            "server-1",
+				CustomProperties: map[string]string{"team": "infra"},
+				Tags:             []string{"prod"},
+			},
+			Issues: nil,
+		},
+	} {
+		tc.TestCreate(t)
+	}
+}
+
+func TestResourceRead(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[metrics_metadata.Dimension]{
+		{
+			Name: "No provider",
+			Meta: func(_ testing.TB) any {
+				return nil
+			},
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input:    &metrics_metadata.Dimension{Key: "host", Value: "server-1"},
+			Expect:   &metrics_metadata.Dimension{Key: "host", Value: "server-1"},
+			Issues: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected to implement type Meta"},
+			},
+		},
+		{
+			Name: "Only reads managed values",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/dimension/host/server-1": func(w http.ResponseWriter, _ *http.Request) {
+					_ = json.NewEncoder(w).Encode(&metrics_metadata.Dimension{
+						Key:              "host",
+						Value:            "server-1",
+						CustomProperties: map[string]string{"team": "platform", "region": "us0"},
+						Tags:             []string{"external"},
+					})
+				},
+			}),
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input: &metrics_metadata.Dimension{
+				Key:              "host",
+				Value:            "server-1",
+				CustomProperties: map[string]string{"team": "infra"},
+				Tags:             []string{"prod"},
+			},
+			Expect: &metrics_metadata.Dimension{
+				Key:              "host",
+				Value:            "server-1",
+				CustomProperties: map[string]string{"team": "platform"},
+			},
+			Issues: nil,
+		},
+	} {
+		tc.TestRead(t)
+	}
+}
+
+func TestResourceDelete(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[metrics_metadata.Dimension]{
+		{
+			Name: "No provider",
+			Meta: func(_ testing.TB) any {
+				return nil
+			},
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input:    &metrics_metadata.Dimension{Key: "host", Value: "server-1"},
+			Expect:   &metrics_metadata.Dimension{Key: "host", Value: "server-1"},
+			Issues: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected to implement type Meta"},
+			},
+		},
+		{
+			Name: "Removes managed values",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/dimension/host/server-1": func(w http.ResponseWriter, _ *http.Request) {
+					_ = json.NewEncoder(w).Encode(&metrics_metadata.Dimension{
+						Key:              "host",
+						Value:            "server-1",
+						CustomProperties: map[string]string{"team": "infra", "region": "us0"},
+						Tags:             []string{"prod"},
+					})
+				},
+				"PUT /v2/dimension/host/server-1": func(w http.ResponseWriter, r *http.Request) {
+					var dim metrics_metadata.Dimension
+					if err := json.NewDecoder(r.Body).Decode(&dim); err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+					_ = r.Body.Close()
+					if _, ok := dim.CustomProperties["team"]; ok || len(dim.Tags) != 0 {
+						http.Error(w, "managed values were not removed", http.StatusBadRequest)
+						return
+					}
+					_ = json.NewEncoder(w).Encode(&dim)
+				},
+			}),
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input: &metrics_metadata.Dimension{
+				Key:              "host",
+				Value:            "server-1",
+				CustomProperties: map[string]string{"team": "infra"},
+				Tags:             []string{"prod"},
+			},
+			Expect: &metrics_metadata.Dimension{
+				Key:              "host",
+				Value:            "server-1",
+				CustomProperties: map[string]string{"team": "infra"},
+				Tags:             []string{"prod"},
+			},
+			Issues: nil,
+		},
+	} {
+		tc.TestDelete(t)
+	}
+}
@@ -4,8 +4,13 @@
 package dimension
 
 import (
+	"slices"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
 )
 
 func newSchema() map[string]*schema.Schema {
@@ -37,3 +42,71 @@ func newSchema() map[string]*schema.Schema {
 		},
 	}
 }
+
+func newResourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"key": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Name of the dimension, such as `host` or `service`",
+		},
+		"value": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Value of the dimension to manage the metadata of",
+		},
+		"custom_properties": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: "Custom properties set on the dimension value. Only the properties defined are managed, any other properties are left unchanged",
+		},
+		"tags": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			Description: "Tags set on the dimension value. Only the tags defined are managed, any other tags are left unchanged",
+		},
+	}
+}
+
+func decodeTerraform(rd *schema.ResourceData) (*metrics_metadata.Dimension, error) {
+	dim := &metrics_metadata.Dimension{
+		Key:   rd.Get("key").(string),
+		Value: rd.Get("value").(string),
+	}
+	if props, ok := rd.Get("custom_properties").(map[string]any); ok && len(props) > 0 {
+		dim.CustomProperties = make(map[string]string, len(props))
+		for k, v := range props {
+			dim.CustomProperties[k] = v.(string)
+		}
+	}
+	if tags, ok := rd.Get("tags").(*schema.Set); ok && tags.Len() > 0 {
+		dim.Tags = convert.SliceAll(tags.List(), convert.ToString)
+		slices.Sort(dim.Tags)
+	}
+	return dim, nil
+}
+
+func encodeTerraform(dim *metrics_metadata.Dimension, rd *schema.ResourceData) error {
+	rd.SetId(NewResourceID(dim.Key, dim.Value))
+	if err := rd.Set("key", dim.Key); err != nil {
+		return err
+	}
+	if err := rd.Set("value", dim.Value); err != nil {
+		return err
+	}
+	if err := rd.Set("custom_properties", dim.CustomProperties); err != nil {
+		return err
+	}
+	return rd.Set("tags", convert.SliceAll(dim.Tags, convert.ToAny[string]))
+}
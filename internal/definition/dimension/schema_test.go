@@ -6,7 +6,10 @@ package dimension
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewSchema(t *testing.T) {
@@ -14,3 +17,38 @@ func TestNewSchema(t *testing.T) {
 
 	assert.NotEmpty(t, newSchema(), "Must have a defined schema returned")
 }
+
+func TestResourceSchemaEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		dim  *metrics_metadata.Dimension
+	}{
+		{
+			name: "key and value only",
+			dim:  &metrics_metadata.Dimension{Key: "host", Value: "server-1"},
+		},
+		{
+			name: "all values set",
+			dim: &metrics_metadata.Dimension{
+				Key:              "host",
+				Value:            "server-1",
+				CustomProperties: map[string]string{"team": "infra", "region": "us0"},
+				Tags:             []string{"critical", "prod"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rd := schema.TestResourceDataRaw(t, newResourceSchema(), map[string]any{})
+			require.NoError(t, encodeTerraform(tc.dim, rd), "Must not error encoding dimension")
+			assert.Equal(t, "host:server-1", rd.Id(), "Must set the resource id")
+
+			actual, err := decodeTerraform(rd)
+			require.NoError(t, err, "Must not error decoding dimension")
+			assert.Equal(t, tc.dim, actual, "Must match the encoded dimension")
+		})
+	}
+}
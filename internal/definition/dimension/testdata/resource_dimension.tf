@@ -0,0 +1,9 @@
+resource "signalfx_dimension" "host" {
+  key   = "host"
+  value = "terraform-acceptance-host"
+
+  custom_properties = {
+    team = "observability"
+  }
+  tags = ["terraform"]
+}
@@ -0,0 +1,45 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/metric"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+)
+
+func TestDataSourceAcceptance(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		steps []resource.TestStep
+	}{
+		{
+			name: "ensure results load",
+			steps: []resource.TestStep{
+				{
+					Config: tftest.LoadConfig("testdata/data_metrics.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.signalfx_metrics.cpu", "id", "name:cpu.*"),
+						resource.TestCheckResourceAttrSet("data.signalfx_metrics.cpu", "metrics.#"),
+						resource.TestCheckResourceAttr("data.signalfx_metric_time_series.cpu", "id", "sf_metric:cpu.utilization"),
+						resource.TestCheckResourceAttrSet("data.signalfx_metric_time_series.cpu", "time_series.#"),
+					),
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tftest.NewAcceptanceHandler(
+				tftest.WithAcceptanceDataSources(map[string]*schema.Resource{
+					metric.MetricsDataSourceName:    metric.NewMetricsDataSource(),
+					metric.TimeSeriesDataSourceName: metric.NewTimeSeriesDataSource(),
+				}),
+			).Test(t, tc.steps)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+const (
+	MetricsDataSourceName = "signalfx_metrics"
+)
+
+func NewMetricsDataSource() *schema.Resource {
+	return &schema.Resource{
+		SchemaFunc:  newMetricsSchema,
+		ReadContext: readMetrics,
+		Description: "This data source allows for searching the metrics reported to the organization using the query provided.",
+	}
+}
+
+func readMetrics(ctx context.Context, rd *schema.ResourceData, meta any) (issues diag.Diagnostics) {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	var (
+		query   = rd.Get("query").(string)
+		orderby = rd.Get("order_by").(string)
+		limit   = rd.Get("limit").(int)
+	)
+
+	tflog.Debug(ctx, "Performing metric search operation", tfext.NewLogFields().
+		Field("query", query).
+		Field("limit", limit).
+		Field("order_by", orderby),
+	)
+
+	results, count, err := searchAll(ctx, limit, PageLimit, func(ctx context.Context, size, offset int) (int, []map[string]any, error) {
+		resp, err := client.SearchMetric(ctx, query, orderby, size, offset)
+		if err != nil {
+			return 0, nil, err
+		}
+		values := make([]map[string]any, 0, len(resp.Results))
+		for _, m := range resp.Results {
+			values = append(values, map[string]any{
+				"name":              m.Name,
+				"type":              m.Type,
+				"description":       m.Description,
+				"custom_properties": m.CustomProperties,
+				"tags":              m.Tags,
+				"last_updated":      int(m.LastUpdated),
+			})
+		}
+		return int(resp.Count), values, nil
+	})
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	rd.SetId(query)
+	if err := rd.Set("metrics", results); err != nil {
+		issues = tfext.AppendDiagnostics(issues, tfext.AsErrorDiagnostics(err)...)
+	}
+
+	return tfext.AppendDiagnostics(issues, truncatedDiagnostics(count, limit)...)
+}
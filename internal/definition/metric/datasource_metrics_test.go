@@ -0,0 +1,117 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+)
+
+func TestMetricsDataSource(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		meta  func(t testing.TB) any
+		names []string
+		diags diag.Diagnostics
+	}{
+		{
+			name: "no provider set",
+			meta: func(testing.TB) any {
+				return nil
+			},
+			names: nil,
+			diags: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected to implement type Meta"},
+			},
+		},
+		{
+			name: "invalid request",
+			meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/metric": func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+
+					http.Error(w, "unable to read metrics", http.StatusBadRequest)
+				},
+			}),
+			names: nil,
+			diags: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "route \"/v2/metric\" had issues with status code 400"},
+			},
+		},
+		{
+			name: "exceeds limit",
+			meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/metric": func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"count": 3,
+						"results": []map[string]any{
+							{"name": "cpu.utilization", "type": "GAUGE", "lastUpdated": 1700000000000},
+							{"name": "cpu.idle", "type": "GAUGE"},
+							{"name": "cpu.wait", "type": "GAUGE"},
+						},
+					})
+				},
+			}),
+			names: []string{"cpu.utilization", "cpu.idle"},
+			diags: diag.Diagnostics{
+				{
+					Severity: diag.Warning,
+					Summary:  "Number of matched results exceeds allowed returned limit, values truncated",
+					Detail:   "Adjust the query to be more selective or increase the limit to avoid this issue",
+				},
+			},
+		},
+		{
+			name: "returns metrics",
+			meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/metric": func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"count": 1,
+						"results": []map[string]any{
+							{"name": "cpu.utilization", "type": "GAUGE", "lastUpdated": 1700000000000},
+						},
+					})
+				},
+			}),
+			names: []string{"cpu.utilization"},
+			diags: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := NewMetricsDataSource()
+
+			rd := data.TestResourceData()
+			_ = rd.Set("query", "name:cpu.*")
+			_ = rd.Set("limit", 2)
+
+			diags := data.ReadContext(context.Background(), rd, tc.meta(t))
+			assert.Equal(t, tc.diags, diags, "Must match the expected values")
+
+			var names []string
+			for _, m := range rd.Get("metrics").([]any) {
+				names = append(names, m.(map[string]any)["name"].(string))
+			}
+			assert.Equal(t, tc.names, names, "Must match the expected metric names")
+		})
+	}
+}
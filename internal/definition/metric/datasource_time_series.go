@@ -0,0 +1,81 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+const (
+	TimeSeriesDataSourceName = "signalfx_metric_time_series"
+)
+
+func NewTimeSeriesDataSource() *schema.Resource {
+	return &schema.Resource{
+		SchemaFunc:  newTimeSeriesSchema,
+		ReadContext: readTimeSeries,
+		Description: "This data source allows for searching the metric time series reported to the organization using the query provided.",
+	}
+}
+
+func readTimeSeries(ctx context.Context, rd *schema.ResourceData, meta any) (issues diag.Diagnostics) {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	var (
+		query   = rd.Get("query").(string)
+		orderby = rd.Get("order_by").(string)
+		limit   = rd.Get("limit").(int)
+	)
+
+	tflog.Debug(ctx, "Performing metric time series search operation", tfext.NewLogFields().
+		Field("query", query).
+		Field("limit", limit).
+		Field("order_by", orderby),
+	)
+
+	results, count, err := searchAll(ctx, limit, PageLimit, func(ctx context.Context, size, offset int) (int, []map[string]any, error) {
+		resp, err := client.SearchMetricTimeSeries(ctx, query, orderby, size, offset)
+		if err != nil {
+			return 0, nil, err
+		}
+		values := make([]map[string]any, 0, len(resp.Results))
+		for _, mts := range resp.Results {
+			dims := make(map[string]any, len(mts.Dimensions))
+			for k, v := range mts.Dimensions {
+				dims[k] = fmt.Sprint(v)
+			}
+			values = append(values, map[string]any{
+				"id":           mts.Id,
+				"metric":       mts.Metric,
+				"type":         mts.Type,
+				"dimensions":   dims,
+				"active":       mts.Active,
+				"created":      int(mts.Created),
+				"last_updated": int(mts.LastUpdated),
+			})
+		}
+		return int(resp.Count), values, nil
+	})
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	rd.SetId(query)
+	if err := rd.Set("time_series", results); err != nil {
+		issues = tfext.AppendDiagnostics(issues, tfext.AsErrorDiagnostics(err)...)
+	}
+
+	return tfext.AppendDiagnostics(issues, truncatedDiagnostics(count, limit)...)
+}
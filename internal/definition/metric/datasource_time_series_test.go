@@ -0,0 +1,110 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+)
+
+func TestTimeSeriesDataSource(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		meta   func(t testing.TB) any
+		values []any
+		diags  diag.Diagnostics
+	}{
+		{
+			name: "no provider set",
+			meta: func(testing.TB) any {
+				return nil
+			},
+			values: []any{},
+			diags: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected to implement type Meta"},
+			},
+		},
+		{
+			name: "invalid request",
+			meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/metrictimeseries": func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+
+					http.Error(w, "unable to read metric time series", http.StatusBadRequest)
+				},
+			}),
+			values: []any{},
+			diags: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "route \"/v2/metrictimeseries\" had issues with status code 400"},
+			},
+		},
+		{
+			name: "returns metric time series",
+			meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/metrictimeseries": func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"count": 1,
+						"results": []map[string]any{
+							{
+								"id":          "AAAAAAAAAAA",
+								"metric":      "cpu.utilization",
+								"type":        "GAUGE",
+								"active":      true,
+								"created":     1600000000000,
+								"lastUpdated": 1700000000000,
+								"dimensions": map[string]any{
+									"service":   "checkout",
+									"sf_metric": "cpu.utilization",
+								},
+							},
+						},
+					})
+				},
+			}),
+			values: []any{
+				map[string]any{
+					"id":           "AAAAAAAAAAA",
+					"metric":       "cpu.utilization",
+					"type":         "GAUGE",
+					"active":       true,
+					"created":      1600000000000,
+					"last_updated": 1700000000000,
+					"dimensions": map[string]any{
+						"service":   "checkout",
+						"sf_metric": "cpu.utilization",
+					},
+				},
+			},
+			diags: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := NewTimeSeriesDataSource()
+
+			rd := data.TestResourceData()
+			_ = rd.Set("query", "service:checkout")
+			_ = rd.Set("limit", 2)
+
+			diags := data.ReadContext(context.Background(), rd, tc.meta(t))
+			assert.Equal(t, tc.diags, diags, "Must match the expected values")
+			assert.Equal(t, tc.values, rd.Get("time_series"), "Must match the expected values")
+		})
+	}
+}
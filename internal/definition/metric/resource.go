@@ -0,0 +1,162 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+const (
+	ResourceName = "signalfx_metric_metadata"
+)
+
+// NewResource manages the description, unit, tags and custom properties of a metric.
+//
+// Metrics are created by sending data and can not be deleted, so creating the resource
+// requires the metric to exist and destroying it only clears the metadata it manages.
+func NewResource() *schema.Resource {
+	return &schema.Resource{
+		SchemaFunc:    newResourceSchema,
+		CreateContext: resourceCreate,
+		ReadContext:   resourceRead,
+		UpdateContext: resourceUpdate,
+		DeleteContext: resourceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "Manages the description, unit, tags and custom properties of a metric shown in the metric catalog.",
+	}
+}
+
+func resourceCreate(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	m, err := decodeTerraform(data)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	wait := time.Duration(data.Get("wait_for_metric_seconds").(int)) * time.Second
+	existing, err := waitForMetric(ctx, client, m.Name, wait)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+	// The metric type is set by the data sent, so the reported type is kept.
+	m.Type = existing.Type
+
+	return updateMetric(ctx, client, m, data)
+}
+
+func resourceRead(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	m, err := client.GetMetric(ctx, data.Id())
+	if err != nil {
+		return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, data))
+	}
+
+	return tfext.AsErrorDiagnostics(encodeTerraform(m, data))
+}
+
+func resourceUpdate(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	m, err := decodeTerraform(data)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	return updateMetric(ctx, client, m, data)
+}
+
+func resourceDelete(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	tflog.Debug(ctx, "Clearing metric metadata", tfext.NewLogFields().
+		Field("name", data.Id()),
+	)
+
+	_, err = client.CreateUpdateMetric(ctx, data.Id(), &metrics_metadata.CreateUpdateMetricRequest{
+		Name: data.Id(),
+		Type: data.Get("type").(string),
+	})
+	return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, data))
+}
+
+func updateMetric(ctx context.Context, client *signalfx.Client, m *metrics_metadata.Metric, data *schema.ResourceData) diag.Diagnostics {
+	updated, err := client.CreateUpdateMetric(ctx, m.Name, &metrics_metadata.CreateUpdateMetricRequest{
+		Name:             m.Name,
+		Description:      m.Description,
+		CustomProperties: m.CustomProperties,
+		Tags:             m.Tags,
+		Type:             m.Type,
+	})
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+	return tfext.AsErrorDiagnostics(encodeTerraform(updated, data))
+}
+
+// waitForMetric returns the metric once it has been reported, checking again
+// until wait has passed. An error is returned straight away if wait is not set.
+func waitForMetric(ctx context.Context, client *signalfx.Client, name string, wait time.Duration) (*metrics_metadata.Metric, error) {
+	m, err := client.GetMetric(ctx, name)
+	if !isNotFound(err) {
+		return m, err
+	}
+	if wait <= 0 {
+		return nil, fmt.Errorf("metric %q has not been reported, send data for the metric before setting its metadata or set wait_for_metric_seconds to wait for it", name)
+	}
+
+	tflog.Info(ctx, "Waiting for metric to be reported", tfext.NewLogFields().
+		Field("name", name).
+		Duration("wait", wait),
+	)
+
+	err = retry.RetryContext(ctx, wait, func() *retry.RetryError {
+		m, err = client.GetMetric(ctx, name)
+		if isNotFound(err) {
+			return retry.RetryableError(err)
+		}
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		return nil
+	})
+	if isNotFound(err) {
+		return nil, fmt.Errorf("metric %q was not reported within %s, check that data is being sent for the metric", name, wait)
+	}
+	return m, err
+}
+
+func isNotFound(err error) bool {
+	re, ok := signalfx.AsResponseError(err)
+	return ok && re.Code() == http.StatusNotFound
+}
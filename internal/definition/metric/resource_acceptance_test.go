@@ -0,0 +1,50 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/metric"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+)
+
+func TestResourceAcceptance(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		steps []resource.TestStep
+	}{
+		{
+			name: "manage metric metadata",
+			steps: []resource.TestStep{
+				{
+					Config: tftest.LoadConfig("testdata/resource_metric_metadata.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("signalfx_metric_metadata.cpu", "id", "cpu.utilization"),
+						resource.TestCheckResourceAttr("signalfx_metric_metadata.cpu", "unit", "Second"),
+						resource.TestCheckResourceAttr("signalfx_metric_metadata.cpu", "custom_properties.team", "observability"),
+						resource.TestCheckResourceAttrSet("signalfx_metric_metadata.cpu", "type"),
+					),
+				},
+				{
+					ResourceName:            "signalfx_metric_metadata.cpu",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"wait_for_metric_seconds"},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tftest.NewAcceptanceHandler(
+				tftest.WithAcceptanceResources(map[string]*schema.Resource{
+					metric.ResourceName: metric.NewResource(),
+				}),
+			).Test(t, tc.steps)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+)
+
+func TestNewResource(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, NewResource(), "Must return a valid value")
+}
+
+func TestResourceCreate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[metrics_metadata.Metric]{
+		{
+			Name: "No provider",
+			Meta: func(_ testing.TB) any {
+				return nil
+			},
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input:    &metrics_metadata.Metric{Name: "cpu.utilization"},
+			Expect:   &metrics_metadata.Metric{Name: "cpu.utilization"},
+			Issues: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected to implement type Meta"},
+			},
+		},
+		{
+			Name: "Metric not reported",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/metric/cpu.utilization": func(w http.ResponseWriter, _ *http.Request) {
+					http.Error(w, "Not found", http.StatusNotFound)
+				},
+			}),
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input:    &metrics_metadata.Metric{Name: "cpu.utilization"},
+			Expect:   &metrics_metadata.Metric{Name: "cpu.utilization"},
+			Issues: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "metric \"cpu.utilization\" has not been reported, send data for the metric before setting its metadata or set wait_for_metric_seconds to wait for it"},
+			},
+		},
+		{
+			Name: "Successful create",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/metric/cpu.utilization": func(w http.ResponseWriter, _ *http.Request) {
+					_ = json.NewEncoder(w).Encode(&metrics_metadata.Metric{
+						Name: "cpu.utilization",
+						Type: "gauge",
+					})
+				},
+				"PUT /v2/metric/cpu.utilization": func(w http.ResponseWriter, r *http.Request) {
+					var req metrics_metadata.CreateUpdateMetricRequest
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+					_ = r.Body.Close()
+					_ = json.NewEncoder(w).Encode(&metrics_metadata.Metric{
+						Name:             req.Name,
+						Type:             req.Type,
+						Description:      req.Description,
+						CustomProperties: req.CustomProperties,
+						Tags:             req.Tags,
+					})
+				},
+			}),
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input: &metrics_metadata.Metric{
+				Name:             "cpu.utilization",
+				Description:      "Percentage of CPU used",
+				CustomProperties: map[string]string{"team": "infra", UnitPropertyName: "Second"},
+				Tags:             []string{"host"},
+			},
+			Expect: &metrics_metadata.Metric{
+				Name:             "cpu.utilization",
+				Type:             "gauge",
+				Description:      "Percentage of CPU used",
+				CustomProperties: map[string]string{"team": "infra", UnitPropertyName: "Second"},
+				Tags:             []string{"host"},
+			},
+			Issues: nil,
+		},
+	} {
+		tc.TestCreate(t)
+	}
+}
+
+func TestResourceRead(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[metrics_metadata.Metric]{
+		{
+			Name: "No provider",
+			Meta: func(_ testing.TB) any {
+				return nil
+			},
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input:    &metrics_metadata.Metric{Name: "cpu.utilization"},
+			Expect:   &metrics_metadata.Metric{Name: "cpu.utilization"},
+			Issues: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected to implement type Meta"},
+			},
+		},
+		{
+			Name: "Successful read",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/metric/cpu.utilization": func(w http.ResponseWriter, _ *http.Request) {
+					_ = json.NewEncoder(w).Encode(&metrics_metadata.Metric{
+						Name:        "cpu.utilization",
+						Type:        "gauge",
+						Description: "Updated elsewhere",
+					})
+				},
+			}),
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input: &metrics_metadata.Metric{
+				Name:        "cpu.utilization",
+				Description: "Percentage of CPU used",
+			},
+			Expect: &metrics_metadata.Metric{
+				Name:        "cpu.utilization",
+				Type:        "gauge",
+				Description: "Updated elsewhere",
+			},
+			Issues: nil,
+		},
+	} {
+		tc.TestRead(t)
+	}
+}
+
+func TestResourceDelete(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[metrics_metadata.Metric]{
+		{
+			Name: "Clears metadata",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"PUT /v2/metric/cpu.utilization": func(w http.ResponseWriter, r *http.Request) {
+					var req metrics_metadata.CreateUpdateMetricRequest
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+					_ = r.Body.Close()
+					if req.Description != "" || len(req.CustomProperties) != 0 || len(req.Tags) != 0 {
+						http.Error(w, "metadata was not cleared", http.StatusBadRequest)
+						return
+					}
+					_ = json.NewEncoder(w).Encode(&metrics_metadata.Metric{Name: req.Name, Type: req.Type})
+				},
+			}),
+			Resource: NewResource(),
+			Encoder:  encodeTerraform,
+			Decoder:  decodeTerraform,
+			Input: &metrics_metadata.Metric{
+				Name:        "cpu.utilization",
+				Type:        "gauge",
+				Description: "Percentage of CPU used",
+				Tags:        []string{"host"},
+			},
+			Expect: &metrics_metadata.Metric{
+				Name:        "cpu.utilization",
+				Type:        "gauge",
+				Description: "Percentage of CPU used",
+				Tags:        []string{"host"},
+			},
+			Issues: nil,
+		},
+	} {
+		tc.TestDelete(t)
+	}
+}
+
+func TestWaitForMetric(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		reported int32
+		wait     time.Duration
+		errVal   string
+	}{
+		{
+			name:     "metric already reported",
+			reported: 0,
+			wait:     0,
+			errVal:   "",
+		},
+		{
+			name:     "metric reported while waiting",
+			reported: 2,
+			wait:     30 * time.Second,
+			errVal:   "",
+		},
+		{
+			name:     "metric not reported in time",
+			reported: 1000,
+			wait:     time.Second,
+			errVal:   "metric \"cpu.utilization\" was not reported within 1s, check that data is being sent for the metric",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			meta := tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/metric/cpu.utilization": func(w http.ResponseWriter, _ *http.Request) {
+					if requests.Add(1) <= tc.reported {
+						http.Error(w, "Not found", http.StatusNotFound)
+						return
+					}
+					_ = json.NewEncoder(w).Encode(&metrics_metadata.Metric{Name: "cpu.utilization", Type: "gauge"})
+				},
+			})(t)
+
+			client, err := pmeta.LoadClient(context.Background(), meta)
+			require.NoError(t, err, "Must have a valid client")
+
+			m, err := waitForMetric(context.Background(), client, "cpu.utilization", tc.wait)
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+				return
+			}
+			require.NoError(t, err, "Must not error waiting for metric")
+			assert.Equal(t, "gauge", m.Type, "Must return the reported metric")
+		})
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// UnitPropertyName is the custom property used to store the unit of the metric,
+// since the metric metadata does not have a field for it.
+const UnitPropertyName = "unit"
+
+func newResourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Name of the metric to manage the metadata of",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Description of the metric shown in the metric catalog",
+		},
+		"unit": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: check.ValueUnit(),
+			Description:      "The unit of the metric values, stored as the `unit` custom property of the metric",
+		},
+		"custom_properties": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			ValidateDiagFunc: checkCustomProperties,
+			Description:      "Custom properties set on the metric, the `unit` property is set using the `unit` field",
+		},
+		"tags": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			Description: "Tags set on the metric",
+		},
+		"wait_for_metric_seconds": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Number of seconds to wait for the metric to be reported when it does not exist yet, the resource fails straight away when set to `0`",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The type of the metric, such as `gauge`, `counter` or `cumulative_counter`",
+		},
+	}
+}
+
+func checkCustomProperties(i any, p cty.Path) diag.Diagnostics {
+	props, ok := i.(map[string]any)
+	if !ok {
+		return tfext.AsErrorDiagnostics(fmt.Errorf("expected %v to be type map", i), p)
+	}
+	if _, exist := props[UnitPropertyName]; exist {
+		return tfext.AsErrorDiagnostics(
+			fmt.Errorf("custom property %q is reserved, use the unit field instead", UnitPropertyName),
+			p,
+		)
+	}
+	return nil
+}
+
+func decodeTerraform(rd *schema.ResourceData) (*metrics_metadata.Metric, error) {
+	m := &metrics_metadata.Metric{
+		Name:        rd.Get("name").(string),
+		Description: rd.Get("description").(string),
+		Type:        rd.Get("type").(string),
+	}
+	if props, ok := rd.Get("custom_properties").(map[string]any); ok && len(props) > 0 {
+		m.CustomProperties = make(map[string]string, len(props))
+		for k, v := range props {
+			m.CustomProperties[k] = v.(string)
+		}
+	}
+	if unit, ok := rd.Get("unit").(string); ok && unit != "" {
+		if m.CustomProperties == nil {
+			m.CustomProperties = make(map[string]string, 1)
+		}
+		m.CustomProperties[UnitPropertyName] = unit
+	}
+	if tags, ok := rd.Get("tags").(*schema.Set); ok && tags.Len() > 0 {
+		m.Tags = convert.SliceAll(tags.List(), convert.ToString)
+		slices.Sort(m.Tags)
+	}
+	return m, nil
+}
+
+func encodeTerraform(m *metrics_metadata.Metric, rd *schema.ResourceData) error {
+	rd.SetId(m.Name)
+	if err := rd.Set("name", m.Name); err != nil {
+		return err
+	}
+	if err := rd.Set("description", m.Description); err != nil {
+		return err
+	}
+	if err := rd.Set("type", m.Type); err != nil {
+		return err
+	}
+
+	props := maps.Clone(m.CustomProperties)
+	if err := rd.Set("unit", props[UnitPropertyName]); err != nil {
+		return err
+	}
+	delete(props, UnitPropertyName)
+	if err := rd.Set("custom_properties", props); err != nil {
+		return err
+	}
+
+	return rd.Set("tags", convert.SliceAll(m.Tags, convert.ToAny[string]))
+}
+
+func newSearchSchema(description string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"query": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  description,
+		},
+		"order_by": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The field to sort the results by, prefix with `-` to sort in descending order",
+		},
+		"limit": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      PageLimit,
+			ValidateFunc: validation.IntBetween(0, 10_000),
+			Description:  "The maximum number of results returned, a warning is reported when more results match the query",
+		},
+	}
+}
+
+func newMetricsSchema() map[string]*schema.Schema {
+	s := newSearchSchema("Query used to search metrics, such as `name:cpu.*` or `sf_metric:cpu.utilization`. " +
+		"Refer to https://dev.splunk.com/observability/reference/api/metrics_metadata/latest#endpoint-retrieve-metrics-metadata-using-query for more details")
+	s["metrics"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "List of the metrics that match the query, ordered by the order_by field",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the metric",
+				},
+				"type": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The type of the metric, such as `gauge`, `counter` or `cumulative_counter`",
+				},
+				"description": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Description of the metric",
+				},
+				"custom_properties": {
+					Type:        schema.TypeMap,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Custom properties set on the metric",
+				},
+				"tags": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Tags set on the metric",
+				},
+				"last_updated": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Timestamp in milliseconds of when the metric was last updated",
+				},
+			},
+		},
+	}
+	return s
+}
+
+func newTimeSeriesSchema() map[string]*schema.Schema {
+	s := newSearchSchema("Query used to search metric time series, such as `sf_metric:cpu.utilization AND service:checkout`. " +
+		"Refer to https://dev.splunk.com/observability/reference/api/metrics_metadata/latest#endpoint-retrieve-metric-timeseries-metadata for more details")
+	s["time_series"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "List of the metric time series that match the query, ordered by the order_by field",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the metric time series",
+				},
+				"metric": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the metric of the time series",
+				},
+				"type": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The type of the metric, such as `gauge`, `counter` or `cumulative_counter`",
+				},
+				"dimensions": {
+					Type:        schema.TypeMap,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "The dimensions of the metric time series",
+				},
+				"active": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Reports if data has been recently sent for the metric time series",
+				},
+				"created": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Timestamp in milliseconds of when the metric time series was first seen",
+				},
+				"last_updated": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Timestamp in milliseconds of when the metric time series was last updated",
+				},
+			},
+		},
+	}
+	return s
+}
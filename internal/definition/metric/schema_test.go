@@ -0,0 +1,97 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	assert.NotEmpty(t, newResourceSchema(), "Must have a defined schema returned")
+}
+
+func TestCheckCustomProperties(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		input  any
+		expect diag.Diagnostics
+	}{
+		{
+			name:   "no properties",
+			input:  map[string]any{},
+			expect: nil,
+		},
+		{
+			name:   "valid properties",
+			input:  map[string]any{"team": "infra"},
+			expect: nil,
+		},
+		{
+			name:  "reserved unit property",
+			input: map[string]any{"unit": "Second"},
+			expect: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "custom property \"unit\" is reserved, use the unit field instead", AttributePath: cty.GetAttrPath("custom_properties")},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := checkCustomProperties(tc.input, cty.GetAttrPath("custom_properties"))
+			assert.Equal(t, tc.expect, actual, "Must match the expected diagnostics")
+		})
+	}
+}
+
+func TestResourceSchemaEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		metric *metrics_metadata.Metric
+		unit   string
+	}{
+		{
+			name:   "name only",
+			metric: &metrics_metadata.Metric{Name: "cpu.utilization", Type: "gauge"},
+			unit:   "",
+		},
+		{
+			name: "all values set",
+			metric: &metrics_metadata.Metric{
+				Name:             "cpu.utilization",
+				Type:             "gauge",
+				Description:      "Percentage of CPU used",
+				CustomProperties: map[string]string{"team": "infra", UnitPropertyName: "Millisecond"},
+				Tags:             []string{"host", "infra"},
+			},
+			unit: "Millisecond",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rd := schema.TestResourceDataRaw(t, newResourceSchema(), map[string]any{})
+			require.NoError(t, encodeTerraform(tc.metric, rd), "Must not error encoding metric")
+			assert.Equal(t, "cpu.utilization", rd.Id(), "Must set the resource id")
+			assert.Equal(t, tc.unit, rd.Get("unit"), "Must set the unit from the custom properties")
+			assert.NotContains(t, rd.Get("custom_properties"), UnitPropertyName, "Must not duplicate the unit in custom properties")
+
+			actual, err := decodeTerraform(rd)
+			require.NoError(t, err, "Must not error decoding metric")
+			assert.Equal(t, tc.metric, actual, "Must match the encoded metric")
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+const (
+	// PageLimit is the default number of results
+	// returned within one response
+	PageLimit = 1000
+)
+
+// searchPageFunc returns the total number of matched results,
+// and the results of the page starting at offset.
+type searchPageFunc func(ctx context.Context, limit, offset int) (count int, results []map[string]any, err error)
+
+// searchAll requests pages of up to size results until limit results have been returned,
+// or there are no more results matching the query.
+func searchAll(ctx context.Context, limit, size int, search searchPageFunc) (results []map[string]any, count int, err error) {
+	results = make([]map[string]any, 0, min(limit, size))
+	for offset := 0; offset < limit; offset += size {
+		page := min(size, limit-offset)
+
+		tflog.Debug(ctx, "Performing search page operation", tfext.NewLogFields().
+			Field("limit", page).
+			Field("offset", offset),
+		)
+
+		total, values, err := search(ctx, page, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		count = total
+		results = append(results, values[:min(len(values), page)]...)
+		if len(values) < page || offset+page >= count {
+			break
+		}
+	}
+	return results, count, nil
+}
+
+// truncatedDiagnostics reports a warning when the query matched more than limit results.
+func truncatedDiagnostics(count, limit int) diag.Diagnostics {
+	if count <= limit {
+		return nil
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Number of matched results exceeds allowed returned limit, values truncated",
+			Detail:   "Adjust the query to be more selective or increase the limit to avoid this issue",
+		},
+	}
+}
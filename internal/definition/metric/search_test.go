@@ -0,0 +1,113 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package metric
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchAll(t *testing.T) {
+	t.Parallel()
+
+	newSearch := func(total int, requests *[][2]int) searchPageFunc {
+		return func(_ context.Context, limit, offset int) (int, []map[string]any, error) {
+			*requests = append(*requests, [2]int{limit, offset})
+			var values []map[string]any
+			for i := offset; i < min(offset+limit, total); i++ {
+				values = append(values, map[string]any{"index": i})
+			}
+			return total, values, nil
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		total    int
+		limit    int
+		size     int
+		results  int
+		requests [][2]int
+	}{
+		{
+			name:     "no results",
+			total:    0,
+			limit:    10,
+			size:     4,
+			results:  0,
+			requests: [][2]int{{4, 0}},
+		},
+		{
+			name:     "single page",
+			total:    3,
+			limit:    10,
+			size:     4,
+			results:  3,
+			requests: [][2]int{{4, 0}},
+		},
+		{
+			name:     "multiple pages",
+			total:    9,
+			limit:    10,
+			size:     4,
+			results:  9,
+			requests: [][2]int{{4, 0}, {4, 4}, {2, 8}},
+		},
+		{
+			name:     "truncated by limit",
+			total:    20,
+			limit:    6,
+			size:     4,
+			results:  6,
+			requests: [][2]int{{4, 0}, {2, 4}},
+		},
+		{
+			name:     "exact page boundary",
+			total:    8,
+			limit:    10,
+			size:     4,
+			results:  8,
+			requests: [][2]int{{4, 0}, {4, 4}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests [][2]int
+			results, count, err := searchAll(context.Background(), tc.limit, tc.size, newSearch(tc.total, &requests))
+			assert.NoError(t, err, "Must not error searching")
+			assert.Len(t, results, tc.results, "Must return the expected number of results")
+			assert.Equal(t, tc.total, count, "Must report the total matched results")
+			assert.Equal(t, tc.requests, requests, "Must request the expected pages")
+		})
+	}
+}
+
+func TestSearchAllError(t *testing.T) {
+	t.Parallel()
+
+	results, count, err := searchAll(context.Background(), 10, 4, func(context.Context, int, int) (int, []map[string]any, error) {
+		return 0, nil, errors.New("failed")
+	})
+	assert.EqualError(t, err, "failed", "Must return the search error")
+	assert.Nil(t, results, "Must not return results")
+	assert.Zero(t, count, "Must not return a count")
+}
+
+func TestTruncatedDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, truncatedDiagnostics(10, 10), "Must not warn when all results are returned")
+	assert.Equal(t, diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Number of matched results exceeds allowed returned limit, values truncated",
+			Detail:   "Adjust the query to be more selective or increase the limit to avoid this issue",
+		},
+	}, truncatedDiagnostics(11, 10), "Must warn when results are truncated")
+}
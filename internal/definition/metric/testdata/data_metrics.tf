@@ -0,0 +1,15 @@
+provider "signalfx" {}
+
+data "signalfx_metrics" "cpu" {
+  provider = signalfx
+
+  query = "name:cpu.*"
+  limit = 5
+}
+
+data "signalfx_metric_time_series" "cpu" {
+  provider = signalfx
+
+  query = "sf_metric:cpu.utilization"
+  limit = 5
+}
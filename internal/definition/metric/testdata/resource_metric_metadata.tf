@@ -0,0 +1,12 @@
+resource "signalfx_metric_metadata" "cpu" {
+  name        = "cpu.utilization"
+  description = "Percentage of CPU used by the host"
+  unit        = "Second"
+
+  custom_properties = {
+    team = "observability"
+  }
+  tags = ["terraform"]
+
+  wait_for_metric_seconds = 60
+}
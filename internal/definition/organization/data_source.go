@@ -35,15 +35,25 @@ func datasourceRead(ctx context.Context, rd *schema.ResourceData, meta any) diag
 	}
 
 	var (
-		hasher = fnv.New64()
-		users  []any
-		limit  = 1000
+		hasher  = fnv.New64()
+		users   []any
+		members = make(map[string]any)
+		limit   = 1000
+		queries []string
 	)
 
 	for _, email := range convert.SliceAll(rd.Get("emails").([]any), convert.ToString) {
 		_, _ = fmt.Fprint(hasher, email)
+		queries = append(queries, fmt.Sprintf("email:%s", email))
+	}
+	if len(queries) == 0 {
+		// No emails set returns all members of the organization.
+		queries = append(queries, "")
+	}
+
+	for _, query := range queries {
 		for offset := 0; ; offset += limit {
-			results, err := sfx.GetOrganizationMembers(ctx, limit, fmt.Sprintf("email:%s", email), offset, "-sf_timestamp")
+			results, err := sfx.GetOrganizationMembers(ctx, limit, query, offset, "-sf_timestamp")
 			if err != nil {
 				return tfext.AsErrorDiagnostics(err)
 			}
@@ -51,14 +61,18 @@ func datasourceRead(ctx context.Context, rd *schema.ResourceData, meta any) diag
 			for _, u := range results.Results {
 				tflog.Debug(ctx, "Retrieved user details", tfext.NewLogFields().JSON("user", u))
 				users = append(users, u.Id)
+				members[u.Email] = u.Id
 			}
 
-			if offset >= int(results.Count) {
+			if offset+limit >= int(results.Count) {
 				break
 			}
 		}
 	}
 	rd.SetId(strconv.FormatUint(hasher.Sum64(), 36))
 
+	if err := rd.Set("members", members); err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
 	return tfext.AsErrorDiagnostics(rd.Set("users", users))
 }
@@ -109,3 +109,36 @@ func TestDataSourceRead(t *testing.T) {
 		})
 	}
 }
+
+func TestDataSourceReadAllMembers(t *testing.T) {
+	t.Parallel()
+
+	meta := tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+		"GET /v2/organization/member": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+			_ = r.Body.Close()
+
+			if r.URL.Query().Get("query") != "" {
+				http.Error(w, "expected no query", http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&organization.MemberSearchResults{
+				Count: 2,
+				Results: []*organization.Member{
+					{FullName: "user 01", Id: "AAAAAAAA", Email: "user-01@example.com"},
+					{FullName: "user 02", Id: "BBBBBBBB", Email: "user-02@example.com"},
+				},
+			})
+		},
+	})
+
+	data := NewDataSource()
+	rd := data.TestResourceData()
+
+	assert.Nil(t, data.ReadContext(t.Context(), rd, meta(t)), "Must not report any issues")
+	assert.Equal(t, []any{"AAAAAAAA", "BBBBBBBB"}, rd.Get("users"), "Must return all members")
+	assert.Equal(t, map[string]any{
+		"user-01@example.com": "AAAAAAAA",
+		"user-02@example.com": "BBBBBBBB",
+	}, rd.Get("members"), "Must map the member emails to their ids")
+}
@@ -0,0 +1,276 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package organization
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/organization"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+const (
+	MemberResourceName = "signalfx_organization_member"
+)
+
+// MemberRoles are the role titles that can be assigned to a member.
+var MemberRoles = []string{"admin", "power", "usage", "read_only"}
+
+// NewMemberResource manages a member of the organization, inviting them
+// by email when they are not already a member and removing them on destroy.
+func NewMemberResource() *schema.Resource {
+	return &schema.Resource{
+		SchemaFunc:    newMemberSchema,
+		CreateContext: resourceMemberCreate,
+		ReadContext:   resourceMemberRead,
+		UpdateContext: resourceMemberUpdate,
+		DeleteContext: resourceMemberDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMemberImport,
+		},
+		Description: "Manages a member of the organization, inviting them by email and setting their admin flag and roles. Requires the supplied token to have Admin priviledges.",
+	}
+}
+
+func newMemberSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"email": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: check.Email,
+			DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+				return strings.EqualFold(old, new)
+			},
+			Description: "Email address of the member, an invite is sent when they are not already a member",
+		},
+		"full_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			DiffSuppressFunc: func(_, _, _ string, rd *schema.ResourceData) bool {
+				// The full name can only be set by the invite,
+				// after that it is managed by the member.
+				return rd.Id() != ""
+			},
+			Description: "Full name of the member, only used when the member is invited",
+		},
+		"admin": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether the member is an administrator of the organization, invited members are not administrators and existing members are left unchanged when not set",
+		},
+		"roles": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(MemberRoles, false),
+			},
+			Description: "Titles of the roles assigned to the member, the organization default roles are assigned when not set",
+		},
+	}
+}
+
+func decodeMember(rd *schema.ResourceData) (*Member, error) {
+	m := &Member{
+		ID:       rd.Id(),
+		Email:    rd.Get("email").(string),
+		FullName: rd.Get("full_name").(string),
+		Admin:    rd.Get("admin").(bool),
+	}
+	if roles, ok := rd.Get("roles").(*schema.Set); ok && roles.Len() > 0 {
+		titles := convert.SliceAll(roles.List(), convert.ToString)
+		slices.Sort(titles)
+		for _, title := range titles {
+			m.Roles = append(m.Roles, MemberRole{Title: title})
+		}
+	}
+	return m, nil
+}
+
+func encodeMember(m *Member, rd *schema.ResourceData) error {
+	rd.SetId(m.ID)
+	if err := rd.Set("email", m.Email); err != nil {
+		return err
+	}
+	if err := rd.Set("full_name", m.FullName); err != nil {
+		return err
+	}
+	if err := rd.Set("admin", m.Admin); err != nil {
+		return err
+	}
+	roles := make([]any, 0, len(m.Roles))
+	for _, r := range m.Roles {
+		roles = append(roles, r.Title)
+	}
+	return rd.Set("roles", roles)
+}
+
+func resourceMemberImport(ctx context.Context, rd *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	if !strings.Contains(rd.Id(), "@") {
+		return []*schema.ResourceData{rd}, nil
+	}
+
+	// Allows for importing using the email address, so that the member list
+	// from `signalfx_organization_members` can be imported in bulk.
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := findMember(ctx, client, rd.Id())
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("no organization member found with email %q", rd.Id())
+	}
+	rd.SetId(existing.Id)
+	return []*schema.ResourceData{rd}, nil
+}
+
+func resourceMemberCreate(ctx context.Context, rd *schema.ResourceData, meta any) diag.Diagnostics {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	desired, err := decodeMember(rd)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	existing, err := findMember(ctx, client, desired.Email)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	var id string
+	if existing != nil {
+		tflog.Info(ctx, "Adopting existing organization member", tfext.NewLogFields().
+			Field("id", existing.Id).
+			Field("email", existing.Email),
+		)
+		id = existing.Id
+	} else {
+		invited, err := client.InviteMember(ctx, &organization.CreateUpdateMemberRequest{
+			Email:    desired.Email,
+			FullName: desired.FullName,
+			Admin:    desired.Admin,
+		})
+		if err != nil {
+			return tfext.AsErrorDiagnostics(err)
+		}
+		tflog.Info(ctx, "Invited organization member", tfext.NewLogFields().
+			Field("id", invited.Id).
+			Field("email", invited.Email),
+		)
+		id = invited.Id
+	}
+	rd.SetId(id)
+
+	return applyMember(ctx, rd, meta, desired)
+}
+
+func resourceMemberRead(ctx context.Context, rd *schema.ResourceData, meta any) diag.Diagnostics {
+	m, err := getMember(ctx, meta, rd.Id())
+	if err != nil {
+		if common.HandleError(ctx, err, rd); rd.Id() == "" {
+			// The member no longer exists so it is only removed from state.
+			return nil
+		}
+		return tfext.AsErrorDiagnostics(err)
+	}
+	return tfext.AsErrorDiagnostics(encodeMember(m, rd))
+}
+
+func resourceMemberUpdate(ctx context.Context, rd *schema.ResourceData, meta any) diag.Diagnostics {
+	desired, err := decodeMember(rd)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+	return applyMember(ctx, rd, meta, desired)
+}
+
+func resourceMemberDelete(ctx context.Context, rd *schema.ResourceData, meta any) diag.Diagnostics {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	tflog.Info(ctx, "Removing organization member", tfext.NewLogFields().
+		Field("id", rd.Id()).
+		Field("email", rd.Get("email")),
+	)
+
+	err = client.DeleteMember(ctx, rd.Id())
+	return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, rd))
+}
+
+// applyMember sets the admin flag and roles of the member, the values
+// are left as they are when they are not set within the configuration.
+func applyMember(ctx context.Context, rd *schema.ResourceData, meta any, desired *Member) diag.Diagnostics {
+	current, err := getMember(ctx, meta, rd.Id())
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	update := &Member{Admin: desired.Admin, Roles: desired.Roles}
+	if cfg := rd.GetRawConfig(); cfg.IsKnown() && !cfg.IsNull() && cfg.GetAttr("admin").IsNull() {
+		update.Admin = current.Admin
+	}
+	if len(update.Roles) == 0 {
+		update.Roles = current.Roles
+	}
+
+	if current.Admin != update.Admin || !sameRoles(current.Roles, update.Roles) {
+		if current, err = updateMember(ctx, meta, rd.Id(), update); err != nil {
+			return tfext.AsErrorDiagnostics(err)
+		}
+	}
+
+	return tfext.AsErrorDiagnostics(encodeMember(current, rd))
+}
+
+// findMember returns the member with the matching email address,
+// or nil when the email address is not a member of the organization.
+func findMember(ctx context.Context, client *signalfx.Client, email string) (*organization.Member, error) {
+	results, err := client.GetOrganizationMembers(ctx, 10, fmt.Sprintf("email:%s", email), 0, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range results.Results {
+		if strings.EqualFold(m.Email, email) {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+func sameRoles(a, b []MemberRole) bool {
+	titles := func(roles []MemberRole) []string {
+		values := make([]string, 0, len(roles))
+		for _, r := range roles {
+			values = append(values, r.Title)
+		}
+		slices.Sort(values)
+		return values
+	}
+	return slices.Equal(titles(a), titles(b))
+}
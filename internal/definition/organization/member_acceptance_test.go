@@ -0,0 +1,50 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package organization
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+)
+
+func TestAcceptanceResourceMember(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		steps []resource.TestStep
+	}{
+		{
+			name: "invite member",
+			steps: []resource.TestStep{
+				{
+					Config: tftest.LoadConfig("testdata/resource_member.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrSet("signalfx_organization_member.example", "id"),
+						resource.TestCheckResourceAttr("signalfx_organization_member.example", "admin", "false"),
+						resource.TestCheckResourceAttr("signalfx_organization_member.example", "roles.#", "1"),
+					),
+				},
+				{
+					ResourceName:            "signalfx_organization_member.example",
+					ImportState:             true,
+					ImportStateId:           "terraform-acceptance@example.com",
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"full_name"},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tftest.NewAcceptanceHandler(
+				tftest.WithAcceptanceResources(map[string]*schema.Resource{
+					MemberResourceName: NewMemberResource(),
+				}),
+			).
+				Test(t, tc.steps)
+		})
+	}
+}
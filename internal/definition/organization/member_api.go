@@ -0,0 +1,56 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package organization
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+// Member is the organization member returned by the API,
+// including the roles that are assigned to the member.
+type Member struct {
+	ID       string       `json:"id,omitempty"`
+	Email    string       `json:"email,omitempty"`
+	FullName string       `json:"fullName,omitempty"`
+	Admin    bool         `json:"admin"`
+	Roles    []MemberRole `json:"roles,omitempty"`
+}
+
+// MemberRole is a role assigned to an organization member.
+type MemberRole struct {
+	Title string `json:"title"`
+}
+
+// getMember returns the member with the given id.
+//
+// Note: The go-sdk member does not include the assigned roles,
+// so the request is made directly until the client adopts them.
+func getMember(ctx context.Context, meta any, id string) (*Member, error) {
+	member := &Member{}
+	if err := doMemberRequest(ctx, meta, http.MethodGet, id, nil, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// updateMember sets the admin flag and roles of the member with the given id.
+//
+// Note: The go-sdk does not support updating members,
+// so the request is made directly until the client adopts it.
+func updateMember(ctx context.Context, meta any, id string, member *Member) (*Member, error) {
+	updated := &Member{}
+	body := &Member{Admin: member.Admin, Roles: member.Roles}
+	if err := doMemberRequest(ctx, meta, http.MethodPut, id, body, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func doMemberRequest(ctx context.Context, meta any, method, id string, body, out any) error {
+	return pmeta.DoRequest(ctx, meta, method, "/v2/organization/member/"+url.PathEscape(id), nil, body, out)
+}
@@ -0,0 +1,295 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package organization
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/signalfx/signalfx-go/organization"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+)
+
+// newMemberTestMeta sets the auth token so that the
+// direct member requests do not create a session token.
+func newMemberTestMeta(routes map[string]http.HandlerFunc) func(testing.TB) any {
+	newMeta := tftest.NewTestHTTPMockMeta(routes)
+	return func(tb testing.TB) any {
+		m := newMeta(tb).(*pmeta.Meta)
+		m.AuthToken = "test-token"
+		return m
+	}
+}
+
+func encodeJSON(v any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_ = r.Body.Close()
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func TestNewMemberResource(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, NewMemberResource().InternalValidate(nil, true), "Must be a valid resource")
+}
+
+func TestMemberCreate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[Member]{
+		{
+			Name: "No provider",
+			Meta: func(_ testing.TB) any {
+				return nil
+			},
+			Resource: NewMemberResource(),
+			Encoder:  encodeMember,
+			Decoder:  decodeMember,
+			Input:    &Member{Email: "user-01@example.com"},
+			Expect:   &Member{Email: "user-01@example.com"},
+			Issues: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected to implement type Meta"},
+			},
+		},
+		{
+			Name: "Invites new member",
+			Meta: newMemberTestMeta(map[string]http.HandlerFunc{
+				"GET /v2/organization/member": encodeJSON(&organization.MemberSearchResults{Count: 0}),
+				"POST /v2/organization/member": encodeJSON(&organization.Member{
+					Id:    "AAAAAAAA",
+					Email: "user-01@example.com",
+				}),
+				"GET /v2/organization/member/AAAAAAAA": encodeJSON(&Member{
+					ID:    "AAAAAAAA",
+					Email: "user-01@example.com",
+					Roles: []MemberRole{{Title: "usage"}},
+				}),
+				"PUT /v2/organization/member/AAAAAAAA": func(w http.ResponseWriter, r *http.Request) {
+					var m Member
+					if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+					_ = r.Body.Close()
+					m.ID, m.Email, m.FullName = "AAAAAAAA", "user-01@example.com", "User 01"
+					_ = json.NewEncoder(w).Encode(&m)
+				},
+			}),
+			Resource: NewMemberResource(),
+			Encoder:  encodeMember,
+			Decoder:  decodeMember,
+			Input: &Member{
+				Email:    "user-01@example.com",
+				FullName: "User 01",
+				Roles:    []MemberRole{{Title: "power"}},
+			},
+			Expect: &Member{
+				ID:       "AAAAAAAA",
+				Email:    "user-01@example.com",
+				FullName: "User 01",
+				Roles:    []MemberRole{{Title: "power"}},
+			},
+			Issues: nil,
+		},
+		{
+			Name: "Adopts existing member",
+			Meta: newMemberTestMeta(map[string]http.HandlerFunc{
+				"GET /v2/organization/member": encodeJSON(&organization.MemberSearchResults{
+					Count: 1,
+					Results: []*organization.Member{
+						{Id: "AAAAAAAA", Email: "User-01@example.com"},
+					},
+				}),
+				"GET /v2/organization/member/AAAAAAAA": encodeJSON(&Member{
+					ID:       "AAAAAAAA",
+					Email:    "user-01@example.com",
+					FullName: "User 01",
+					Admin:    true,
+					Roles:    []MemberRole{{Title: "admin"}},
+				}),
+			}),
+			Resource: NewMemberResource(),
+			Encoder:  encodeMember,
+			Decoder:  decodeMember,
+			Input: &Member{
+				Email: "user-01@example.com",
+				Admin: true,
+			},
+			Expect: &Member{
+				ID:       "AAAAAAAA",
+				Email:    "user-01@example.com",
+				FullName: "User 01",
+				Admin:    true,
+				Roles:    []MemberRole{{Title: "admin"}},
+			},
+			Issues: nil,
+		},
+		{
+			Name: "Failed invite",
+			Meta: newMemberTestMeta(map[string]http.HandlerFunc{
+				"GET /v2/organization/member": encodeJSON(&organization.MemberSearchResults{Count: 0}),
+				"POST /v2/organization/member": func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+					http.Error(w, "Forbidden", http.StatusForbidden)
+				},
+			}),
+			Resource: NewMemberResource(),
+			Encoder:  encodeMember,
+			Decoder:  decodeMember,
+			Input:    &Member{Email: "user-01@example.com"},
+			Expect:   &Member{Email: "user-01@example.com"},
+			Issues: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "route \"/v2/organization/member\" had issues with status code 403"},
+			},
+		},
+	} {
+		tc.TestCreate(t)
+	}
+}
+
+func TestMemberRead(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[Member]{
+		{
+			Name: "No provider",
+			Meta: func(_ testing.TB) any {
+				return nil
+			},
+			Resource: NewMemberResource(),
+			Encoder:  encodeMember,
+			Decoder:  decodeMember,
+			Input:    &Member{ID: "AAAAAAAA", Email: "user-01@example.com"},
+			Expect:   &Member{ID: "AAAAAAAA", Email: "user-01@example.com"},
+			Issues: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected to implement type Meta"},
+			},
+		},
+		{
+			Name: "Member removed",
+			Meta: newMemberTestMeta(map[string]http.HandlerFunc{
+				"GET /v2/organization/member/AAAAAAAA": func(w http.ResponseWriter, _ *http.Request) {
+					http.Error(w, "Not found", http.StatusNotFound)
+				},
+			}),
+			Resource: NewMemberResource(),
+			Encoder:  encodeMember,
+			Decoder:  decodeMember,
+			Input:    &Member{ID: "AAAAAAAA", Email: "user-01@example.com"},
+			Expect:   &Member{Email: "user-01@example.com"},
+			Issues:   nil,
+		},
+		{
+			Name: "Successful read",
+			Meta: newMemberTestMeta(map[string]http.HandlerFunc{
+				"GET /v2/organization/member/AAAAAAAA": encodeJSON(&Member{
+					ID:       "AAAAAAAA",
+					Email:    "user-01@example.com",
+					FullName: "User 01",
+					Roles:    []MemberRole{{Title: "read_only"}},
+				}),
+			}),
+			Resource: NewMemberResource(),
+			Encoder:  encodeMember,
+			Decoder:  decodeMember,
+			Input:    &Member{ID: "AAAAAAAA", Email: "user-01@example.com"},
+			Expect: &Member{
+				ID:       "AAAAAAAA",
+				Email:    "user-01@example.com",
+				FullName: "User 01",
+				Roles:    []MemberRole{{Title: "read_only"}},
+			},
+			Issues: nil,
+		},
+	} {
+		tc.TestRead(t)
+	}
+}
+
+func TestMemberDelete(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[Member]{
+		{
+			Name: "Removes member",
+			Meta: newMemberTestMeta(map[string]http.HandlerFunc{
+				"DELETE /v2/organization/member/AAAAAAAA": func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				},
+			}),
+			Resource: NewMemberResource(),
+			Encoder:  encodeMember,
+			Decoder:  decodeMember,
+			Input:    &Member{ID: "AAAAAAAA", Email: "user-01@example.com"},
+			Expect:   &Member{ID: "AAAAAAAA", Email: "user-01@example.com"},
+			Issues:   nil,
+		},
+	} {
+		tc.TestDelete(t)
+	}
+}
+
+func TestMemberImport(t *testing.T) {
+	t.Parallel()
+
+	meta := newMemberTestMeta(map[string]http.HandlerFunc{
+		"GET /v2/organization/member": encodeJSON(&organization.MemberSearchResults{
+			Count: 1,
+			Results: []*organization.Member{
+				{Id: "AAAAAAAA", Email: "user-01@example.com"},
+			},
+		}),
+	})
+
+	for _, tc := range []struct {
+		name   string
+		id     string
+		expect string
+		errVal string
+	}{
+		{name: "member id", id: "AAAAAAAA", expect: "AAAAAAAA"},
+		{name: "member email", id: "user-01@example.com", expect: "AAAAAAAA"},
+		{name: "unknown email", id: "user-02@example.com", errVal: "no organization member found with email \"user-02@example.com\""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rd := NewMemberResource().TestResourceData()
+			rd.SetId(tc.id)
+
+			actual, err := resourceMemberImport(t.Context(), rd, meta(t))
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+				return
+			}
+			require.NoError(t, err, "Must not error importing member")
+			require.Len(t, actual, 1, "Must return the imported member")
+			assert.Equal(t, tc.expect, actual[0].Id(), "Must match the expected member id")
+		})
+	}
+}
+
+func TestSameRoles(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, sameRoles(nil, []MemberRole{}), "Must match empty roles")
+	assert.True(t, sameRoles(
+		[]MemberRole{{Title: "power"}, {Title: "admin"}},
+		[]MemberRole{{Title: "admin"}, {Title: "power"}},
+	), "Must match roles in any order")
+	assert.False(t, sameRoles(
+		[]MemberRole{{Title: "power"}},
+		[]MemberRole{{Title: "usage"}},
+	), "Must not match different roles")
+}
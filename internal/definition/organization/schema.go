@@ -17,7 +17,8 @@ func newSchema() map[string]*schema.Schema {
 				Type:             schema.TypeString,
 				ValidateDiagFunc: check.Email,
 			},
-			Required: true,
+			Optional:    true,
+			Description: "Email addresses of the members to look up, all members of the organization are returned when not set",
 		},
 		"users": {
 			Type:     schema.TypeList,
@@ -26,5 +27,13 @@ func newSchema() map[string]*schema.Schema {
 				Type: schema.TypeString,
 			},
 		},
+		"members": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: "Map of the member email addresses to their user ID, which can be used to import `signalfx_organization_member` resources in bulk",
+		},
 	}
 }
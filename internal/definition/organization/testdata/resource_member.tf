@@ -0,0 +1,5 @@
+resource "signalfx_organization_member" "example" {
+  email     = "terraform-acceptance@example.com"
+  full_name = "Terraform Acceptance"
+  roles     = ["read_only"]
+}
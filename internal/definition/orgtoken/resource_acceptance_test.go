@@ -4,12 +4,15 @@
 package orgtoken_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/orgtoken"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
 )
 
@@ -44,3 +47,59 @@ func TestResourceAcceptance(t *testing.T) {
 		})
 	}
 }
+
+func TestRotationAcceptanceFakeAPI(t *testing.T) {
+	api := fakeapi.New()
+
+	var secret string
+	tftest.NewAcceptanceHandler(
+		tftest.WithAcceptanceResources(map[string]*schema.Resource{
+			orgtoken.ResourceName:         orgtoken.NewResource(),
+			orgtoken.RotationResourceName: orgtoken.NewRotationResource(),
+		}),
+		tftest.WithAcceptanceFakeAPI(api),
+	).
+		Test(t, []resource.TestStep{
+			{
+				Config: tftest.LoadConfig("testdata/rotation.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("signalfx_org_token_rotation.ingest", "name", "collector-token"),
+					resource.TestCheckResourceAttrPair("signalfx_org_token_rotation.ingest", "secret", "signalfx_org_token.ingest", "secret"),
+					resource.TestCheckResourceAttr("signalfx_org_token_rotation.ingest", "previous_secret", ""),
+					resource.TestCheckResourceAttrSet("signalfx_org_token_rotation.ingest", "rotated_at"),
+					resource.TestCheckResourceAttrWith("signalfx_org_token_rotation.ingest", "secret", func(value string) error {
+						secret = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: tftest.LoadConfig("testdata/rotation_updated.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("signalfx_org_token_rotation.ingest", "secret", func(value string) error {
+						if value == secret {
+							return fmt.Errorf("expected the secret to be rotated")
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("signalfx_org_token_rotation.ingest", "previous_secret", func(value string) error {
+						if value != secret {
+							return fmt.Errorf("expected the previous secret to be %q, got %q", secret, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrSet("signalfx_org_token_rotation.ingest", "previous_secret_expires_at"),
+				),
+			},
+			{
+				Config:  tftest.LoadConfig("testdata/rotation_updated.tf"),
+				Destroy: true,
+				Check: func(*terraform.State) error {
+					if n := api.Len(fakeapi.OrgTokens); n != 0 {
+						return fmt.Errorf("expected all tokens to be deleted, found %d", n)
+					}
+					return nil
+				},
+			},
+		})
+}
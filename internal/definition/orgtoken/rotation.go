@@ -0,0 +1,224 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package orgtoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.uber.org/multierr"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+const (
+	RotationResourceName = "signalfx_org_token_rotation"
+)
+
+// NewRotationResource manages the secret of an existing org token,
+// rotating it whenever a keeper changes or the rotation period has elapsed.
+func NewRotationResource() *schema.Resource {
+	return &schema.Resource{
+		SchemaFunc:    newRotationSchema,
+		ReadContext:   resourceRotationRead,
+		CreateContext: resourceRotationCreate,
+		UpdateContext: resourceRotationUpdate,
+		DeleteContext: resourceRotationDelete,
+		CustomizeDiff: resourceRotationCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func newRotationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the existing org token to rotate",
+		},
+		"keepers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Arbitrary values that rotate the token secret when changed",
+		},
+		"rotation_days": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Number of days after the last rotation that the token secret is rotated on the next apply",
+		},
+		"grace_period_seconds": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Number of seconds the previous secret remains valid after a rotation. Defaults to `0`",
+		},
+		"secret": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The current secret of the token",
+		},
+		"previous_secret": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The secret replaced by the last rotation, set until the grace period has passed",
+		},
+		"previous_secret_expires_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The RFC3339 timestamp of when the previous secret is no longer valid",
+		},
+		"rotated_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The RFC3339 timestamp of the last rotation, or when the resource was created",
+		},
+	}
+}
+
+// rotationState is the shared read methods of
+// [schema.ResourceData] and [schema.ResourceDiff].
+type rotationState interface {
+	Get(key string) any
+	GetChange(key string) (any, any)
+	HasChange(key string) bool
+}
+
+// rotationRequired reports if the token secret needs to be rotated,
+// which is when a keeper has changed or the rotation period has elapsed.
+func rotationRequired(state rotationState, now time.Time) bool {
+	if state.HasChange("keepers") {
+		return true
+	}
+	days := state.Get("rotation_days").(int)
+	if days <= 0 {
+		return false
+	}
+	last, _ := state.GetChange("rotated_at")
+	rotated, err := time.Parse(time.RFC3339, last.(string))
+	if err != nil {
+		// The last rotation is unknown, for example after an import,
+		// so rotate to ensure the period is respected from now on.
+		return true
+	}
+	return !now.Before(rotated.AddDate(0, 0, days))
+}
+
+func resourceRotationCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, _ any) error {
+	if diff.Id() == "" || !rotationRequired(diff, time.Now()) {
+		return nil
+	}
+	tflog.Debug(ctx, "Org token secret will be rotated", tfext.NewLogFields().Field("name", diff.Id()))
+	return multierr.Combine(
+		diff.SetNewComputed("secret"),
+		diff.SetNewComputed("previous_secret"),
+		diff.SetNewComputed("previous_secret_expires_at"),
+		diff.SetNewComputed("rotated_at"),
+	)
+}
+
+func resourceRotationCreate(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	sfx, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	// Creating the resource adopts the current secret,
+	// the first rotation happens once it is required.
+	token, err := sfx.GetOrgToken(ctx, data.Get("name").(string))
+	if err != nil {
+		return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, data))
+	}
+
+	data.SetId(token.Name)
+	return tfext.AsErrorDiagnostics(multierr.Combine(
+		data.Set("secret", token.Secret),
+		data.Set("previous_secret", ""),
+		data.Set("previous_secret_expires_at", ""),
+		data.Set("rotated_at", time.Now().UTC().Format(time.RFC3339)),
+	))
+}
+
+func resourceRotationRead(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	sfx, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	token, err := sfx.GetOrgToken(ctx, data.Id())
+	if err != nil {
+		return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, data))
+	}
+
+	errs := multierr.Combine(
+		data.Set("name", token.Name),
+		data.Set("secret", token.Secret),
+	)
+
+	if expires, err := time.Parse(time.RFC3339, data.Get("previous_secret_expires_at").(string)); err == nil && !time.Now().Before(expires) {
+		tflog.Debug(ctx, "Grace period has passed, removing previous secret", tfext.NewLogFields().Field("name", token.Name))
+		errs = multierr.Append(errs, multierr.Combine(
+			data.Set("previous_secret", ""),
+			data.Set("previous_secret_expires_at", ""),
+		))
+	}
+
+	return tfext.AsErrorDiagnostics(errs)
+}
+
+func resourceRotationUpdate(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	now := time.Now().UTC()
+	if !rotationRequired(data, now) {
+		return resourceRotationRead(ctx, data, meta)
+	}
+
+	var (
+		grace     = data.Get("grace_period_seconds").(int)
+		before, _ = data.GetChange("secret")
+	)
+
+	token, err := rotateOrgToken(ctx, meta, data.Id(), grace)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, data))
+	}
+
+	tflog.Info(ctx, "Rotated org token secret", tfext.NewLogFields().
+		Field("name", data.Id()).
+		Field("grace_period_seconds", grace),
+	)
+
+	previous, expires := "", ""
+	if grace > 0 {
+		previous = before.(string)
+		expires = now.Add(time.Duration(grace) * time.Second).Format(time.RFC3339)
+	}
+
+	return tfext.AsErrorDiagnostics(multierr.Combine(
+		data.Set("secret", token.Secret),
+		data.Set("previous_secret", previous),
+		data.Set("previous_secret_expires_at", expires),
+		data.Set("rotated_at", now.Format(time.RFC3339)),
+	))
+}
+
+func resourceRotationDelete(ctx context.Context, data *schema.ResourceData, _ any) diag.Diagnostics {
+	// The token is owned by `signalfx_org_token` or created outside of Terraform,
+	// so removing the rotation only stops managing the secret.
+	tflog.Debug(ctx, "Removing org token rotation from state", tfext.NewLogFields().Field("name", data.Id()))
+	data.SetId("")
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package orgtoken
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/signalfx/signalfx-go/orgtoken"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+// rotateOrgToken replaces the secret of the named token, the previous secret
+// remains valid for the grace period (in seconds) once the new secret is issued.
+//
+// Note: The rotate endpoint is not available as part of the go-sdk,
+// so the request is made directly until the client adopts it.
+func rotateOrgToken(ctx context.Context, meta any, name string, grace int) (*orgtoken.Token, error) {
+	token := &orgtoken.Token{}
+	err := pmeta.DoRequest(ctx, meta, http.MethodPost,
+		"/v2/token/"+url.PathEscape(name)+"/rotate",
+		url.Values{"graceful": {strconv.Itoa(grace)}},
+		nil,
+		token,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
@@ -0,0 +1,117 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package orgtoken
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+type mockRotationState struct {
+	values  map[string]any
+	changed map[string]bool
+}
+
+func (m mockRotationState) Get(key string) any { return m.values[key] }
+
+func (m mockRotationState) GetChange(key string) (any, any) { return m.values[key], m.values[key] }
+
+func (m mockRotationState) HasChange(key string) bool { return m.changed[key] }
+
+func TestNewRotationResource(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, NewRotationResource().InternalValidate(nil, true), "Must be a valid resource")
+}
+
+func TestRotationRequired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name   string
+		state  mockRotationState
+		expect bool
+	}{
+		{
+			name: "keepers changed",
+			state: mockRotationState{
+				values:  map[string]any{"rotation_days": 0, "rotated_at": now.Format(time.RFC3339)},
+				changed: map[string]bool{"keepers": true},
+			},
+			expect: true,
+		},
+		{
+			name: "no rotation period",
+			state: mockRotationState{
+				values: map[string]any{"rotation_days": 0, "rotated_at": ""},
+			},
+			expect: false,
+		},
+		{
+			name: "within rotation period",
+			state: mockRotationState{
+				values: map[string]any{"rotation_days": 30, "rotated_at": now.AddDate(0, 0, -29).Format(time.RFC3339)},
+			},
+			expect: false,
+		},
+		{
+			name: "rotation period elapsed",
+			state: mockRotationState{
+				values: map[string]any{"rotation_days": 30, "rotated_at": now.AddDate(0, 0, -30).Format(time.RFC3339)},
+			},
+			expect: true,
+		},
+		{
+			name: "unknown last rotation",
+			state: mockRotationState{
+				values: map[string]any{"rotation_days": 30, "rotated_at": ""},
+			},
+			expect: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expect, rotationRequired(tc.state, now), "Must match the expected result")
+		})
+	}
+}
+
+func TestRotateOrgToken(t *testing.T) {
+	t.Parallel()
+
+	api := fakeapi.New()
+	_, err := api.Seed(fakeapi.OrgTokens, map[string]any{"name": "my token", "secret": "original"})
+	require.NoError(t, err, "Must seed the token")
+
+	s := httptest.NewServer(api)
+	t.Cleanup(s.Close)
+
+	meta := &pmeta.Meta{APIURL: s.URL, AuthToken: t.Name()}
+
+	_, err = rotateOrgToken(t.Context(), nil, "my token", 0)
+	assert.ErrorIs(t, err, pmeta.ErrMetaNotProvided, "Must error without a configured provider")
+
+	_, err = rotateOrgToken(t.Context(), meta, "missing", 0)
+	assert.EqualError(t, err, "route \"/v2/token/missing/rotate\" had issues with status code 404", "Must report the failed route")
+
+	token, err := rotateOrgToken(t.Context(), meta, "my token", 3600)
+	require.NoError(t, err, "Must rotate the token")
+	assert.Equal(t, "my token", token.Name, "Must return the rotated token")
+	assert.NotEqual(t, "original", token.Secret, "Must return the new secret")
+
+	stored, ok := api.Get(fakeapi.OrgTokens, "my token")
+	require.True(t, ok, "Must keep the token")
+	assert.Equal(t, "original", stored["previousSecret"], "Must have rotated the original secret")
+	assert.EqualValues(t, 3600, stored["previousSecretGracePeriod"], "Must send the grace period")
+}
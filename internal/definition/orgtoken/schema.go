@@ -43,7 +43,13 @@ func newSchema() map[string]*schema.Schema {
 			Type:        schema.TypeString,
 			Computed:    true,
 			Sensitive:   true,
-			Description: "The value of the token used for API actions.",
+			Description: "The value of the token used for API actions, left empty when `exclude_secret_from_state` is set.",
+		},
+		"exclude_secret_from_state": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Keeps the token secret out of state when set to `true`, use the `signalfx_org_token_secret` ephemeral resource to read the secret when it is needed. Defaults to `false`",
 		},
 		"notifications": {
 			Type:     schema.TypeList,
@@ -52,8 +58,10 @@ func newSchema() map[string]*schema.Schema {
 				Type:             schema.TypeString,
 				ValidateDiagFunc: check.Notification(),
 			},
-			Description: "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
+			ConflictsWith: []string{"notification"},
+			Description:   "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
 		},
+		"notification": newNotificationBlockSchema(),
 		"host_or_usage_limits": {
 			Type:          schema.TypeSet,
 			Optional:      true,
@@ -156,13 +164,13 @@ func decodeTerraform(data *schema.ResourceData) (*orgtoken.Token, error) {
 		}
 	}
 
-	if values, ok := data.GetOk("notifications"); ok {
-		notifys, err := common.NewNotificationList(values.([]any))
-		if err != nil {
-			return nil, err
-		}
-		token.Notifications = notifys
+	strs, _ := data.Get("notifications").([]any)
+	blocks, _ := data.Get("notification").([]any)
+	notifys, err := common.NewNotificationListFromEither(strs, blocks)
+	if err != nil {
+		return nil, err
 	}
+	token.Notifications = notifys
 
 	if v, ok := data.GetOk("host_or_usage_limits"); ok {
 		limits := v.(*schema.Set).List()[0].(map[string]any)
@@ -194,8 +202,8 @@ func decodeTerraform(data *schema.ResourceData) (*orgtoken.Token, error) {
 }
 
 func encodeTerraform(token *orgtoken.Token, data *schema.ResourceData) error {
-	notifys, err := common.NewNotificationStringList(token.Notifications)
-	if err != nil {
+	notifys := make(map[string]any)
+	if err := common.EncodeNotificationFields(notifys, token.Notifications, common.UsesNotificationBlocks(data.Get("notification"))); err != nil {
 		return fmt.Errorf("notifications: %w", err)
 	}
 
@@ -204,8 +212,9 @@ func encodeTerraform(token *orgtoken.Token, data *schema.ResourceData) error {
 		data.Set("description", token.Description),
 		data.Set("disabled", token.Disabled),
 		data.Set("auth_scopes", token.AuthScopes),
-		data.Set("notifications", notifys),
-		data.Set("secret", token.Secret),
+		data.Set("notifications", notifys[common.NotificationStringField]),
+		data.Set("notification", notifys[common.NotificationBlockField]),
+		data.Set("secret", stateSecret(token, data)),
 		data.Set("expires_at", token.Expiry),
 	)
 
@@ -253,3 +262,17 @@ func encodeTerraform(token *orgtoken.Token, data *schema.ResourceData) error {
 }
 
 func unsetThreshold(v int64) bool { return v != -1 }
+
+func newNotificationBlockSchema() *schema.Schema {
+	s := common.NewNotificationBlockSchema("Typed notification destinations to use when an incident occurs, an alternative to `notifications`")
+	s.ConflictsWith = []string{"notifications"}
+	return s
+}
+
+// stateSecret returns the token secret that should be stored in state.
+func stateSecret(token *orgtoken.Token, data *schema.ResourceData) string {
+	if data.Get("exclude_secret_from_state").(bool) {
+		return ""
+	}
+	return token.Secret
+}
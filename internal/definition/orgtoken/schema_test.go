@@ -106,6 +106,27 @@ func TestSchemaDecode(t *testing.T) {
 			},
 			errVal: "",
 		},
+		{
+			name: "notification blocks set",
+			values: map[string]any{
+				"name": "my awesome token",
+				"notification": []any{
+					map[string]any{
+						"webhook": []any{
+							map[string]any{"url": "https://example.com/hook", "secret": "shh"},
+						},
+					},
+				},
+			},
+			expect: &orgtoken.Token{
+				Name:   "my awesome token",
+				Limits: &orgtoken.Limit{},
+				Notifications: []*notification.Notification{
+					{Type: "Webhook", Value: &notification.WebhookNotification{Type: "Webhook", Secret: "shh", Url: "https://example.com/hook"}},
+				},
+			},
+			errVal: "",
+		},
 		{
 			name: "invalid notification",
 			values: map[string]any{
@@ -136,7 +157,9 @@ func TestSchemaEncode(t *testing.T) {
 
 	for _, tc := range []struct {
 		name   string
+		values map[string]any
 		token  *orgtoken.Token
+		expect string
 		errVal string
 	}{
 		{
@@ -144,6 +167,17 @@ func TestSchemaEncode(t *testing.T) {
 			token:  &orgtoken.Token{},
 			errVal: "",
 		},
+		{
+			name:   "secret stored in state",
+			token:  &orgtoken.Token{Name: "my awesome token", Secret: "aabb"},
+			expect: "aabb",
+		},
+		{
+			name:   "secret excluded from state",
+			values: map[string]any{"exclude_secret_from_state": true},
+			token:  &orgtoken.Token{Name: "my awesome token", Secret: "aabb"},
+			expect: "",
+		},
 		{
 			name:   "broken notifications",
 			token:  &orgtoken.Token{Notifications: []*notification.Notification{{Type: "broken"}}},
@@ -178,13 +212,14 @@ func TestSchemaEncode(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			data := schema.TestResourceDataRaw(t, newSchema(), map[string]any{})
+			data := schema.TestResourceDataRaw(t, newSchema(), tc.values)
 
 			err := encodeTerraform(tc.token, data)
 			if tc.errVal != "" {
 				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
 			} else {
 				assert.NoError(t, err, "Must not error")
+				assert.Equal(t, tc.expect, data.Get("secret"), "Must match the expected secret in state")
 			}
 		})
 	}
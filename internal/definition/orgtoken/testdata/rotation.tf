@@ -0,0 +1,13 @@
+resource "signalfx_org_token" "ingest" {
+  name        = "collector-token"
+  auth_scopes = ["INGEST"]
+}
+
+resource "signalfx_org_token_rotation" "ingest" {
+  name                 = signalfx_org_token.ingest.name
+  grace_period_seconds = 3600
+
+  keepers = {
+    release = "v1"
+  }
+}
@@ -6,78 +6,117 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net"
-	"net/http"
-	"time"
 
-	"github.com/hashicorp/go-retryablehttp"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/signalfx/signalfx-go"
 
-	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/autoarchiveexemptmetric"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/autoarchivesettings"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/detector"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/dimension"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/metric"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/organization"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/orgtoken"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/team"
-	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
-	"github.com/splunk-terraform/terraform-provider-signalfx/internal/track"
 	"github.com/splunk-terraform/terraform-provider-signalfx/version"
 )
 
 func New() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"auth_token": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ConflictsWith: []string{"email", "password", "organization_id"},
-				DefaultFunc:   schema.EnvDefaultFunc("SFX_AUTH_TOKEN", ""),
-				Description:   "Splunk Observability Cloud auth token",
+				Description:   "Splunk Observability Cloud auth token, can also be set with `SFX_AUTH_TOKEN`",
 			},
 			"api_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("SFX_API_URL", "https://api.signalfx.com"),
-				Description: "API URL for your Splunk Observability Cloud org, may include a realm",
+				Description: "API URL for your Splunk Observability Cloud org, may include a realm. Defaults to https://api.signalfx.com",
+			},
+			"realm": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"api_url"},
+				ValidateFunc:  validation.StringInSlice(pmeta.Realms, false),
+				Description:   "Realm of your Splunk Observability Cloud org, used to derive the API and application URLs. Conflicts with `api_url`",
 			},
 			"custom_app_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("SFX_CUSTOM_APP_URL", "https://app.signalfx.com"),
-				Description: "Application URL for your Splunk Observability Cloud org, often customized for organizations using SSO",
+				Description: "Application URL for your Splunk Observability Cloud org, often customized for organizations using SSO. Defaults to https://app.signalfx.com",
 			},
 			"timeout_seconds": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     120,
 				Description: "Timeout duration for a single HTTP call in seconds. Defaults to 120",
 			},
 			"retry_max_attempts": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     4,
 				Description: "Max retries for a single HTTP call. Defaults to 4",
 			},
 			"retry_wait_min_seconds": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     1,
 				Description: "Minimum retry wait for a single HTTP call in seconds. Defaults to 1",
 			},
 			"retry_wait_max_seconds": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     30,
 				Description: "Maximum retry wait for a single HTTP call in seconds. Defaults to 30",
 			},
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of API requests made per second, the rate is lowered while the API responds with `429 Too Many Requests`. Defaults to 0 which does not limit the rate",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of API requests made at the same time. Defaults to 0 which does not limit the number of requests",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the proxy used for API requests, which may include the proxy credentials. Defaults to the `HTTPS_PROXY` and `NO_PROXY` environment variables",
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "Path to a PEM encoded certificate authority bundle that is trusted along with the system certificates. Conflicts with `ca_cert_pem`",
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM encoded certificate authority bundle that is trusted along with the system certificates. Conflicts with `ca_cert_file`",
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key"},
+				Description:  "PEM encoded client certificate, or the path to one, used for mutual TLS. Requires `client_key`",
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_cert"},
+				Description:  "PEM encoded private key of the client certificate, or the path to one. Requires `client_cert`",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skips verifying the certificate of the API, only intended for testing. Defaults to false",
+			},
 			"email": {
 				Type:          schema.TypeString,
 				Optional:      true,
@@ -96,6 +135,11 @@ func New() *schema.Provider {
 				ConflictsWith: []string{"auth_token"},
 				Description:   "Required if the user is configured to be part of multiple organizations",
 			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the profile to read from the provider configuration files, can also be set with `SFX_PROFILE`",
+			},
 			"feature_preview": {
 				Type: schema.TypeMap,
 				Elem: &schema.Schema{
@@ -128,106 +172,49 @@ func New() *schema.Provider {
 			detector.ResourceName:                detector.NewResource(),
 			autoarchivesettings.ResourceName:     autoarchivesettings.NewResource(),
 			autoarchiveexemptmetric.ResourceName: autoarchiveexemptmetric.NewResource(),
+			orgtoken.RotationResourceName:        orgtoken.NewRotationResource(),
+			dimension.ResourceName:               dimension.NewResource(),
+			metric.ResourceName:                  metric.NewResource(),
+			organization.MemberResourceName:      organization.NewMemberResource(),
+			team.MembershipResourceName:          team.NewMembershipResource(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			dimension.DataSourceName:    dimension.NewDataSource(),
-			organization.DataSourceName: organization.NewDataSource(),
+			dimension.DataSourceName:        dimension.NewDataSource(),
+			metric.MetricsDataSourceName:    metric.NewMetricsDataSource(),
+			metric.TimeSeriesDataSourceName: metric.NewTimeSeriesDataSource(),
+			organization.DataSourceName:     organization.NewDataSource(),
 		},
+		ProviderMetaSchema:   pmeta.NewProviderMetaSchema(),
 		ConfigureContextFunc: configureProvider,
 	}
-}
-
-func configureProvider(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
-	meta := &pmeta.Meta{
-		Email:          data.Get("email").(string),
-		Password:       data.Get("password").(string),
-		OrganizationID: data.Get("organization_id").(string),
-		Tags:           convert.SliceAll(data.Get("tags").([]any), convert.ToString),
-		Teams:          convert.SliceAll(data.Get("teams").([]any), convert.ToString),
-	}
 
-	for _, lookup := range pmeta.NewDefaultProviderLookups() {
-		if err := lookup.Do(ctx, meta); err != nil {
-			tflog.Debug(
-				ctx,
-				"Issue trying to load external provider configuration, skipping",
-				tfext.ErrorLogFields(err),
-			)
-		}
-	}
-
-	if token, ok := data.GetOk("auth_token"); ok {
-		meta.AuthToken = token.(string)
-	}
-	if url, ok := data.GetOk("api_url"); ok {
-		meta.APIURL = url.(string)
+	for name, res := range p.ResourcesMap {
+		pmeta.DecorateProviderMeta(res)
+		tfext.DecorateResourceAddress(name, res)
 	}
-	if url, ok := data.GetOk("custom_app_url"); ok {
-		meta.CustomAppURL = url.(string)
+	for name, ds := range p.DataSourcesMap {
+		tfext.DecorateResourceAddress(name, ds)
 	}
+	return p
+}
 
-	err := meta.Validate()
-	if err != nil {
-		return nil, tfext.AsErrorDiagnostics(err)
-	}
-
-	var (
-		attempts = data.Get("retry_max_attempts").(int)
-		timeout  = time.Duration(int64(data.Get("timeout_seconds").(int))) * time.Second
-		waitmin  = time.Duration(int64(data.Get("retry_wait_min_seconds").(int))) * time.Second
-		waitmax  = time.Duration(int64((data.Get("retry_wait_max_seconds").(int)))) * time.Second
-	)
-
-	token, err := meta.LoadSessionToken(ctx)
+func configureProvider(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
+	meta, err := pmeta.NewResolver().Resolve(ctx, pmeta.NewConfigFromResourceData(data))
 	if err != nil {
 		return nil, tfext.AsErrorDiagnostics(err)
 	}
 
-	rc := retryablehttp.NewClient()
-	rc.RetryMax = attempts
-	rc.RetryWaitMin = waitmin
-	rc.RetryWaitMax = waitmax
-	rc.HTTPClient.Timeout = timeout
-	rc.HTTPClient.Transport = logging.NewSubsystemLoggingHTTPTransport("signalfx", &http.Transport{
-		Proxy:               http.ProxyFromEnvironment,
-		DialContext:         (&net.Dialer{Timeout: 5 * time.Second}).DialContext,
-		TLSHandshakeTimeout: 5 * time.Second,
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 100,
-	})
-
-	meta.Client, err = signalfx.NewClient(
-		token,
-		signalfx.APIUrl(meta.APIURL),
-		signalfx.HTTPClient(rc.StandardClient()),
-		signalfx.UserAgent(fmt.Sprintf("Terraform terraform-provider-signalfx/%s", version.ProviderVersion)),
+	diags, err := meta.Finalize(ctx,
+		fmt.Sprintf("Terraform terraform-provider-signalfx/%s", version.ProviderVersion),
+		tfext.NewHTTPLogger("signalfx"),
 	)
-
+	previews := pmeta.PreviewSDKDiagnostics(diags)
 	if err != nil {
-		return nil, tfext.AsErrorDiagnostics(err)
+		return nil, tfext.AppendDiagnostics(previews, tfext.AsErrorDiagnostics(err)...)
 	}
-
-	tflog.Debug(ctx, "Configured settings for http client", tfext.NewLogFields().
-		Field("attempts", attempts).
-		Duration("timeout", timeout).
-		Duration("wait_min", waitmin).
-		Duration("wait_max", waitmax),
-	)
-
-	for feat, val := range data.Get("feature_preview").(map[string]any) {
-		if err := pmeta.LoadPreviewRegistry(ctx, meta).Configure(ctx, feat, val.(bool)); err != nil {
-			return nil, tfext.AsWarnDiagnostics(err)
-		}
-	}
-
-	if gate, ok := pmeta.LoadPreviewRegistry(ctx, meta).Get(feature.PreviewProviderTracking); ok && gate.Enabled() {
-		tracking, err := track.ReadGitDetails(ctx)
-		if err != nil {
-			tflog.Info(ctx, "Unable to load git details, skipping", tfext.ErrorLogFields(err))
-		} else {
-			meta.Tags = append(meta.Tags, tracking.Tags()...)
-		}
+	if previews.HasError() {
+		return nil, previews
 	}
 
-	return meta, nil
+	return meta, previews
 }
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
@@ -34,6 +35,11 @@ func TestProviderHasResource(t *testing.T) {
 		"signalfx_detector",
 		"signalfx_automated_archival_settings",
 		"signalfx_automated_archival_exempt_metric",
+		"signalfx_org_token_rotation",
+		"signalfx_dimension",
+		"signalfx_metric_metadata",
+		"signalfx_organization_member",
+		"signalfx_team_membership",
 	}
 
 	for name := range p.ResourcesMap {
@@ -68,11 +74,33 @@ func TestProviderConfiguration(t *testing.T) {
 				"api_url":    "api.us.signalfx.com",
 			},
 			meta: &pmeta.Meta{
-				AuthToken:    "hunter2",
-				APIURL:       "api.us.signalfx.com",
-				CustomAppURL: "https://app.signalfx.com",
-				Tags:         []string{},
-				Teams:        []string{},
+				AuthToken:        "hunter2",
+				APIURL:           "api.us.signalfx.com",
+				CustomAppURL:     pmeta.DefaultCustomAppURL,
+				Timeout:          pmeta.DefaultTimeout,
+				RetryMaxAttempts: pmeta.DefaultRetryMaxAttempts,
+				RetryWaitMin:     pmeta.DefaultRetryWaitMin,
+				RetryWaitMax:     pmeta.DefaultRetryWaitMax,
+			},
+			expect: nil,
+		},
+		{
+			name: "setting realm",
+			details: map[string]any{
+				"auth_token": "hunter2",
+				"realm":      "eu0",
+			},
+			meta: &pmeta.Meta{
+				AuthToken:        "hunter2",
+				Realm:            "eu0",
+				APIURL:           "https://api.eu0.observability.splunkcloud.com",
+				IngestURL:        "https://ingest.eu0.observability.splunkcloud.com",
+				StreamURL:        "https://stream.eu0.observability.splunkcloud.com",
+				CustomAppURL:     "https://app.eu0.observability.splunkcloud.com",
+				Timeout:          pmeta.DefaultTimeout,
+				RetryMaxAttempts: pmeta.DefaultRetryMaxAttempts,
+				RetryWaitMin:     pmeta.DefaultRetryWaitMin,
+				RetryWaitMax:     pmeta.DefaultRetryWaitMax,
 			},
 			expect: nil,
 		},
@@ -87,7 +115,12 @@ func TestProviderConfiguration(t *testing.T) {
 			},
 			meta: nil,
 			expect: diag.Diagnostics{
-				{Severity: diag.Warning, Summary: "no preview with id \"feature-01\" found"},
+				{
+					Severity:      diag.Error,
+					Summary:       "Unknown feature preview",
+					Detail:        "no preview with id \"feature-01\" found",
+					AttributePath: cty.GetAttrPath("feature_preview").IndexString("feature-01"),
+				},
 			},
 		},
 		{
@@ -105,14 +138,17 @@ func TestProviderConfiguration(t *testing.T) {
 			meta: &pmeta.Meta{
 				AuthToken:    "hunter2",
 				APIURL:       "api.signalfx.com",
-				CustomAppURL: "https://app.signalfx.com",
+				CustomAppURL: pmeta.DefaultCustomAppURL,
 				Tags: []string{
 					"brown",
 					"bear",
 					"battery",
 					"staple",
 				},
-				Teams: []string{},
+				Timeout:          pmeta.DefaultTimeout,
+				RetryMaxAttempts: pmeta.DefaultRetryMaxAttempts,
+				RetryWaitMin:     pmeta.DefaultRetryWaitMin,
+				RetryWaitMax:     pmeta.DefaultRetryWaitMax,
 			},
 			expect: nil,
 		},
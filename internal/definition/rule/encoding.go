@@ -31,13 +31,13 @@ func DecodeTerraform(rd *schema.ResourceData) ([]*detector.Rule, error) {
 			RunbookUrl:           data["runbook_url"].(string),
 			Tip:                  data["tip"].(string),
 		}
-		if n, ok := data["notifications"].([]any); ok {
-			notifiy, err := common.NewNotificationList(n)
-			if err != nil {
-				return nil, err
-			}
-			rule.Notifications = notifiy
+		strs, _ := data["notifications"].([]any)
+		blocks, _ := data["notification"].([]any)
+		notifiy, err := common.NewNotificationListFromEither(strs, blocks)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.DetectLabel, err)
 		}
+		rule.Notifications = notifiy
 		if states, ok := data["skip_clear_notification_states"].(*schema.Set); ok {
 			for _, s := range states.List() {
 				rule.SkipClearNotificationStates = append(rule.SkipClearNotificationStates, s.(string))
@@ -53,25 +53,49 @@ func EncodeTerraform(rules []*detector.Rule, rd *schema.ResourceData) error {
 		return nil
 	}
 
+	// Rules previously written with typed notification blocks
+	// are kept as blocks to avoid a diff between the two forms.
+	blocks := NotificationBlocks(rd.Get("rule"))
+
 	items := make([]map[string]any, 0, len(rules))
 	for _, r := range rules {
-		notifys, err := common.NewNotificationStringList(r.Notifications)
-		if err != nil {
-			return fmt.Errorf("notification issue: %w", err)
-		}
-		items = append(items, map[string]any{
+		item := map[string]any{
 			"detect_label":                   r.DetectLabel,
 			"description":                    r.Description,
 			"disabled":                       r.Disabled,
-			"notifications":                  notifys,
 			"parameterized_body":             r.ParameterizedBody,
 			"parameterized_subject":          r.ParameterizedSubject,
 			"runbook_url":                    r.RunbookUrl,
 			"severity":                       r.Severity,
 			"tip":                            r.Tip,
 			"skip_clear_notification_states": r.SkipClearNotificationStates,
-		})
+		}
+		if err := common.EncodeNotificationFields(item, r.Notifications, blocks[Key(r.DetectLabel, string(r.Severity))]); err != nil {
+			return fmt.Errorf("notification issue: %w", err)
+		}
+		items = append(items, item)
 	}
 
 	return rd.Set("rule", items)
 }
+
+// Key identifies a rule by its detect label and severity,
+// since several rules can share a detect label with different severities.
+func Key(detectLabel, severity string) string {
+	return detectLabel + "/" + severity
+}
+
+// NotificationBlocks reports which of the stored rules, keyed by [Key],
+// were written using typed notification blocks.
+func NotificationBlocks(rules any) map[string]bool {
+	blocks := make(map[string]bool)
+	if set, ok := rules.(*schema.Set); ok {
+		for _, v := range set.List() {
+			data := v.(map[string]any)
+			label, _ := data["detect_label"].(string)
+			severity, _ := data["severity"].(string)
+			blocks[Key(label, severity)] = common.UsesNotificationBlocks(data["notification"])
+		}
+	}
+	return blocks
+}
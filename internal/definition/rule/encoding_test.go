@@ -10,6 +10,7 @@ import (
 	"github.com/signalfx/signalfx-go/detector"
 	"github.com/signalfx/signalfx-go/notification"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDecodeTerraform(t *testing.T) {
@@ -81,6 +82,55 @@ func TestDecodeTerraform(t *testing.T) {
 			},
 			errVal: "",
 		},
+		{
+			name: "notification blocks defined",
+			data: func() *schema.ResourceData {
+				data := resource.TestResourceData()
+				_ = data.Set("rule", []any{
+					map[string]any{
+						"severity":     string(detector.INFO),
+						"detect_label": "errs",
+						"notification": []any{
+							map[string]any{
+								"slack": []any{map[string]any{"credential_id": "credential", "channel": "alerts"}},
+							},
+						},
+					},
+				})
+				return data
+			},
+			expect: []*detector.Rule{
+				{
+					Severity:    detector.INFO,
+					DetectLabel: "errs",
+					Notifications: []*notification.Notification{
+						{Type: "Slack", Value: &notification.SlackNotification{Type: "Slack", CredentialId: "credential", Channel: "alerts"}},
+					},
+				},
+			},
+			errVal: "",
+		},
+		{
+			name: "both notification forms defined",
+			data: func() *schema.ResourceData {
+				data := resource.TestResourceData()
+				_ = data.Set("rule", []any{
+					map[string]any{
+						"severity":      string(detector.INFO),
+						"detect_label":  "errs",
+						"notifications": []any{"Email,example@com"},
+						"notification": []any{
+							map[string]any{
+								"team": []any{map[string]any{"team": "team-id"}},
+							},
+						},
+					},
+				})
+				return data
+			},
+			expect: nil,
+			errVal: "rule \"errs\": only one of \"notifications\" or \"notification\" can be set",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
@@ -164,3 +214,67 @@ func TestEncodeTerraform(t *testing.T) {
 
 	}
 }
+
+func TestEncodeTerraformNotificationForms(t *testing.T) {
+	t.Parallel()
+
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"rule": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: NewSchema(),
+				},
+				Set: Hash,
+			},
+		},
+	}
+
+	rd := schema.TestResourceDataRaw(t, resource.Schema, map[string]any{
+		"rule": []any{
+			map[string]any{
+				"detect_label": "errs",
+				"severity":     string(detector.CRITICAL),
+				"notification": []any{
+					map[string]any{
+						"email": []any{map[string]any{"email": "critical@example.com"}},
+					},
+				},
+			},
+			map[string]any{
+				"detect_label":  "errs",
+				"severity":      string(detector.WARNING),
+				"notifications": []any{"Email,warning@example.com"},
+			},
+		},
+	})
+
+	rules := []*detector.Rule{
+		{
+			DetectLabel: "errs",
+			Severity:    detector.CRITICAL,
+			Notifications: []*notification.Notification{
+				{Type: "Email", Value: &notification.EmailNotification{Type: "Email", Email: "critical@example.com"}},
+			},
+		},
+		{
+			DetectLabel: "errs",
+			Severity:    detector.WARNING,
+			Notifications: []*notification.Notification{
+				{Type: "Email", Value: &notification.EmailNotification{Type: "Email", Email: "warning@example.com"}},
+			},
+		},
+	}
+	require.NoError(t, EncodeTerraform(rules, rd), "Must encode the rules")
+
+	forms := make(map[string]bool)
+	for _, v := range rd.Get("rule").(*schema.Set).List() {
+		data := v.(map[string]any)
+		forms[data["severity"].(string)] = len(data["notification"].([]any)) > 0
+	}
+	assert.Equal(t, map[string]bool{
+		string(detector.CRITICAL): true,
+		string(detector.WARNING):  false,
+	}, forms, "Must keep the notification form of each rule sharing the detect label")
+}
@@ -8,11 +8,11 @@ import (
 	"hash/crc32"
 	"io"
 	"slices"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
 )
 
 func NewSchema() map[string]*schema.Schema {
@@ -42,6 +42,7 @@ func NewSchema() map[string]*schema.Schema {
 			},
 			Description: "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
 		},
+		"notification": common.NewNotificationBlockSchema("Typed notification destinations to use when an incident occurs, an alternative to `notifications` that can not be used together with it"),
 		"disabled": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -132,14 +133,10 @@ func Hash(v any) int {
 		}
 	}
 
-	if notifys, ok := rule["notifications"].([]any); ok {
-		slices.SortFunc(notifys, func(a, b any) int {
-			return strings.Compare(a.(string), b.(string))
-		})
-
-		for _, n := range notifys {
-			_, _ = io.WriteString(hash, fmt.Sprintf("%s-", n))
-		}
+	strs, _ := rule["notifications"].([]any)
+	blocks, _ := rule["notification"].([]any)
+	for _, n := range common.NotificationHashValues(strs, blocks) {
+		_, _ = io.WriteString(hash, fmt.Sprintf("%s-", n))
 	}
 
 	if states, ok := rule["skip_clear_notification_states"].(*schema.Set); ok {
@@ -69,3 +69,23 @@ func TestHashSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestHashNotificationForms(t *testing.T) {
+	t.Parallel()
+
+	strs := map[string]any{
+		"detect_label":  "my-metric",
+		"notifications": []any{"Slack,credential,alerts"},
+	}
+	blocks := map[string]any{
+		"detect_label": "my-metric",
+		"notification": []any{
+			map[string]any{
+				"slack": []any{map[string]any{"credential_id": "credential", "channel": "alerts"}},
+			},
+		},
+	}
+
+	assert.NotEqual(t, Hash(strs), Hash(blocks), "Must not match when changing notification forms")
+	assert.Equal(t, Hash(blocks), Hash(blocks), "Must be consistent for typed notification blocks")
+}
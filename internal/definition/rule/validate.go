@@ -0,0 +1,63 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rule
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
+)
+
+// ValidateDetectLabels ensures each rule's `detect_label` matches a label
+// published within `program_text` so the mismatch fails the plan
+// instead of being rejected by the API during apply.
+func ValidateDetectLabels() schema.ValidateRawResourceConfigFunc {
+	return func(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+		labels, ok := check.PublishedLabels(req.RawConfig)
+		if !ok {
+			return
+		}
+		rules := req.RawConfig.GetAttr("rule")
+		if rules.IsNull() || !rules.IsKnown() {
+			return
+		}
+		for it := rules.ElementIterator(); it.Next(); {
+			_, rule := it.Element()
+			if rule.IsNull() || !rule.IsKnown() {
+				continue
+			}
+			label := rule.GetAttr("detect_label")
+			if label.IsNull() || !label.IsKnown() || slices.Contains(labels, label.AsString()) {
+				continue
+			}
+			resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("rule %s has detect_label %q which is not published within program_text", describe(rule), label.AsString()),
+				Detail: fmt.Sprintf(
+					"Each rule must refer to a label set by publish() within program_text, the available labels are: %s",
+					check.FormatLabels(labels),
+				),
+				AttributePath: cty.GetAttrPath("rule").Index(rule).GetAttr("detect_label"),
+			})
+		}
+	}
+}
+
+// describe names the rule using its description when set,
+// otherwise falls back to the rule's severity.
+func describe(rule cty.Value) string {
+	if v := rule.GetAttr("description"); !v.IsNull() && v.IsKnown() && v.AsString() != "" {
+		return fmt.Sprintf("%q", v.AsString())
+	}
+	if v := rule.GetAttr("severity"); !v.IsNull() && v.IsKnown() {
+		return fmt.Sprintf("with severity %q", v.AsString())
+	}
+	return "without a description"
+}
@@ -0,0 +1,99 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDetectLabels(t *testing.T) {
+	t.Parallel()
+
+	newRule := func(description, label string) cty.Value {
+		desc := cty.NullVal(cty.String)
+		if description != "" {
+			desc = cty.StringVal(description)
+		}
+		return cty.ObjectVal(map[string]cty.Value{
+			"description":  desc,
+			"severity":     cty.StringVal("Critical"),
+			"detect_label": cty.StringVal(label),
+		})
+	}
+
+	for _, tc := range []struct {
+		name   string
+		config cty.Value
+		expect diag.Diagnostics
+	}{
+		{
+			name: "matching labels",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("A = data('cpu').publish('A')\ndetect(when(A > 10)).publish('CPU high')"),
+				"rule":         cty.SetVal([]cty.Value{newRule("cpu", "CPU high")}),
+			}),
+			expect: nil,
+		},
+		{
+			name: "unknown program text",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.UnknownVal(cty.String),
+				"rule":         cty.SetVal([]cty.Value{newRule("cpu", "CPU high")}),
+			}),
+			expect: nil,
+		},
+		{
+			name: "computed labels are not checked",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("l = 'CPU high'\ndetect(when(data('cpu') > 10)).publish(l)"),
+				"rule":         cty.SetVal([]cty.Value{newRule("cpu", "CPU low")}),
+			}),
+			expect: nil,
+		},
+		{
+			name: "mismatched label",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("A = data('cpu').publish('A')\ndetect(when(A > 10)).publish('CPU high')"),
+				"rule":         cty.SetVal([]cty.Value{newRule("cpu", "CPU low")}),
+			}),
+			expect: diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "rule \"cpu\" has detect_label \"CPU low\" which is not published within program_text",
+					Detail:        "Each rule must refer to a label set by publish() within program_text, the available labels are: \"A\", \"CPU high\"",
+					AttributePath: cty.GetAttrPath("rule").Index(newRule("cpu", "CPU low")).GetAttr("detect_label"),
+				},
+			},
+		},
+		{
+			name: "rule without description",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"program_text": cty.StringVal("detect(when(data('cpu') > 10)).publish('CPU high')"),
+				"rule":         cty.SetVal([]cty.Value{newRule("", "CPU")}),
+			}),
+			expect: diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "rule with severity \"Critical\" has detect_label \"CPU\" which is not published within program_text",
+					Detail:        "Each rule must refer to a label set by publish() within program_text, the available labels are: \"CPU high\"",
+					AttributePath: cty.GetAttrPath("rule").Index(newRule("", "CPU")).GetAttr("detect_label"),
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			ValidateDetectLabels()(context.Background(), schema.ValidateResourceConfigFuncRequest{RawConfig: tc.config}, resp)
+			assert.Equal(t, tc.expect, resp.Diagnostics, "Must match the expected diagnostics")
+		})
+	}
+}
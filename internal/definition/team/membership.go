@@ -0,0 +1,277 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package team
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/team"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+const (
+	MembershipResourceName = "signalfx_team_membership"
+)
+
+// membership is the set of team members that are owned by the resource,
+// other members of the team are left as they are.
+type membership struct {
+	TeamID  string
+	Members []string
+}
+
+// teamLocks serialises the member changes made to each team, since the
+// API replaces the full member list and concurrent changes would be lost.
+var teamLocks sync.Map
+
+func lockTeam(id string) (unlock func()) {
+	v, _ := teamLocks.LoadOrStore(id, &sync.Mutex{})
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// NewMembershipResource manages members of a team additively,
+// so that several configurations can add members to the same team.
+func NewMembershipResource() *schema.Resource {
+	return &schema.Resource{
+		SchemaFunc:    newMembershipSchema,
+		CreateContext: resourceMembershipCreate,
+		ReadContext:   resourceMembershipRead,
+		UpdateContext: resourceMembershipUpdate,
+		DeleteContext: resourceMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMembershipImport,
+		},
+		Description: "Adds members to a team without removing the members that are managed elsewhere. Requires the supplied token to have Admin priviledges.",
+	}
+}
+
+// NewMembershipID returns the ID used by the membership resource, which is also used to import it.
+// The ID includes the owned members so that each membership of the same team has its own ID.
+func NewMembershipID(teamID string, members []string) string {
+	return teamID + "/" + strings.Join(slices.Sorted(slices.Values(members)), ",")
+}
+
+func resourceMembershipImport(ctx context.Context, rd *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	teamID, list, ok := strings.Cut(rd.Id(), "/")
+	members := strings.Split(list, ",")
+	if !ok || teamID == "" || slices.Contains(members, "") {
+		return nil, fmt.Errorf("invalid import id %q, expected the format team_id/user_id[,user_id...]", rd.Id())
+	}
+	if err := rd.Set("team_id", teamID); err != nil {
+		return nil, err
+	}
+	if err := rd.Set("members", convert.SliceAll(members, convert.ToAny[string])); err != nil {
+		return nil, err
+	}
+	rd.SetId(NewMembershipID(teamID, members))
+	return []*schema.ResourceData{rd}, nil
+}
+
+func newMembershipSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"team_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "ID of the team to add the members to",
+		},
+		"members": {
+			Type:     schema.TypeSet,
+			Required: true,
+			MinItems: 1,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			Description: "User IDs of the members added to the team, other members of the team are not changed",
+		},
+	}
+}
+
+func decodeMembership(rd *schema.ResourceData) (*membership, error) {
+	m := &membership{
+		TeamID: rd.Get("team_id").(string),
+	}
+	if members, ok := rd.Get("members").(*schema.Set); ok && members.Len() > 0 {
+		m.Members = convert.SliceAll(members.List(), convert.ToString)
+		slices.Sort(m.Members)
+	}
+	return m, nil
+}
+
+func encodeMembership(m *membership, rd *schema.ResourceData) error {
+	if err := rd.Set("team_id", m.TeamID); err != nil {
+		return err
+	}
+	return rd.Set("members", convert.SliceAll(m.Members, convert.ToAny[string]))
+}
+
+func resourceMembershipCreate(ctx context.Context, rd *schema.ResourceData, meta any) diag.Diagnostics {
+	desired, err := decodeMembership(rd)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+	return applyMembership(ctx, rd, meta, desired, nil)
+}
+
+func resourceMembershipRead(ctx context.Context, rd *schema.ResourceData, meta any) diag.Diagnostics {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	owned, err := decodeMembership(rd)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	tm, err := client.GetTeam(ctx, owned.TeamID)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, rd))
+	}
+
+	owned.Members = ownedMembers(tm.Members, owned.Members)
+	return tfext.AsErrorDiagnostics(encodeMembership(owned, rd))
+}
+
+func resourceMembershipUpdate(ctx context.Context, rd *schema.ResourceData, meta any) diag.Diagnostics {
+	desired, err := decodeMembership(rd)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+	old, _ := rd.GetChange("members")
+	return applyMembership(ctx, rd, meta, desired, convert.SliceAll(old.(*schema.Set).List(), convert.ToString))
+}
+
+func resourceMembershipDelete(ctx context.Context, rd *schema.ResourceData, meta any) diag.Diagnostics {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	owned, err := decodeMembership(rd)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	unlock := lockTeam(owned.TeamID)
+	defer unlock()
+
+	tm, err := client.GetTeam(ctx, owned.TeamID)
+	if isNotFound(err) {
+		// Removing the team also removed the members.
+		return nil
+	}
+	if err != nil {
+		return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, rd))
+	}
+
+	tflog.Info(ctx, "Removing members from team", tfext.NewLogFields().
+		Field("team-id", owned.TeamID).
+		Field("members", owned.Members),
+	)
+
+	_, err = updateMembers(ctx, client, tm, mergeMembers(tm.Members, owned.Members, nil))
+	return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, rd))
+}
+
+// applyMembership adds the desired members to the team and removes
+// the previously owned members that are no longer desired.
+func applyMembership(ctx context.Context, rd *schema.ResourceData, meta any, desired *membership, previous []string) diag.Diagnostics {
+	client, err := pmeta.LoadClient(ctx, meta)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(err)
+	}
+
+	unlock := lockTeam(desired.TeamID)
+	defer unlock()
+
+	tm, err := client.GetTeam(ctx, desired.TeamID)
+	if err != nil {
+		return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, rd))
+	}
+
+	tm, err = updateMembers(ctx, client, tm, mergeMembers(tm.Members, previous, desired.Members))
+	if err != nil {
+		return tfext.AsErrorDiagnostics(common.HandleError(ctx, err, rd))
+	}
+
+	tflog.Debug(ctx, "Updated team members", tfext.NewLogFields().
+		Field("team-id", desired.TeamID).
+		Field("members", desired.Members),
+	)
+
+	rd.SetId(NewMembershipID(desired.TeamID, desired.Members))
+	desired.Members = ownedMembers(tm.Members, desired.Members)
+	return tfext.AsErrorDiagnostics(encodeMembership(desired, rd))
+}
+
+// updateMembers replaces the members of the team with the sorted members,
+// keeping the other team details as they are. The team is returned
+// unchanged when the members are already set.
+func updateMembers(ctx context.Context, client *signalfx.Client, tm *team.Team, members []string) (*team.Team, error) {
+	if slices.Equal(slices.Sorted(slices.Values(tm.Members)), members) {
+		return tm, nil
+	}
+	return client.UpdateTeam(ctx, tm.Id, &team.CreateUpdateTeamRequest{
+		Name:              tm.Name,
+		Description:       tm.Description,
+		Members:           members,
+		NotificationLists: tm.NotificationLists,
+	})
+}
+
+// mergeMembers returns the current members with the previously owned
+// members removed and the desired members added, members that were
+// never owned are kept.
+func mergeMembers(current, previous, desired []string) []string {
+	merged := make([]string, 0, len(current)+len(desired))
+	for _, m := range current {
+		if slices.Contains(previous, m) && !slices.Contains(desired, m) {
+			continue
+		}
+		merged = append(merged, m)
+	}
+	for _, m := range desired {
+		if !slices.Contains(merged, m) {
+			merged = append(merged, m)
+		}
+	}
+	slices.Sort(merged)
+	return merged
+}
+
+// ownedMembers returns the sorted members of the team that are also owned.
+func ownedMembers(current, owned []string) []string {
+	var members []string
+	for _, m := range current {
+		if slices.Contains(owned, m) && !slices.Contains(members, m) {
+			members = append(members, m)
+		}
+	}
+	slices.Sort(members)
+	return members
+}
+
+func isNotFound(err error) bool {
+	re, ok := signalfx.AsResponseError(err)
+	return ok && re.Code() == http.StatusNotFound
+}
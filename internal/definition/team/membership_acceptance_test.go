@@ -0,0 +1,85 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package team
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+)
+
+// checkTeamMembers validates the members stored by the fake api,
+// since each resource only reports the members that it owns.
+func checkTeamMembers(api *fakeapi.Server, expect ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["signalfx_team.example_test"]
+		if !ok {
+			return fmt.Errorf("team not found in state")
+		}
+		tm, ok := api.Get(fakeapi.Teams, rs.Primary.ID)
+		if !ok {
+			return fmt.Errorf("team %q not found", rs.Primary.ID)
+		}
+		members, _ := tm["members"].([]any)
+		actual := convert.SliceAll(members, convert.ToString)
+		slices.Sort(actual)
+		if !slices.Equal(expect, actual) {
+			return fmt.Errorf("expected team members %v, found %v", expect, actual)
+		}
+		return nil
+	}
+}
+
+func TestAcceptanceMembershipFakeAPI(t *testing.T) {
+	api := fakeapi.New()
+
+	tftest.NewAcceptanceHandler(
+		tftest.WithAcceptanceResources(map[string]*schema.Resource{
+			ResourceName:           NewResource(),
+			MembershipResourceName: NewMembershipResource(),
+		}),
+		tftest.WithAcceptanceFakeAPI(api),
+	).
+		Test(t, []resource.TestStep{
+			{
+				Config: tftest.LoadConfig("testdata/resource_team_membership.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("signalfx_team.example_test", "members.#", "1"),
+					resource.TestCheckResourceAttr("signalfx_team_membership.example_test", "members.#", "2"),
+					checkTeamMembers(api, "AAAAAAAAAA", "BBBBBBBBBB", "CCCCCCCCCC"),
+				),
+			},
+			{
+				Config: tftest.LoadConfig("testdata/resource_team_membership_updated.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("signalfx_team.example_test", "members.#", "1"),
+					resource.TestCheckResourceAttr("signalfx_team_membership.example_test", "members.#", "1"),
+					checkTeamMembers(api, "AAAAAAAAAA", "CCCCCCCCCC"),
+				),
+			},
+			{
+				ResourceName:      "signalfx_team_membership.example_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:  tftest.LoadConfig("testdata/resource_team_membership_updated.tf"),
+				Destroy: true,
+				Check: func(*terraform.State) error {
+					if n := api.Len(fakeapi.Teams); n != 0 {
+						return fmt.Errorf("expected all teams to be deleted, found %d", n)
+					}
+					return nil
+				},
+			},
+		})
+}
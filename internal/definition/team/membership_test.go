@@ -0,0 +1,315 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package team
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/signalfx/signalfx-go/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+)
+
+func encodeTeam(tm *team.Team) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_ = r.Body.Close()
+		_ = json.NewEncoder(w).Encode(tm)
+	}
+}
+
+func updateTeam(tb testing.TB, expect []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req team.CreateUpdateTeamRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = r.Body.Close()
+		assert.Equal(tb, "test", req.Name, "Must keep the team name")
+		assert.Equal(tb, expect, req.Members, "Must match the expected members")
+		_ = json.NewEncoder(w).Encode(&team.Team{
+			Id:      "0001",
+			Name:    req.Name,
+			Members: req.Members,
+		})
+	}
+}
+
+func TestNewMembershipResource(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, NewMembershipResource().InternalValidate(nil, true), "Must be a valid resource")
+}
+
+func TestMembershipImport(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		id      string
+		expect  string
+		teamID  string
+		members []any
+		errVal  string
+	}{
+		{name: "single member", id: "0001/a", expect: "0001/a", teamID: "0001", members: []any{"a"}},
+		{name: "several members", id: "0001/b,a", expect: "0001/a,b", teamID: "0001", members: []any{"a", "b"}},
+		{name: "only team id", id: "0001", errVal: "invalid import id \"0001\", expected the format team_id/user_id[,user_id...]"},
+		{name: "missing members", id: "0001/", errVal: "invalid import id \"0001/\", expected the format team_id/user_id[,user_id...]"},
+		{name: "empty member", id: "0001/a,,b", errVal: "invalid import id \"0001/a,,b\", expected the format team_id/user_id[,user_id...]"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rd := NewMembershipResource().TestResourceData()
+			rd.SetId(tc.id)
+
+			actual, err := resourceMembershipImport(context.Background(), rd, nil)
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+				return
+			}
+			require.NoError(t, err, "Must not error importing resource")
+			require.Len(t, actual, 1, "Must return the imported resource")
+			assert.Equal(t, tc.expect, actual[0].Id(), "Must use the sorted members within the id")
+			assert.Equal(t, tc.teamID, actual[0].Get("team_id"), "Must match the expected team id")
+			assert.ElementsMatch(t, tc.members, actual[0].Get("members").(*schema.Set).List(), "Must match the expected members")
+		})
+	}
+}
+
+func TestMembershipID(t *testing.T) {
+	t.Parallel()
+
+	meta := tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+		"GET /v2/team/0001": encodeTeam(&team.Team{Id: "0001", Name: "test", Members: []string{"a", "b", "c"}}),
+	})
+
+	ids := make(map[string]struct{})
+	for _, members := range [][]any{{"b", "a"}, {"c"}} {
+		rd := schema.TestResourceDataRaw(t, newMembershipSchema(), map[string]any{
+			"team_id": "0001",
+			"members": members,
+		})
+		require.Empty(t, resourceMembershipCreate(t.Context(), rd, meta(t)), "Must not report any issues")
+		ids[rd.Id()] = struct{}{}
+	}
+
+	assert.Equal(t, map[string]struct{}{"0001/a,b": {}, "0001/c": {}}, ids, "Must use a distinct id for each membership of the team")
+}
+
+func TestMembershipFakeAPI(t *testing.T) {
+	t.Parallel()
+
+	api := fakeapi.New(fakeapi.WithSeed(1))
+	id, err := api.Seed(fakeapi.Teams, map[string]any{"name": "test", "members": []any{"c"}})
+	require.NoError(t, err, "Must seed the team")
+
+	meta := tftest.NewTestHTTPMockMeta(api.Routes())(t)
+	rd := schema.TestResourceDataRaw(t, newMembershipSchema(), map[string]any{
+		"team_id": id,
+		"members": []any{"b", "a"},
+	})
+
+	require.Empty(t, resourceMembershipCreate(t.Context(), rd, meta), "Must not report any issues")
+	assert.Equal(t, id+"/a,b", rd.Id(), "Must use the team and members within the id")
+
+	tm, ok := api.Get(fakeapi.Teams, id)
+	require.True(t, ok, "Must keep the team")
+	assert.Equal(t, []any{"a", "b", "c"}, tm["members"], "Must add the members to the team")
+
+	require.Empty(t, resourceMembershipDelete(t.Context(), rd, meta), "Must not report any issues")
+
+	tm, ok = api.Get(fakeapi.Teams, id)
+	require.True(t, ok, "Must keep the team")
+	assert.Equal(t, []any{"c"}, tm["members"], "Must only remove the owned members")
+}
+
+func TestMembershipCreate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[membership]{
+		{
+			Name: "No provider",
+			Meta: func(_ testing.TB) any {
+				return nil
+			},
+			Resource: NewMembershipResource(),
+			Encoder:  encodeMembership,
+			Decoder:  decodeMembership,
+			Input:    &membership{TeamID: "0001", Members: []string{"a"}},
+			Expect:   &membership{TeamID: "0001", Members: []string{"a"}},
+			Issues: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "expected to implement type Meta"},
+			},
+		},
+		{
+			Name: "Adds members to team",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/team/0001": encodeTeam(&team.Team{Id: "0001", Name: "test", Members: []string{"c", "a"}}),
+				"PUT /v2/team/0001": updateTeam(t, []string{"a", "b", "c"}),
+			}),
+			Resource: NewMembershipResource(),
+			Encoder:  encodeMembership,
+			Decoder:  decodeMembership,
+			Input:    &membership{TeamID: "0001", Members: []string{"a", "b"}},
+			Expect:   &membership{TeamID: "0001", Members: []string{"a", "b"}},
+			Issues:   nil,
+		},
+		{
+			Name: "Members already added",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/team/0001": encodeTeam(&team.Team{Id: "0001", Name: "test", Members: []string{"a", "b"}}),
+			}),
+			Resource: NewMembershipResource(),
+			Encoder:  encodeMembership,
+			Decoder:  decodeMembership,
+			Input:    &membership{TeamID: "0001", Members: []string{"b"}},
+			Expect:   &membership{TeamID: "0001", Members: []string{"b"}},
+			Issues:   nil,
+		},
+		{
+			Name: "Failed to read team",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/team/0001": func(w http.ResponseWriter, _ *http.Request) {
+					http.Error(w, "Bad request", http.StatusBadRequest)
+				},
+			}),
+			Resource: NewMembershipResource(),
+			Encoder:  encodeMembership,
+			Decoder:  decodeMembership,
+			Input:    &membership{TeamID: "0001", Members: []string{"a"}},
+			Expect:   &membership{TeamID: "0001", Members: []string{"a"}},
+			Issues: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "route \"/v2/team/0001\" had issues with status code 400"},
+			},
+		},
+	} {
+		tc.TestCreate(t)
+	}
+}
+
+func TestMembershipRead(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[membership]{
+		{
+			Name: "Only owned members",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/team/0001": encodeTeam(&team.Team{Id: "0001", Name: "test", Members: []string{"a", "c", "d"}}),
+			}),
+			Resource: NewMembershipResource(),
+			Encoder:  encodeMembership,
+			Decoder:  decodeMembership,
+			Input:    &membership{TeamID: "0001", Members: []string{"a", "b"}},
+			Expect:   &membership{TeamID: "0001", Members: []string{"a"}},
+			Issues:   nil,
+		},
+	} {
+		tc.TestRead(t)
+	}
+}
+
+func TestMembershipDelete(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []tftest.ResourceOperationTestCase[membership]{
+		{
+			Name: "Removes owned members",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/team/0001": encodeTeam(&team.Team{Id: "0001", Name: "test", Members: []string{"a", "b", "c"}}),
+				"PUT /v2/team/0001": updateTeam(t, []string{"c"}),
+			}),
+			Resource: NewMembershipResource(),
+			Encoder:  encodeMembership,
+			Decoder:  decodeMembership,
+			Input:    &membership{TeamID: "0001", Members: []string{"a", "b"}},
+			Expect:   &membership{TeamID: "0001", Members: []string{"a", "b"}},
+			Issues:   nil,
+		},
+		{
+			Name: "Team already removed",
+			Meta: tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+				"GET /v2/team/0001": func(w http.ResponseWriter, _ *http.Request) {
+					http.Error(w, "Not found", http.StatusNotFound)
+				},
+			}),
+			Resource: NewMembershipResource(),
+			Encoder:  encodeMembership,
+			Decoder:  decodeMembership,
+			Input:    &membership{TeamID: "0001", Members: []string{"a"}},
+			Expect:   &membership{TeamID: "0001", Members: []string{"a"}},
+			Issues:   nil,
+		},
+	} {
+		tc.TestDelete(t)
+	}
+}
+
+func TestMergeMembers(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		current  []string
+		previous []string
+		desired  []string
+		expect   []string
+	}{
+		{
+			name:    "adds members",
+			current: []string{"c"},
+			desired: []string{"b", "a"},
+			expect:  []string{"a", "b", "c"},
+		},
+		{
+			name:     "removes previously owned members",
+			current:  []string{"a", "b", "c"},
+			previous: []string{"a", "b"},
+			desired:  []string{"b"},
+			expect:   []string{"b", "c"},
+		},
+		{
+			name:     "removes all owned members",
+			current:  []string{"a", "b"},
+			previous: []string{"a", "b"},
+			desired:  nil,
+			expect:   []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expect, mergeMembers(tc.current, tc.previous, tc.desired), "Must match the expected members")
+		})
+	}
+}
+
+func TestResourceReadIgnoresUnmanagedMembers(t *testing.T) {
+	t.Parallel()
+
+	meta := tftest.NewTestHTTPMockMeta(map[string]http.HandlerFunc{
+		"GET /v2/team/0001": encodeTeam(&team.Team{Id: "0001", Name: "test", Members: []string{"a", "b", "c"}}),
+	})
+
+	rd := schema.TestResourceDataRaw(t, newSchema(), map[string]any{
+		"name":                     "test",
+		"members":                  []any{"a", "d"},
+		"ignore_unmanaged_members": true,
+	})
+	rd.SetId("0001")
+
+	require.Empty(t, NewResource().ReadContext(t.Context(), rd, meta(t)), "Must not report any issues")
+	assert.ElementsMatch(t, []any{"a"}, rd.Get("members").(*schema.Set).List(), "Must only include the owned members")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/signalfx/signalfx-go/team"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 )
 
@@ -80,6 +81,11 @@ func newResourceRead() schema.ReadContextFunc {
 
 		tflog.Debug(ctx, "Successfully fetched team data")
 
+		if rd.Get("ignore_unmanaged_members").(bool) {
+			owned, _ := rd.Get("members").(*schema.Set)
+			tm.Members = ownedMembers(tm.Members, convert.SliceAll(owned.List(), convert.ToString))
+		}
+
 		if err := rd.Set("url", pmeta.LoadApplicationURL(ctx, meta, AppPath, tm.Id)); err != nil {
 			return diag.FromErr(err)
 		}
@@ -100,16 +106,36 @@ func newResourceUpdate() schema.UpdateContextFunc {
 			return diag.FromErr(err)
 		}
 
+		unlock := lockTeam(rd.Id())
+		defer unlock()
+
+		members := payload.Members
+		ignore := rd.Get("ignore_unmanaged_members").(bool)
+		if ignore {
+			// Only the members that were previously set are removed,
+			// so that members added elsewhere are kept.
+			current, err := client.GetTeam(ctx, rd.Id())
+			if common.HandleError(ctx, err, rd) != nil {
+				return diag.FromErr(err)
+			}
+			old, _ := rd.GetChange("members")
+			members = mergeMembers(current.Members, convert.SliceAll(old.(*schema.Set).List(), convert.ToString), payload.Members)
+		}
+
 		tm, err := client.UpdateTeam(ctx, rd.Id(), &team.CreateUpdateTeamRequest{
 			Name:              payload.Name,
 			Description:       payload.Description,
-			Members:           payload.Members,
+			Members:           members,
 			NotificationLists: payload.NotificationLists,
 		})
 		if common.HandleError(ctx, err, rd) != nil {
 			return diag.FromErr(err)
 		}
 
+		if ignore {
+			tm.Members = ownedMembers(tm.Members, payload.Members)
+		}
+
 		if err := rd.Set("url", pmeta.LoadApplicationURL(ctx, meta, AppPath, tm.Id)); err != nil {
 			return diag.FromErr(err)
 		}
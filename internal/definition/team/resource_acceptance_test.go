@@ -4,11 +4,14 @@
 package team
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
 )
 
@@ -86,3 +89,45 @@ func TestAcceptance(t *testing.T) {
 		})
 	}
 }
+
+func TestAcceptanceFakeAPI(t *testing.T) {
+	api := fakeapi.New()
+
+	tftest.NewAcceptanceHandler(
+		tftest.WithAcceptanceResources(map[string]*schema.Resource{
+			ResourceName: NewResource(),
+		}),
+		tftest.WithAcceptanceFakeAPI(api),
+	).
+		Test(t, []resource.TestStep{
+			{
+				Config: tftest.LoadConfig("testdata/resource_team.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("signalfx_team.example_test", "name", "my team"),
+					resource.TestCheckResourceAttr("signalfx_team.example_test", "description", "An example of team"),
+				),
+			},
+			{
+				Config: tftest.LoadConfig("testdata/resource_team_updated.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("signalfx_team.example_test", "notifications_critical.#", "1"),
+					resource.TestCheckResourceAttr("signalfx_team.example_test", "notifications_critical.0", "Email,test@example.com"),
+				),
+			},
+			{
+				ResourceName:      "signalfx_team.example_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:  tftest.LoadConfig("testdata/resource_team_updated.tf"),
+				Destroy: true,
+				Check: func(*terraform.State) error {
+					if n := api.Len(fakeapi.Teams); n != 0 {
+						return fmt.Errorf("expected all teams to be deleted, found %d", n)
+					}
+					return nil
+				},
+			},
+		})
+}
@@ -4,6 +4,8 @@
 package team
 
 import (
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/signalfx/signalfx-go/notification"
 	"github.com/signalfx/signalfx-go/team"
@@ -30,6 +32,11 @@ func newSchema() map[string]*schema.Schema {
 			Elem:        &schema.Schema{Type: schema.TypeString},
 			Description: "Members of team",
 		},
+		"ignore_unmanaged_members": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Ignore the members of the team that are not set in `members`, such as those added by `signalfx_team_membership`, instead of removing them",
+		},
 		"notifications_critical": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -39,6 +46,7 @@ func newSchema() map[string]*schema.Schema {
 			},
 			Description: "List of notification destinations to use for the critical alerts category.",
 		},
+		"notification_critical": common.NewNotificationBlockSchema("Typed notification destinations to use for the critical alerts category, an alternative to `notifications_critical` that can not be used together with it."),
 		"notifications_default": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -48,6 +56,7 @@ func newSchema() map[string]*schema.Schema {
 			},
 			Description: "List of notification destinations to use for the default alerts category.",
 		},
+		"notification_default": common.NewNotificationBlockSchema("Typed notification destinations to use for the default alerts category, an alternative to `notifications_default` that can not be used together with it."),
 		"notifications_info": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -57,6 +66,7 @@ func newSchema() map[string]*schema.Schema {
 			},
 			Description: "List of notification destinations to use for the info alerts category.",
 		},
+		"notification_info": common.NewNotificationBlockSchema("Typed notification destinations to use for the info alerts category, an alternative to `notifications_info` that can not be used together with it."),
 		"notifications_major": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -66,6 +76,7 @@ func newSchema() map[string]*schema.Schema {
 			},
 			Description: "List of notification destinations to use for the major alerts category.",
 		},
+		"notification_major": common.NewNotificationBlockSchema("Typed notification destinations to use for the major alerts category, an alternative to `notifications_major` that can not be used together with it."),
 		"notifications_minor": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -75,6 +86,7 @@ func newSchema() map[string]*schema.Schema {
 			},
 			Description: "List of notification destinations to use for the minor alerts category.",
 		},
+		"notification_minor": common.NewNotificationBlockSchema("Typed notification destinations to use for the minor alerts category, an alternative to `notifications_minor` that can not be used together with it."),
 		"notifications_warning": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -84,6 +96,7 @@ func newSchema() map[string]*schema.Schema {
 			},
 			Description: "List of notification destinations to use for the warning alerts category.",
 		},
+		"notification_warning": common.NewNotificationBlockSchema("Typed notification destinations to use for the warning alerts category, an alternative to `notifications_warning` that can not be used together with it."),
 		"url": {
 			Type:        schema.TypeString,
 			Computed:    true,
@@ -110,12 +123,12 @@ func decodeTerraform(rd *schema.ResourceData) (*team.Team, error) {
 		"notifications_major":    &t.NotificationLists.Major,
 		"notifications_critical": &t.NotificationLists.Critical,
 	} {
-		if val, ok := rd.Get(name).([]any); ok && len(val) > 0 {
-			var err error
-			(*field), err = common.NewNotificationList(val)
-			if err != nil {
-				return nil, err
-			}
+		strs, _ := rd.Get(name).([]any)
+		blocks, _ := rd.Get(blockFieldName(name)).([]any)
+		var err error
+		(*field), err = common.NewNotificationListFromEither(strs, blocks)
+		if err != nil {
+			return nil, err
 		}
 	}
 	return t, nil
@@ -130,14 +143,12 @@ func encodeTerraform(tm *team.Team, rd *schema.ResourceData) error {
 		return err
 	}
 
-	if l := len(tm.Members); l > 0 {
-		members := make([]any, l)
-		for i, m := range tm.Members {
-			members[i] = m
-		}
-		if err := rd.Set("members", schema.NewSet(schema.HashString, members)); err != nil {
-			return err
-		}
+	members := make([]any, len(tm.Members))
+	for i, m := range tm.Members {
+		members[i] = m
+	}
+	if err := rd.Set("members", schema.NewSet(schema.HashString, members)); err != nil {
+		return err
 	}
 
 	for name, values := range map[string][]*notification.Notification{
@@ -151,18 +162,24 @@ func encodeTerraform(tm *team.Team, rd *schema.ResourceData) error {
 		if len(values) == 0 {
 			continue
 		}
-		items := make([]string, len(values))
-		for i, n := range values {
-			s, err := common.NewNotificationStringFromAPI(n)
-			if err != nil {
-				return err
-			}
-			items[i] = s
+		item := make(map[string]any)
+		blocks := common.UsesNotificationBlocks(rd.Get(blockFieldName(name)))
+		if err := common.EncodeNotificationFields(item, values, blocks); err != nil {
+			return err
 		}
 
-		if err := rd.Set(name, items); err != nil {
+		if err := rd.Set(name, item[common.NotificationStringField]); err != nil {
+			return err
+		}
+		if err := rd.Set(blockFieldName(name), item[common.NotificationBlockField]); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// blockFieldName returns the typed notification block field
+// that is the alternative to the notification string field.
+func blockFieldName(name string) string {
+	return strings.Replace(name, common.NotificationStringField, common.NotificationBlockField, 1)
+}
@@ -43,6 +43,42 @@ func TestDecodeTerraform(t *testing.T) {
 			expect: nil,
 			errVal: "invalid notification string \"0\", not enough commas",
 		},
+		{
+			name: "notification blocks",
+			data: map[string]any{
+				"notification_critical": []any{
+					map[string]any{
+						"slack": []any{
+							map[string]any{"credential_id": "credential", "channel": "alerts"},
+						},
+					},
+				},
+			},
+			expect: &team.Team{
+				NotificationLists: team.NotificationLists{
+					Critical: []*notification.Notification{
+						{
+							Type:  "Slack",
+							Value: &notification.SlackNotification{Type: "Slack", CredentialId: "credential", Channel: "alerts"},
+						},
+					},
+				},
+			},
+			errVal: "",
+		},
+		{
+			name: "both notification forms",
+			data: map[string]any{
+				"notifications_critical": []any{"Team,team-id"},
+				"notification_critical": []any{
+					map[string]any{
+						"team": []any{map[string]any{"team": "team-id"}},
+					},
+				},
+			},
+			expect: nil,
+			errVal: "only one of \"notifications\" or \"notification\" can be set",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
@@ -173,3 +209,42 @@ func TestEncodeTerraform(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeTerraformNotificationForm(t *testing.T) {
+	t.Parallel()
+
+	input := &team.Team{
+		NotificationLists: team.NotificationLists{
+			Critical: []*notification.Notification{
+				{Type: "Team", Value: &notification.TeamNotification{Type: "Team", Team: "team-id"}},
+			},
+			Major: []*notification.Notification{
+				{Type: "Team", Value: &notification.TeamNotification{Type: "Team", Team: "team-id"}},
+			},
+		},
+	}
+
+	rd := schema.TestResourceDataRaw(t, newSchema(), map[string]any{
+		"name":                   "example",
+		"notifications_critical": []any{"Team,team-id"},
+		"notification_major": []any{
+			map[string]any{
+				"team": []any{map[string]any{"team": "team-id"}},
+			},
+		},
+	})
+
+	if !assert.NoError(t, encodeTerraform(input, rd), "Must not error encoding data") {
+		return
+	}
+
+	assert.Equal(t, []any{"Team,team-id"}, rd.Get("notifications_critical"), "Must keep the string form")
+	assert.Empty(t, rd.Get("notification_critical"), "Must not set the typed blocks")
+	assert.Empty(t, rd.Get("notifications_major"), "Must not set the string form")
+	assert.Len(t, rd.Get("notification_major"), 1, "Must keep the typed block form")
+
+	tm, err := decodeTerraform(rd)
+	if assert.NoError(t, err, "Must not error decoding data") {
+		assert.Equal(t, input.NotificationLists, tm.NotificationLists, "Must round trip both notification forms")
+	}
+}
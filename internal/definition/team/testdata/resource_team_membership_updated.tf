@@ -0,0 +1,17 @@
+provider "signalfx" {}
+
+resource "signalfx_team" "example_test" {
+  provider = signalfx
+
+  name    = "my team"
+  members = ["AAAAAAAAAA"]
+
+  ignore_unmanaged_members = true
+}
+
+resource "signalfx_team_membership" "example_test" {
+  provider = signalfx
+
+  team_id = signalfx_team.example_test.id
+  members = ["CCCCCCCCCC"]
+}
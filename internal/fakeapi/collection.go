@@ -0,0 +1,132 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"net/http"
+	"slices"
+)
+
+// Collection describes how an API object type is stored and validated.
+type Collection struct {
+	// Name is used to refer to the collection when seeding or inspecting state.
+	Name string
+	// Path is the API route, for example `/v2/detector`.
+	Path string
+	// IDField is the object field that is used to identify the object,
+	// the value is generated on create unless the field is the name.
+	IDField string
+	// Required are the fields that must be set on create and update.
+	Required []string
+	// CreateStatus is the status code returned on a successful create.
+	CreateStatus int
+	// Actions are the additional routes supported per object,
+	// for example `enable` will support `PUT /v2/detector/{id}/enable`
+	// and `rotate` will support `POST /v2/token/{name}/rotate`.
+	Actions []string
+	// Validate enables `POST {path}/validate` that checks the required fields.
+	Validate bool
+	// SecretField is populated with a generated value on create when set.
+	SecretField string
+}
+
+const (
+	Detectors       = "detector"
+	Charts          = "chart"
+	Dashboards      = "dashboard"
+	DashboardGroups = "dashboardgroup"
+	Teams           = "team"
+	Integrations    = "integration"
+	OrgTokens       = "token"
+	MutingRules     = "alertmuting"
+	SLOs            = "slo"
+)
+
+// DefaultCollections returns the API object types supported by the fake server.
+func DefaultCollections() []Collection {
+	return []Collection{
+		{
+			Name:         Detectors,
+			Path:         "/v2/detector",
+			IDField:      "id",
+			Required:     []string{"name", "programText"},
+			CreateStatus: http.StatusOK,
+			Actions:      []string{"enable", "disable"},
+			Validate:     true,
+		},
+		{
+			Name:         Charts,
+			Path:         "/v2/chart",
+			IDField:      "id",
+			Required:     []string{"name"},
+			CreateStatus: http.StatusOK,
+		},
+		{
+			Name:         Dashboards,
+			Path:         "/v2/dashboard",
+			IDField:      "id",
+			Required:     []string{"name"},
+			CreateStatus: http.StatusOK,
+		},
+		{
+			Name:         DashboardGroups,
+			Path:         "/v2/dashboardgroup",
+			IDField:      "id",
+			Required:     []string{"name"},
+			CreateStatus: http.StatusOK,
+		},
+		{
+			Name:         Teams,
+			Path:         "/v2/team",
+			IDField:      "id",
+			Required:     []string{"name"},
+			CreateStatus: http.StatusOK,
+		},
+		{
+			Name:         Integrations,
+			Path:         "/v2/integration",
+			IDField:      "id",
+			Required:     []string{"type"},
+			CreateStatus: http.StatusOK,
+		},
+		{
+			Name:         OrgTokens,
+			Path:         "/v2/token",
+			IDField:      "name",
+			Required:     []string{"name"},
+			CreateStatus: http.StatusOK,
+			Actions:      []string{"rotate"},
+			SecretField:  "secret",
+		},
+		{
+			Name:         MutingRules,
+			Path:         "/v2/alertmuting",
+			IDField:      "id",
+			Required:     []string{"startTime"},
+			CreateStatus: http.StatusCreated,
+		},
+		{
+			Name:         SLOs,
+			Path:         "/v2/slo",
+			IDField:      "id",
+			Required:     []string{"name", "type"},
+			CreateStatus: http.StatusOK,
+			Validate:     true,
+		},
+	}
+}
+
+func (c Collection) hasAction(action string) bool {
+	return slices.Contains(c.Actions, action)
+}
+
+func (c Collection) missing(obj map[string]any) []string {
+	var fields []string
+	for _, field := range c.Required {
+		if v, ok := obj[field]; !ok || v == nil || v == "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
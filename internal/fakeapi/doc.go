@@ -0,0 +1,17 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fakeapi provides an in memory implementation of the SignalFx API
+// so that resources can be exercised through full plan, apply, import,
+// and destroy cycles without access to a real organization.
+// The objects are stored as decoded JSON so the package does not depend on
+// the API client types, and it can be plugged into both the framework
+// and SDKv2 test harnesses using [Server.Endpoints] and [Server.Routes].
+//
+// Acceptance tests can be run offline by passing the server to the test handler:
+//
+//	tftest.NewAcceptanceHandler(
+//		tftest.WithAcceptanceResources(resources),
+//		tftest.WithAcceptanceFakeAPI(fakeapi.New()),
+//	).Test(t, steps)
+package fakeapi
@@ -0,0 +1,457 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	idAlphabet   = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-"
+	idLength     = 11
+	secretLength = 22
+
+	authHeader = "X-SF-Token"
+	creator    = "AAAAAAAAAAA"
+)
+
+// Server is a stateful in memory implementation of the SignalFx API
+// that supports the CRUD operations of each configured collection.
+type Server struct {
+	mu sync.Mutex
+
+	collections []Collection
+	objects     map[string]map[string]map[string]any
+	order       map[string][]string
+
+	rand  *rand.Rand
+	clock func() time.Time
+}
+
+var _ http.Handler = (*Server)(nil)
+
+// Option allows for the server to be customised.
+type Option func(*Server)
+
+// WithCollections replaces the default collections served.
+func WithCollections(collections ...Collection) Option {
+	return func(s *Server) {
+		s.collections = collections
+	}
+}
+
+// WithSeed sets the seed used to generate object IDs,
+// this allows for tests to have consistent IDs between runs.
+func WithSeed(seed uint64) Option {
+	return func(s *Server) {
+		s.rand = rand.New(rand.NewPCG(seed, seed))
+	}
+}
+
+// WithClock overrides the time used for the `created` and `lastUpdated` fields.
+func WithClock(clock func() time.Time) Option {
+	return func(s *Server) {
+		s.clock = clock
+	}
+}
+
+// New creates a fake API server that is empty,
+// objects can be added using the API or by calling [Server.Seed].
+func New(opts ...Option) *Server {
+	s := &Server{
+		collections: DefaultCollections(),
+		objects:     make(map[string]map[string]map[string]any),
+		order:       make(map[string][]string),
+		rand:        rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+		clock:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	for _, c := range s.collections {
+		s.objects[c.Name] = make(map[string]map[string]any)
+	}
+	return s
+}
+
+// Endpoints returns the routes handled by the server in the format
+// expected by `fwtest.NewMock` and `fwtest.NewMockProto5Server`.
+func (s *Server) Endpoints() map[string]http.Handler {
+	endpoints := make(map[string]http.Handler, len(s.collections)*2)
+	for _, c := range s.collections {
+		endpoints[c.Path] = s
+		endpoints[c.Path+"/"] = s
+	}
+	return endpoints
+}
+
+// Routes returns the routes handled by the server in the format
+// expected by `tftest.NewTestHTTPMockMeta`.
+func (s *Server) Routes() map[string]http.HandlerFunc {
+	routes := make(map[string]http.HandlerFunc, len(s.collections)*2)
+	for _, c := range s.collections {
+		routes[c.Path] = s.ServeHTTP
+		routes[c.Path+"/"] = s.ServeHTTP
+	}
+	return routes
+}
+
+// Seed stores the object as if it had been created by the API,
+// returning the ID of the object that was stored.
+func (s *Server) Seed(collection string, obj map[string]any) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collection(collection)
+	if !ok {
+		return "", fmt.Errorf("unknown collection %q", collection)
+	}
+	stored, err := clone(obj)
+	if err != nil {
+		return "", err
+	}
+	id, status, msg := s.insert(c, stored)
+	if status != 0 {
+		return "", fmt.Errorf("unable to seed %s: %s", collection, msg)
+	}
+	return id, nil
+}
+
+// Get returns a copy of the stored object.
+func (s *Server) Get(collection, id string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[collection][id]
+	if !ok {
+		return nil, false
+	}
+	copied, err := clone(obj)
+	return copied, err == nil
+}
+
+// Len returns the number of objects stored within the collection.
+func (s *Server) Len(collection string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.objects[collection])
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(authHeader) == "" {
+		writeError(w, http.StatusUnauthorized, "missing %s header", authHeader)
+		return
+	}
+
+	for _, c := range s.collections {
+		rest, ok := strings.CutPrefix(r.URL.EscapedPath(), c.Path)
+		if !ok || (rest != "" && rest[0] != '/') {
+			continue
+		}
+		parts := strings.Split(strings.Trim(rest, "/"), "/")
+		for i, p := range parts {
+			if parts[i], ok = unescape(p); !ok {
+				writeError(w, http.StatusBadRequest, "invalid path segment %q", p)
+				return
+			}
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case len(parts) == 1 && parts[0] == "":
+			s.serveCollection(w, r, c)
+		case len(parts) == 1 && parts[0] == "validate" && c.Validate && r.Method == http.MethodPost:
+			s.serveValidate(w, r, c)
+		case len(parts) == 1:
+			s.serveObject(w, r, c, parts[0])
+		case len(parts) == 2 && c.hasAction(parts[1]):
+			s.serveAction(w, r, c, parts[0], parts[1])
+		default:
+			writeError(w, http.StatusNotFound, "unknown route %s", r.URL.Path)
+		}
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "unknown route %s", r.URL.Path)
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, c Collection) {
+	switch r.Method {
+	case http.MethodGet:
+		s.serveList(w, r, c)
+	case http.MethodPost:
+		obj, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		if missing := c.missing(obj); len(missing) > 0 {
+			writeError(w, http.StatusBadRequest, "missing required fields: %s", strings.Join(missing, ", "))
+			return
+		}
+		id, status, msg := s.insert(c, obj)
+		if status != 0 {
+			writeError(w, status, "%s", msg)
+			return
+		}
+		writeJSON(w, c.CreateStatus, s.objects[c.Name][id])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+	}
+}
+
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, c Collection) {
+	var (
+		query   = r.URL.Query()
+		name    = strings.ToLower(query.Get("name"))
+		results = make([]map[string]any, 0)
+	)
+	for _, id := range s.order[c.Name] {
+		obj := s.objects[c.Name][id]
+		if n, _ := obj["name"].(string); name != "" && !strings.Contains(strings.ToLower(n), name) {
+			continue
+		}
+		results = append(results, obj)
+	}
+
+	count := len(results)
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	results = results[min(max(offset, 0), len(results)):]
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit >= 0 && limit < len(results) {
+		results = results[:limit]
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"count":   count,
+		"results": results,
+	})
+}
+
+func (s *Server) serveValidate(w http.ResponseWriter, r *http.Request, c Collection) {
+	obj, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+	if missing := c.missing(obj); len(missing) > 0 {
+		writeError(w, http.StatusBadRequest, "missing required fields: %s", strings.Join(missing, ", "))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, c Collection, id string) {
+	existing, found := s.objects[c.Name][id]
+	if !found {
+		writeError(w, http.StatusNotFound, "%s %q not found", c.Name, id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, existing)
+	case http.MethodPut:
+		obj, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		if missing := c.missing(obj); len(missing) > 0 {
+			writeError(w, http.StatusBadRequest, "missing required fields: %s", strings.Join(missing, ", "))
+			return
+		}
+		for _, field := range []string{c.IDField, c.SecretField, "created", "creator"} {
+			if field == "" {
+				continue
+			}
+			obj[field] = existing[field]
+		}
+		obj["lastUpdated"] = s.clock().UnixMilli()
+		obj["lastUpdatedBy"] = creator
+		s.objects[c.Name][id] = obj
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		delete(s.objects[c.Name], id)
+		s.order[c.Name] = slices.DeleteFunc(s.order[c.Name], func(v string) bool { return v == id })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+	}
+}
+
+func (s *Server) serveAction(w http.ResponseWriter, r *http.Request, c Collection, id, action string) {
+	obj, found := s.objects[c.Name][id]
+	if !found {
+		writeError(w, http.StatusNotFound, "%s %q not found", c.Name, id)
+		return
+	}
+	switch action {
+	case "rotate":
+		s.serveRotate(w, r, c, obj)
+	default:
+		s.serveRuleAction(w, r, obj, action)
+	}
+}
+
+// serveRotate replaces the secret of the object, the previous secret and
+// the requested grace period are recorded so tests can inspect them.
+func (s *Server) serveRotate(w http.ResponseWriter, r *http.Request, c Collection, obj map[string]any) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+		return
+	}
+	grace := 0
+	if v := r.URL.Query().Get("graceful"); v != "" {
+		var err error
+		if grace, err = strconv.Atoi(v); err != nil || grace < 0 {
+			writeError(w, http.StatusBadRequest, "invalid graceful value %q", v)
+			return
+		}
+	}
+	obj["previousSecret"] = obj[c.SecretField]
+	obj["previousSecretGracePeriod"] = grace
+	obj[c.SecretField] = s.newSecret()
+	obj["lastUpdated"] = s.clock().UnixMilli()
+	obj["lastUpdatedBy"] = creator
+	writeJSON(w, http.StatusOK, obj)
+}
+
+// serveRuleAction handles the enable and disable actions that
+// update the `disabled` field of each matching rule.
+func (s *Server) serveRuleAction(w http.ResponseWriter, r *http.Request, obj map[string]any, action string) {
+	if r.Method != http.MethodPut {
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+		return
+	}
+	var labels []string
+	if err := json.NewDecoder(r.Body).Decode(&labels); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %s", err)
+		return
+	}
+	rules, _ := obj["rules"].([]any)
+	for _, v := range rules {
+		rule, _ := v.(map[string]any)
+		if label, _ := rule["detectLabel"].(string); rule != nil && slices.Contains(labels, label) {
+			rule["disabled"] = action == "disable"
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// insert stores the object, a non zero status is returned with a message
+// when the object could not be stored.
+func (s *Server) insert(c Collection, obj map[string]any) (string, int, string) {
+	id, _ := obj[c.IDField].(string)
+	switch {
+	case c.IDField == "name" && id == "":
+		return "", http.StatusBadRequest, "missing required fields: name"
+	case c.IDField == "name":
+		if _, exists := s.objects[c.Name][id]; exists {
+			return "", http.StatusConflict, fmt.Sprintf("%s %q already exists", c.Name, id)
+		}
+	case id == "":
+		id = s.newID(c)
+	}
+
+	if c.SecretField != "" {
+		if secret, _ := obj[c.SecretField].(string); secret == "" {
+			obj[c.SecretField] = s.newSecret()
+		}
+	}
+
+	now := s.clock().UnixMilli()
+	obj[c.IDField] = id
+	obj["created"] = now
+	obj["creator"] = creator
+	obj["lastUpdated"] = now
+	obj["lastUpdatedBy"] = creator
+
+	s.objects[c.Name][id] = obj
+	s.order[c.Name] = append(s.order[c.Name], id)
+	return id, 0, ""
+}
+
+func (s *Server) newID(c Collection) string {
+	for {
+		id := s.randomString(idLength)
+		if _, exists := s.objects[c.Name][id]; !exists {
+			return id
+		}
+	}
+}
+
+func (s *Server) newSecret() string {
+	return s.randomString(secretLength)
+}
+
+func (s *Server) randomString(n int) string {
+	var sb strings.Builder
+	for range n {
+		sb.WriteByte(idAlphabet[s.rand.IntN(len(idAlphabet))])
+	}
+	return sb.String()
+}
+
+func (s *Server) collection(name string) (Collection, bool) {
+	for _, c := range s.collections {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Collection{}, false
+}
+
+func readObject(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	var obj map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %s", err)
+		return nil, false
+	}
+	if obj == nil {
+		obj = make(map[string]any)
+	}
+	return obj, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]any{
+		"code":    status,
+		"message": fmt.Sprintf(format, args...),
+	})
+}
+
+func unescape(segment string) (string, bool) {
+	v, err := url.PathUnescape(segment)
+	return v, err == nil
+}
+
+func clone(obj map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var copied map[string]any
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	if copied == nil {
+		copied = make(map[string]any)
+	}
+	return copied, nil
+}
@@ -0,0 +1,249 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, s *Server) func(method, path, body string) (int, map[string]any) {
+	mux := http.NewServeMux()
+	for pattern, h := range s.Endpoints() {
+		mux.Handle(pattern, h)
+	}
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	return func(method, path, body string) (int, map[string]any) {
+		req, err := http.NewRequestWithContext(t.Context(), method, ts.URL+path, strings.NewReader(body))
+		require.NoError(t, err, "Must create request")
+		req.Header.Set(authHeader, "token")
+
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err, "Must complete request")
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err, "Must read response")
+
+		var obj map[string]any
+		if len(data) > 0 {
+			require.NoError(t, json.Unmarshal(data, &obj), "Must return valid json")
+		}
+		return resp.StatusCode, obj
+	}
+}
+
+func TestServerLifecycle(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	s := New(WithSeed(1), WithClock(func() time.Time { return now }))
+	do := newTestClient(t, s)
+
+	status, created := do(http.MethodPost, "/v2/detector", `{"name":"cpu","programText":"detect(when(A > 1)).publish('high')"}`)
+	require.Equal(t, http.StatusOK, status, "Must create the detector")
+
+	id, _ := created["id"].(string)
+	assert.Len(t, id, idLength, "Must generate an ID")
+	assert.Equal(t, float64(now.UnixMilli()), created["created"], "Must set the created time")
+	assert.Equal(t, 1, s.Len(Detectors), "Must store the detector")
+
+	status, read := do(http.MethodGet, "/v2/detector/"+id, "")
+	assert.Equal(t, http.StatusOK, status, "Must read the detector")
+	assert.Equal(t, created, read, "Must return the stored detector")
+
+	status, updated := do(http.MethodPut, "/v2/detector/"+id, `{"name":"cpu updated","programText":"A = data('cpu')"}`)
+	assert.Equal(t, http.StatusOK, status, "Must update the detector")
+	assert.Equal(t, id, updated["id"], "Must keep the detector ID")
+	assert.Equal(t, "cpu updated", updated["name"], "Must store the updated name")
+	assert.Equal(t, created["created"], updated["created"], "Must keep the created time")
+
+	status, _ = do(http.MethodDelete, "/v2/detector/"+id, "")
+	assert.Equal(t, http.StatusNoContent, status, "Must delete the detector")
+
+	status, body := do(http.MethodGet, "/v2/detector/"+id, "")
+	assert.Equal(t, http.StatusNotFound, status, "Must not find the deleted detector")
+	assert.Equal(t, float64(http.StatusNotFound), body["code"], "Must return the error code")
+	assert.Equal(t, 0, s.Len(Detectors), "Must remove the detector")
+}
+
+func TestServerErrors(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	do := newTestClient(t, s)
+
+	for _, tc := range []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		status  int
+		message string
+	}{
+		{
+			name:    "missing required fields",
+			method:  http.MethodPost,
+			path:    "/v2/detector",
+			body:    `{"name":"cpu"}`,
+			status:  http.StatusBadRequest,
+			message: "missing required fields: programText",
+		},
+		{
+			name:    "invalid body",
+			method:  http.MethodPost,
+			path:    "/v2/team",
+			body:    `{`,
+			status:  http.StatusBadRequest,
+			message: "invalid request body: unexpected EOF",
+		},
+		{
+			name:    "unknown object",
+			method:  http.MethodPut,
+			path:    "/v2/chart/AAAAAAAAAAA",
+			body:    `{"name":"chart"}`,
+			status:  http.StatusNotFound,
+			message: "chart \"AAAAAAAAAAA\" not found",
+		},
+		{
+			name:    "unknown route",
+			method:  http.MethodGet,
+			path:    "/v2/dashboard/AAAAAAAAAAA/unknown",
+			status:  http.StatusNotFound,
+			message: "unknown route /v2/dashboard/AAAAAAAAAAA/unknown",
+		},
+		{
+			name:    "method not allowed",
+			method:  http.MethodPatch,
+			path:    "/v2/slo",
+			status:  http.StatusMethodNotAllowed,
+			message: "method PATCH not allowed",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status, body := do(tc.method, tc.path, tc.body)
+			assert.Equal(t, tc.status, status, "Must match the expected status")
+			assert.Equal(t, tc.message, body["message"], "Must match the expected message")
+		})
+	}
+}
+
+func TestServerUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	New().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/team", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "Must reject requests without a token")
+}
+
+func TestServerList(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	for _, name := range []string{"alpha", "beta", "alphabet"} {
+		_, err := s.Seed(Teams, map[string]any{"name": name})
+		require.NoError(t, err, "Must seed team")
+	}
+	do := newTestClient(t, s)
+
+	status, body := do(http.MethodGet, "/v2/team?name=alpha&limit=1", "")
+	assert.Equal(t, http.StatusOK, status, "Must list teams")
+	assert.Equal(t, float64(2), body["count"], "Must count all matching teams")
+	if assert.Len(t, body["results"], 1, "Must limit the results") {
+		assert.Equal(t, "alpha", body["results"].([]any)[0].(map[string]any)["name"], "Must keep the insert order")
+	}
+
+	_, body = do(http.MethodGet, "/v2/team?offset=2", "")
+	if assert.Len(t, body["results"], 1, "Must apply the offset") {
+		assert.Equal(t, "alphabet", body["results"].([]any)[0].(map[string]any)["name"], "Must return the remaining team")
+	}
+}
+
+func TestServerOrgTokens(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	do := newTestClient(t, s)
+
+	status, created := do(http.MethodPost, "/v2/token", `{"name":"my token","authScopes":["API"]}`)
+	require.Equal(t, http.StatusOK, status, "Must create the token")
+	assert.Len(t, created["secret"], secretLength, "Must generate a secret")
+
+	status, _ = do(http.MethodPost, "/v2/token", `{"name":"my token"}`)
+	assert.Equal(t, http.StatusConflict, status, "Must not allow duplicate token names")
+
+	status, updated := do(http.MethodPut, "/v2/token/my%20token", `{"name":"my token","disabled":true}`)
+	assert.Equal(t, http.StatusOK, status, "Must update the token by name")
+	assert.Equal(t, created["secret"], updated["secret"], "Must keep the token secret")
+	assert.Equal(t, true, updated["disabled"], "Must store the update")
+
+	status, rotated := do(http.MethodPost, "/v2/token/my%20token/rotate?graceful=3600", "")
+	assert.Equal(t, http.StatusOK, status, "Must rotate the token")
+	assert.NotEqual(t, created["secret"], rotated["secret"], "Must generate a new secret")
+	assert.Equal(t, created["secret"], rotated["previousSecret"], "Must record the previous secret")
+	assert.EqualValues(t, 3600, rotated["previousSecretGracePeriod"], "Must record the grace period")
+
+	status, _ = do(http.MethodPost, "/v2/token/my%20token/rotate?graceful=-1", "")
+	assert.Equal(t, http.StatusBadRequest, status, "Must reject a negative grace period")
+
+	status, _ = do(http.MethodPut, "/v2/token/my%20token/rotate", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status, "Must only rotate using POST")
+}
+
+func TestServerDetectorActions(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	id, err := s.Seed(Detectors, map[string]any{
+		"name":        "cpu",
+		"programText": "detect(when(A > 1)).publish('high')",
+		"rules": []any{
+			map[string]any{"detectLabel": "high", "disabled": false},
+		},
+	})
+	require.NoError(t, err, "Must seed detector")
+	do := newTestClient(t, s)
+
+	status, _ := do(http.MethodPost, "/v2/detector/validate", `{"name":"cpu","programText":"A = data('cpu')"}`)
+	assert.Equal(t, http.StatusNoContent, status, "Must validate the detector")
+
+	status, _ = do(http.MethodPut, "/v2/detector/"+id+"/disable", `["high"]`)
+	assert.Equal(t, http.StatusNoContent, status, "Must disable the rule")
+
+	det, ok := s.Get(Detectors, id)
+	require.True(t, ok, "Must find the detector")
+	assert.Equal(t, true, det["rules"].([]any)[0].(map[string]any)["disabled"], "Must mark the rule as disabled")
+}
+
+func TestServerSeed(t *testing.T) {
+	t.Parallel()
+
+	_, err := New().Seed("unknown", map[string]any{})
+	assert.EqualError(t, err, "unknown collection \"unknown\"", "Must error with unknown collections")
+
+	_, err = New().Seed(OrgTokens, map[string]any{})
+	assert.EqualError(t, err, "unable to seed token: missing required fields: name", "Must error without a token name")
+
+	a, err := New(WithSeed(10)).Seed(Charts, map[string]any{"name": "chart"})
+	require.NoError(t, err, "Must seed chart")
+	b, err := New(WithSeed(10)).Seed(Charts, map[string]any{"name": "chart"})
+	require.NoError(t, err, "Must seed chart")
+	assert.Equal(t, a, b, "Must generate the same ID from the same seed")
+
+	id, err := New().Seed(Charts, map[string]any{"id": "existing", "name": "chart"})
+	require.NoError(t, err, "Must seed chart")
+	assert.Equal(t, "existing", id, "Must keep the provided ID")
+}
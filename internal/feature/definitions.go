@@ -18,9 +18,10 @@ package feature
 // - Set the version added in (this helps sorting oldest previews to newest)
 
 const (
-	PreviewProviderTeams    = "provider.teams"
-	PreviewProviderTags     = "provider.tags"
-	PreviewProviderTracking = "provider.track"
+	PreviewProviderTeams     = "provider.teams"
+	PreviewProviderTags      = "provider.tags"
+	PreviewProviderTracking  = "provider.track"
+	PreviewProviderReadCache = "provider.read_cache"
 )
 
 var (
@@ -40,4 +41,10 @@ var (
 		WithPreviewDescription("Allows for the project's VCS information to be added to the global tags to provide additional context for resources created"),
 		WithPreviewAddInVersion("v9.14.0"),
 	)
+
+	_ = GetGlobalRegistry().MustRegister(
+		PreviewProviderReadCache,
+		WithPreviewDescription("Stores the API responses read during a run so that the same object is only read once, a write to an object type removes the stored responses of that type"),
+		WithPreviewAddInVersion("v9.15.0"),
+	)
 )
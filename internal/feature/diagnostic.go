@@ -0,0 +1,64 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Severity is how a [Diagnostic] is to be reported by the provider.
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+// DiagnosticKind identifies the reason a [Diagnostic] was raised
+// so that callers do not depend on the message content.
+type DiagnosticKind int
+
+const (
+	// DiagnosticUnknown is raised when the configured preview is not registered.
+	DiagnosticUnknown DiagnosticKind = iota
+	// DiagnosticGlobalAvailable is raised when the configured preview is enabled by default.
+	DiagnosticGlobalAvailable
+	// DiagnosticDeprecated is raised when the configured preview is deprecated in the provider version.
+	DiagnosticDeprecated
+	// DiagnosticRemoved is raised when the configured preview has been removed in the provider version.
+	DiagnosticRemoved
+)
+
+// Diagnostic is an issue found configuring a feature preview,
+// it is kept independent of the Terraform SDKs so each provider can report it.
+type Diagnostic struct {
+	Severity Severity
+	Kind     DiagnosticKind
+	Feature  string
+	Summary  string
+	Detail   string
+}
+
+// Diagnostics is the collection of issues found while configuring feature previews.
+type Diagnostics []Diagnostic
+
+// HasError reports if any diagnostic should stop the provider from being configured.
+func (diags Diagnostics) HasError() bool {
+	return slices.ContainsFunc(diags, func(d Diagnostic) bool {
+		return d.Severity == SeverityError
+	})
+}
+
+// Err returns the error diagnostics joined together, or nil if there is none.
+func (diags Diagnostics) Err() error {
+	var errs []error
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			errs = append(errs, fmt.Errorf("feature_preview %q: %s", d.Feature, d.Detail))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,57 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package feature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		diags  Diagnostics
+		hasErr bool
+		errVal string
+	}{
+		{
+			name:   "no diagnostics",
+			diags:  nil,
+			hasErr: false,
+			errVal: "",
+		},
+		{
+			name: "warnings only",
+			diags: Diagnostics{
+				{Severity: SeverityWarning, Kind: DiagnosticDeprecated, Feature: "provider.tags", Summary: "summary", Detail: "detail"},
+			},
+			hasErr: false,
+			errVal: "",
+		},
+		{
+			name: "errors and warnings",
+			diags: Diagnostics{
+				{Severity: SeverityWarning, Kind: DiagnosticDeprecated, Feature: "provider.tags", Summary: "summary", Detail: "detail"},
+				{Severity: SeverityError, Kind: DiagnosticUnknown, Feature: "provider.tag", Summary: "unknown", Detail: "not found"},
+				{Severity: SeverityError, Kind: DiagnosticRemoved, Feature: "provider.teams", Summary: "removed", Detail: "was removed"},
+			},
+			hasErr: true,
+			errVal: "feature_preview \"provider.tag\": not found\nfeature_preview \"provider.teams\": was removed",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.hasErr, tc.diags.HasError(), "Must match the expected error state")
+			if tc.errVal != "" {
+				assert.EqualError(t, tc.diags.Err(), tc.errVal, "Must match the expected error")
+			} else {
+				assert.NoError(t, tc.diags.Err(), "Must not return an error")
+			}
+		})
+	}
+}
@@ -28,6 +28,8 @@ type Preview struct {
 	available   bool
 	description string
 	introduced  string
+	deprecated  string
+	removed     string
 }
 
 func NewPreview(opts ...PreviewOption) (*Preview, error) {
@@ -56,6 +58,14 @@ func NewPreviewLogFields(name string, p *Preview) tfext.LogFields {
 	return lf
 }
 
+// clone returns a copy of the preview that can be enabled independently.
+func (p *Preview) clone() *Preview {
+	c := *p
+	c.enabled = new(atomic.Bool)
+	c.enabled.Store(p.Enabled())
+	return &c
+}
+
 func (p Preview) Enabled() bool {
 	return p.enabled.Load()
 }
@@ -75,3 +85,15 @@ func (p Preview) Description() string {
 func (p Preview) Introduced() string {
 	return p.introduced
 }
+
+// Deprecated returns the provider version the preview was deprecated in,
+// or an empty string if it is not deprecated.
+func (p Preview) Deprecated() string {
+	return p.deprecated
+}
+
+// Removed returns the provider version the preview is removed in,
+// or an empty string if there is no planned removal.
+func (p Preview) Removed() string {
+	return p.removed
+}
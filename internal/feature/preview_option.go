@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+
+	goversion "github.com/hashicorp/go-version"
 )
 
 type PreviewOption func(g *Preview) error
@@ -28,18 +30,38 @@ func WithPreviewGlobalAvailable() PreviewOption {
 
 func WithPreviewAddInVersion(version string) PreviewOption {
 	return func(g *Preview) error {
-		matched, err := regexp.MatchString(`^v[1-9]+\.[0-9]+`, version)
-		if err != nil {
+		if err := validateVersion(version); err != nil {
 			return err
 		}
-		if !matched {
-			return fmt.Errorf("version string %q needs to be in format vX.Y[.+]", version)
-		}
 		g.introduced = version
 		return nil
 	}
 }
 
+// WithPreviewDeprecatedInVersion marks the preview as deprecated from the provider version,
+// configuring the preview from that version will return a warning.
+func WithPreviewDeprecatedInVersion(version string) PreviewOption {
+	return func(g *Preview) error {
+		if err := validateVersion(version); err != nil {
+			return err
+		}
+		g.deprecated = version
+		return nil
+	}
+}
+
+// WithPreviewRemovedInVersion sets the provider version the preview is removed in,
+// configuring the preview from that version will return an error.
+func WithPreviewRemovedInVersion(version string) PreviewOption {
+	return func(g *Preview) error {
+		if err := validateVersion(version); err != nil {
+			return err
+		}
+		g.removed = version
+		return nil
+	}
+}
+
 func WithPreviewDescription(description string) PreviewOption {
 	return func(g *Preview) error {
 		if description == "" {
@@ -49,3 +71,14 @@ func WithPreviewDescription(description string) PreviewOption {
 		return nil
 	}
 }
+
+func validateVersion(version string) error {
+	matched, err := regexp.MatchString(`^v[1-9][0-9]*\.[0-9]+`, version)
+	if err != nil {
+		return err
+	}
+	if _, err := goversion.NewVersion(version); !matched || err != nil {
+		return fmt.Errorf("version string %q needs to be in format vX.Y[.+]", version)
+	}
+	return nil
+}
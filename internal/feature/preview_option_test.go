@@ -38,6 +38,26 @@ func TestPreviewOption(t *testing.T) {
 			fn:     WithPreviewAddInVersion("v2.1.0"),
 			errVal: "",
 		},
+		{
+			name:   "Bad DeprecatedInVersion",
+			fn:     WithPreviewDeprecatedInVersion("9.16.0"),
+			errVal: "version string \"9.16.0\" needs to be in format vX.Y[.+]",
+		},
+		{
+			name:   "Valid DeprecatedInVersion",
+			fn:     WithPreviewDeprecatedInVersion("v9.16.0"),
+			errVal: "",
+		},
+		{
+			name:   "Bad RemovedInVersion",
+			fn:     WithPreviewRemovedInVersion("v10.x"),
+			errVal: "version string \"v10.x\" needs to be in format vX.Y[.+]",
+		},
+		{
+			name:   "Valid RemovedInVersion",
+			fn:     WithPreviewRemovedInVersion("v10.0.0"),
+			errVal: "",
+		},
 		{
 			name:   "Invalid Description",
 			fn:     WithPreviewDescription(""),
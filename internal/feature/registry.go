@@ -7,20 +7,40 @@ import (
 	"context"
 	"fmt"
 	"iter"
+	"maps"
 	"regexp"
+	"slices"
+	"strings"
 	"sync"
 
+	goversion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+	"github.com/splunk-terraform/terraform-provider-signalfx/version"
 )
 
 type Registry struct {
 	features sync.Map
+	version  string
 }
 
-func NewRegistry() *Registry {
-	return &Registry{}
+type RegistryOption func(r *Registry)
+
+// WithRegistryProviderVersion sets the provider version that is compared to
+// the deprecation and removal version of each preview, defaults to [version.ProviderVersion].
+func WithRegistryProviderVersion(version string) RegistryOption {
+	return func(r *Registry) {
+		r.version = version
+	}
+}
+
+func NewRegistry(opts ...RegistryOption) *Registry {
+	r := &Registry{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *Registry) All() iter.Seq2[string, *Preview] {
@@ -31,23 +51,68 @@ func (r *Registry) All() iter.Seq2[string, *Preview] {
 	}
 }
 
-func (reg *Registry) Configure(ctx context.Context, feature string, enabled bool) error {
+// Configure sets the preview to enabled, returning diagnostics for any issues
+// the user should be made aware of.
+//
+// Configuring an unknown preview or one that has been removed in the provider version
+// returns an error diagnostic and leaves the registry unchanged.
+func (reg *Registry) Configure(ctx context.Context, feature string, enabled bool) Diagnostics {
 	p, ok := reg.Get(feature)
 	if !ok {
-		return fmt.Errorf("no preview with id %q found", feature)
+		detail := fmt.Sprintf("no preview with id %q found", feature)
+		if names := reg.suggestions(feature); len(names) > 0 {
+			detail += fmt.Sprintf(", did you mean %q?", strings.Join(names, `", "`))
+		}
+		return Diagnostics{{
+			Severity: SeverityError,
+			Kind:     DiagnosticUnknown,
+			Feature:  feature,
+			Summary:  "Unknown feature preview",
+			Detail:   detail,
+		}}
+	}
+
+	if p.Removed() != "" && reg.released(p.Removed()) {
+		return Diagnostics{{
+			Severity: SeverityError,
+			Kind:     DiagnosticRemoved,
+			Feature:  feature,
+			Summary:  "Feature preview has been removed",
+			Detail: fmt.Sprintf(
+				"The preview %q was removed in %s and can no longer be configured, remove it from the provider configuration",
+				feature, p.Removed(),
+			),
+		}}
+	}
+
+	var diags Diagnostics
+	if p.Deprecated() != "" && reg.released(p.Deprecated()) {
+		detail := fmt.Sprintf("The preview %q was deprecated in %s", feature, p.Deprecated())
+		if p.Removed() != "" {
+			detail += fmt.Sprintf(" and will be removed in %s", p.Removed())
+		}
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Kind:     DiagnosticDeprecated,
+			Feature:  feature,
+			Summary:  "Feature preview is deprecated",
+			Detail:   detail + ", remove it from the provider configuration",
+		})
 	}
 
 	if p.GlobalAvailable() {
-		tflog.Warn(
-			ctx,
-			"Preview has been marked as Global Available,"+
-				" it is no longer required to be configured. "+
-				"If you're experiencing issues with a new feature, "+
-				"please reach out to customer support so the issue can be addressed",
-			tfext.NewLogFields().
-				Field("feature", feature).
-				Field("enabled", p.Enabled()),
-		)
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Kind:     DiagnosticGlobalAvailable,
+			Feature:  feature,
+			Summary:  "Feature preview is globally available",
+			Detail: fmt.Sprintf(
+				"The preview %q has been marked as Global Available, it is no longer required to be configured. "+
+					"If you're experiencing issues with a new feature, "+
+					"please reach out to customer support so the issue can be addressed",
+				feature,
+			),
+		})
 	}
 
 	p.SetEnabled(enabled)
@@ -56,10 +121,47 @@ func (reg *Registry) Configure(ctx context.Context, feature string, enabled bool
 		Field("feature", feature).
 		Field("enabled", p.Enabled()).
 		Field("added_in", p.Introduced()).
+		Field("deprecated_in", p.Deprecated()).
+		Field("removed_in", p.Removed()).
 		Field("description", p.Description()),
 	)
 
-	return nil
+	return diags
+}
+
+// released reports if the provider version is at or above the preview version.
+// A provider version that can not be parsed is a development build,
+// so it is considered to be newer than any release.
+func (reg *Registry) released(at string) bool {
+	current := reg.version
+	if current == "" {
+		current = version.ProviderVersion
+	}
+	pv, err := goversion.NewVersion(current)
+	if err != nil {
+		return true
+	}
+	v, err := goversion.NewVersion(at)
+	if err != nil {
+		return false
+	}
+	return pv.GreaterThanOrEqual(v)
+}
+
+// Scope returns a copy of the registry with the overrides configured,
+// the registry itself is left unchanged so that the overrides only apply
+// to the caller of the returned registry.
+func (reg *Registry) Scope(ctx context.Context, overrides map[string]bool) (*Registry, Diagnostics) {
+	scoped := NewRegistry(WithRegistryProviderVersion(reg.version))
+	for name, p := range reg.All() {
+		scoped.features.Store(name, p.clone())
+	}
+
+	var diags Diagnostics
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		diags = append(diags, scoped.Configure(ctx, name, overrides[name])...)
+	}
+	return scoped, diags
 }
 
 func (reg *Registry) Get(id string) (*Preview, bool) {
@@ -101,3 +203,53 @@ func (reg *Registry) MustRegister(feature string, opts ...PreviewOption) *Previe
 	}
 	return g
 }
+
+// suggestions returns the registered previews that are close to the feature name,
+// ordered from the closest match.
+func (reg *Registry) suggestions(feature string) []string {
+	type match struct {
+		name string
+		dist int
+	}
+	var matches []match
+	for name := range reg.All() {
+		dist := levenshtein(feature, name)
+		// Allows for small typos, or for the scope to be left out of the name.
+		if dist <= 2 || strings.HasSuffix(name, "."+feature) {
+			matches = append(matches, match{name: name, dist: dist})
+		}
+	}
+	slices.SortFunc(matches, func(a, b match) int {
+		if a.dist != b.dist {
+			return a.dist - b.dist
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		names = append(names, m.name)
+	}
+	return names
+}
+
+// levenshtein returns the number of single character edits to change a into b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
@@ -136,44 +136,212 @@ func TestRegistryConfigure(t *testing.T) {
 
 	for _, tc := range []struct {
 		name    string
+		version string
 		opts    []PreviewOption
 		feature string
-		errVal  string
+		enabled bool
+		diags   Diagnostics
 	}{
 		{
 			name:    "default preview",
+			version: "v9.15.0",
 			opts:    []PreviewOption{},
 			feature: "feature-01",
-			errVal:  "",
+			enabled: true,
+			diags:   nil,
 		},
 		{
 			name:    "unset preview",
+			version: "v9.15.0",
+			opts:    []PreviewOption{},
+			feature: "example",
+			enabled: false,
+			diags: Diagnostics{
+				{
+					Severity: SeverityError,
+					Kind:     DiagnosticUnknown,
+					Feature:  "example",
+					Summary:  "Unknown feature preview",
+					Detail:   "no preview with id \"example\" found",
+				},
+			},
+		},
+		{
+			name:    "unset preview with close match",
+			version: "v9.15.0",
 			opts:    []PreviewOption{},
 			feature: "feature-02",
-			errVal:  "no preview with id \"feature-02\" found",
+			enabled: false,
+			diags: Diagnostics{
+				{
+					Severity: SeverityError,
+					Kind:     DiagnosticUnknown,
+					Feature:  "feature-02",
+					Summary:  "Unknown feature preview",
+					Detail:   "no preview with id \"feature-02\" found, did you mean \"feature-01\"?",
+				},
+			},
 		},
 		{
 			name: "globally available",
 			opts: []PreviewOption{
 				WithPreviewGlobalAvailable(),
 			},
+			version: "v9.15.0",
 			feature: "feature-01",
-			errVal:  "",
+			enabled: true,
+			diags: Diagnostics{
+				{
+					Severity: SeverityWarning,
+					Kind:     DiagnosticGlobalAvailable,
+					Feature:  "feature-01",
+					Summary:  "Feature preview is globally available",
+					Detail: "The preview \"feature-01\" has been marked as Global Available, it is no longer required to be configured. " +
+						"If you're experiencing issues with a new feature, please reach out to customer support so the issue can be addressed",
+				},
+			},
+		},
+		{
+			name: "deprecated in a later version",
+			opts: []PreviewOption{
+				WithPreviewDeprecatedInVersion("v9.16.0"),
+				WithPreviewRemovedInVersion("v10.0.0"),
+			},
+			version: "v9.15.0",
+			feature: "feature-01",
+			enabled: true,
+			diags:   nil,
+		},
+		{
+			name: "deprecated",
+			opts: []PreviewOption{
+				WithPreviewDeprecatedInVersion("v9.15.0"),
+				WithPreviewRemovedInVersion("v10.0.0"),
+			},
+			version: "v9.15.1",
+			feature: "feature-01",
+			enabled: true,
+			diags: Diagnostics{
+				{
+					Severity: SeverityWarning,
+					Kind:     DiagnosticDeprecated,
+					Feature:  "feature-01",
+					Summary:  "Feature preview is deprecated",
+					Detail:   "The preview \"feature-01\" was deprecated in v9.15.0 and will be removed in v10.0.0, remove it from the provider configuration",
+				},
+			},
+		},
+		{
+			name: "removed",
+			opts: []PreviewOption{
+				WithPreviewDeprecatedInVersion("v9.15.0"),
+				WithPreviewRemovedInVersion("v10.0.0"),
+			},
+			version: "v10.0.0",
+			feature: "feature-01",
+			enabled: false,
+			diags: Diagnostics{
+				{
+					Severity: SeverityError,
+					Kind:     DiagnosticRemoved,
+					Feature:  "feature-01",
+					Summary:  "Feature preview has been removed",
+					Detail:   "The preview \"feature-01\" was removed in v10.0.0 and can no longer be configured, remove it from the provider configuration",
+				},
+			},
+		},
+		{
+			name: "removed in development build",
+			opts: []PreviewOption{
+				WithPreviewRemovedInVersion("v10.0.0"),
+			},
+			version: "dev",
+			feature: "feature-01",
+			enabled: false,
+			diags: Diagnostics{
+				{
+					Severity: SeverityError,
+					Kind:     DiagnosticRemoved,
+					Feature:  "feature-01",
+					Summary:  "Feature preview has been removed",
+					Detail:   "The preview \"feature-01\" was removed in v10.0.0 and can no longer be configured, remove it from the provider configuration",
+				},
+			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			reg := NewRegistry()
-			_, err := reg.Register("feature-01", tc.opts...)
+			reg := NewRegistry(WithRegistryProviderVersion(tc.version))
+			p, err := reg.Register("feature-01", tc.opts...)
 			require.NoError(t, err, "Must not error when register a feature preview")
+			p.SetEnabled(false)
 
-			err = reg.Configure(context.Background(), tc.feature, true)
-			if tc.errVal != "" {
-				assert.EqualError(t, err, tc.errVal, "Must match the expected value")
-			} else {
-				assert.NoError(t, err, "Must not return an error")
-			}
+			diags := reg.Configure(context.Background(), tc.feature, true)
+			assert.Equal(t, tc.diags, diags, "Must match the expected diagnostics")
+			assert.Equal(t, tc.enabled, p.Enabled(), "Must match the expected enabled state")
+		})
+	}
+}
+
+func TestRegistryScope(t *testing.T) {
+	t.Parallel()
+
+	reg := NewRegistry(WithRegistryProviderVersion("v10.0.0"))
+	tags := reg.MustRegister(PreviewProviderTags)
+	teams := reg.MustRegister(PreviewProviderTeams, WithPreviewGlobalAvailable())
+	reg.MustRegister(PreviewProviderTracking, WithPreviewRemovedInVersion("v10.0.0"))
+
+	scoped, diags := reg.Scope(t.Context(), map[string]bool{
+		PreviewProviderTags:     true,
+		PreviewProviderTeams:    false,
+		PreviewProviderTracking: true,
+	})
+	require.Len(t, diags, 2, "Must report the globally available and removed previews")
+	assert.Equal(t, DiagnosticGlobalAvailable, diags[0].Kind, "Must be ordered by the preview name")
+	assert.Equal(t, DiagnosticRemoved, diags[1].Kind, "Must be ordered by the preview name")
+
+	p, ok := scoped.Get(PreviewProviderTags)
+	require.True(t, ok, "Must copy each preview")
+	assert.True(t, p.Enabled(), "Must enable the scoped preview")
+	assert.False(t, tags.Enabled(), "Must not modify the original preview")
+
+	p, ok = scoped.Get(PreviewProviderTeams)
+	require.True(t, ok, "Must copy each preview")
+	assert.False(t, p.Enabled(), "Must opt out of the scoped preview")
+	assert.True(t, teams.Enabled(), "Must not modify the original preview")
+
+	p, ok = scoped.Get(PreviewProviderTracking)
+	require.True(t, ok, "Must copy each preview")
+	assert.False(t, p.Enabled(), "Must not enable the removed preview")
+
+	scoped.MustRegister("scoped.only")
+	_, ok = reg.Get("scoped.only")
+	assert.False(t, ok, "Must not register previews in the original registry")
+}
+
+func TestRegistrySuggestions(t *testing.T) {
+	t.Parallel()
+
+	reg := NewRegistry()
+	for _, name := range []string{PreviewProviderTags, PreviewProviderTeams, PreviewProviderTracking, PreviewProviderReadCache} {
+		reg.MustRegister(name)
+	}
+
+	for _, tc := range []struct {
+		feature string
+		expect  []string
+	}{
+		{feature: "provider.tag", expect: []string{PreviewProviderTags}},
+		{feature: "provider.team", expect: []string{PreviewProviderTeams}},
+		{feature: "provider.readcache", expect: []string{PreviewProviderReadCache}},
+		{feature: "read_cache", expect: []string{PreviewProviderReadCache}},
+		{feature: "detectors.validation", expect: []string{}},
+	} {
+		t.Run(tc.feature, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expect, reg.suggestions(tc.feature), "Must match the expected suggestions")
 		})
 	}
 }
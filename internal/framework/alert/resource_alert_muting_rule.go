@@ -29,6 +29,7 @@ import (
 	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwerr"
 	fwshared "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/shared"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
 const alertMutingDetectorIDProperty = "sf_detectorId"
@@ -171,13 +172,20 @@ func (amr *ResourceAlertMutingRule) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	meta, diags := amr.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_alert_muting_rule", "")
+
 	payload, diags := model.toRequest(ctx, false, time.Now())
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	details, err := amr.Details().Client.CreateAlertMutingRule(ctx, payload)
+	details, err := meta.Client.CreateAlertMutingRule(ctx, payload)
 	if resp.Diagnostics.Append(fwerr.ErrorHandler(ctx, resp.State, err)...); resp.Diagnostics.HasError() {
 		return
 	}
@@ -195,7 +203,14 @@ func (amr *ResourceAlertMutingRule) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	details, err := amr.Details().Client.GetAlertMutingRule(ctx, model.ID.ValueString())
+	meta, diags := amr.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_alert_muting_rule", model.ID.ValueString())
+
+	details, err := meta.Client.GetAlertMutingRule(ctx, model.ID.ValueString())
 	if resp.Diagnostics.Append(fwerr.ErrorHandler(ctx, resp.State, err)...); resp.Diagnostics.HasError() || err != nil {
 		return
 	}
@@ -221,13 +236,20 @@ func (amr *ResourceAlertMutingRule) Update(ctx context.Context, req resource.Upd
 	model.ID = prior.ID
 	model.EffectiveStartTime = prior.EffectiveStartTime
 
+	meta, diags := amr.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_alert_muting_rule", model.ID.ValueString())
+
 	payload, diags := model.toRequest(ctx, true, time.Now())
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	details, err := amr.Details().Client.UpdateAlertMutingRule(ctx, model.ID.ValueString(), payload)
+	details, err := meta.Client.UpdateAlertMutingRule(ctx, model.ID.ValueString(), payload)
 	if resp.Diagnostics.Append(fwerr.ErrorHandler(ctx, resp.State, err)...); resp.Diagnostics.HasError() {
 		return
 	}
@@ -245,7 +267,14 @@ func (amr *ResourceAlertMutingRule) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
-	err := amr.Details().Client.DeleteAlertMutingRule(ctx, model.ID.ValueString())
+	meta, diags := amr.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_alert_muting_rule", model.ID.ValueString())
+
+	err := meta.Client.DeleteAlertMutingRule(ctx, model.ID.ValueString())
 	if err != nil && strings.Contains(err.Error(), "400") {
 		return
 	}
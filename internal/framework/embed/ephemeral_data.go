@@ -0,0 +1,41 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwembed
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+// EphemeralResourceData is an embeddable struct that provides common functionality for ephemeral resources,
+// since it implements the extended method required for [ephemeral.EphemeralResourceWithConfigure].
+type EphemeralResourceData struct {
+	meta *pmeta.Meta
+}
+
+func (ed *EphemeralResourceData) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// The configure can be called before the provider has actually been configured.
+	// To avoid against erroring early when this happens, the configure method should just return instead
+	if req.ProviderData == nil {
+		return
+	}
+
+	if meta, ok := req.ProviderData.(*pmeta.Meta); !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Empty(),
+			"Invalid Provider Data",
+			"Provider data must be configured before using the ephemeral resource.",
+		)
+	} else {
+		ed.meta = meta
+	}
+}
+
+func (ed *EphemeralResourceData) Details() *pmeta.Meta {
+	return ed.meta
+}
@@ -0,0 +1,70 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwembed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/stretchr/testify/assert"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+func TestEphemeralResource_Configure(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name              string
+		providerData      any
+		expectDiagnostics bool
+		expectedMeta      *pmeta.Meta
+	}{
+		{
+			name:              "valid provider data",
+			providerData:      &pmeta.Meta{},
+			expectDiagnostics: false,
+			expectedMeta:      &pmeta.Meta{},
+		},
+		{
+			name:              "invalid provider data - wrong type",
+			providerData:      "invalid",
+			expectDiagnostics: true,
+			expectedMeta:      nil,
+		},
+		{
+			name:              "nil provider data",
+			providerData:      nil,
+			expectDiagnostics: false,
+			expectedMeta:      nil,
+		},
+		{
+			name:              "invalid provider data - int type",
+			providerData:      42,
+			expectDiagnostics: true,
+			expectedMeta:      nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ed := &EphemeralResourceData{}
+			req := ephemeral.ConfigureRequest{
+				ProviderData: tc.providerData,
+			}
+			resp := &ephemeral.ConfigureResponse{
+				Diagnostics: diag.Diagnostics{},
+			}
+
+			ed.Configure(context.Background(), req, resp)
+
+			assert.Equal(t, tc.expectDiagnostics, resp.Diagnostics.HasError(), "Expected diagnostics to match")
+			assert.Equal(t, tc.expectedMeta, ed.Details(), "Expected meta to match")
+		})
+	}
+}
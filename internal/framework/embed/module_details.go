@@ -0,0 +1,63 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwembed
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+// ModuleConfigModel is the `provider_meta "signalfx"` block that is set within a module.
+type ModuleConfigModel struct {
+	FeaturePreview types.Map `tfsdk:"feature_preview"`
+}
+
+// ModuleDetails returns the provider details with the feature previews
+// set by the module's `provider_meta` block applied.
+func (rd *ResourceData) ModuleDetails(ctx context.Context, providerMeta tfsdk.Config) (*pmeta.Meta, diag.Diagnostics) {
+	return loadModuleDetails(ctx, rd.meta, providerMeta)
+}
+
+// ModuleDetails returns the provider details with the feature previews
+// set by the module's `provider_meta` block applied.
+func (dd *DatasourceData) ModuleDetails(ctx context.Context, providerMeta tfsdk.Config) (*pmeta.Meta, diag.Diagnostics) {
+	return loadModuleDetails(ctx, dd.meta, providerMeta)
+}
+
+func loadModuleDetails(ctx context.Context, meta *pmeta.Meta, providerMeta tfsdk.Config) (*pmeta.Meta, diag.Diagnostics) {
+	if meta == nil || providerMeta.Raw.IsNull() {
+		return meta, nil
+	}
+
+	var model ModuleConfigModel
+	diags := providerMeta.Get(ctx, &model)
+	if diags.HasError() || model.FeaturePreview.IsNull() || model.FeaturePreview.IsUnknown() {
+		return meta, diags
+	}
+
+	previews := make(map[string]bool, len(model.FeaturePreview.Elements()))
+	for name, val := range model.FeaturePreview.Elements() {
+		previews[name] = val.Equal(types.BoolValue(true))
+	}
+
+	// The diagnostics are not set with a path since it would
+	// refer to the resource rather than the provider_meta block.
+	scoped, issues := meta.WithModulePreviews(ctx, previews)
+	for _, d := range issues {
+		detail := fmt.Sprintf("provider_meta feature_preview %q: %s", d.Feature, d.Detail)
+		if d.Severity == feature.SeverityError {
+			diags.AddError(d.Summary, detail)
+		} else {
+			diags.AddWarning(d.Summary, detail)
+		}
+	}
+	return scoped, diags
+}
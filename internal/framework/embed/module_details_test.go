@@ -0,0 +1,90 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwembed
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider/metaschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+func TestModuleDetails(t *testing.T) {
+	t.Parallel()
+
+	s := metaschema.Schema{
+		Attributes: map[string]metaschema.Attribute{
+			"feature_preview": metaschema.MapAttribute{ElementType: types.BoolType, Optional: true},
+		},
+	}
+	newConfig := func(previews map[string]tftypes.Value) tfsdk.Config {
+		return tfsdk.Config{
+			Schema: s,
+			Raw: tftypes.NewValue(s.Type().TerraformType(t.Context()), map[string]tftypes.Value{
+				"feature_preview": tftypes.NewValue(tftypes.Map{ElementType: tftypes.Bool}, previews),
+			}),
+		}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		config tfsdk.Config
+		tags   []string
+		issues diag.Diagnostics
+	}{
+		{
+			name:   "no provider meta",
+			config: tfsdk.Config{},
+			tags:   nil,
+			issues: nil,
+		},
+		{
+			name:   "no module previews",
+			config: newConfig(nil),
+			tags:   nil,
+			issues: nil,
+		},
+		{
+			name: "module enables preview",
+			config: newConfig(map[string]tftypes.Value{
+				feature.PreviewProviderTags: tftypes.NewValue(tftypes.Bool, true),
+			}),
+			tags:   []string{"env:test"},
+			issues: nil,
+		},
+		{
+			name: "unknown module preview",
+			config: newConfig(map[string]tftypes.Value{
+				"provider.tag": tftypes.NewValue(tftypes.Bool, true),
+			}),
+			tags: nil,
+			issues: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Unknown feature preview",
+					"provider_meta feature_preview \"provider.tag\": no preview with id \"provider.tag\" found, did you mean \"provider.tags\"?",
+				),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reg := feature.NewRegistry()
+			reg.MustRegister(feature.PreviewProviderTags)
+
+			rd := &ResourceData{meta: &pmeta.Meta{Registry: reg, Tags: []string{"env:test"}}}
+			meta, issues := rd.ModuleDetails(t.Context(), tc.config)
+			assert.Equal(t, tc.issues, issues, "Must match the expected diagnostics")
+			assert.Equal(t, tc.tags, pmeta.LoadProviderTags(t.Context(), meta), "Must match the expected tags")
+			assert.Nil(t, pmeta.LoadProviderTags(t.Context(), rd.Details()), "Must not modify the provider details")
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwfeature
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+type FeaturePreviewsDataSource struct {
+	fwembed.DatasourceData
+}
+
+type FeaturePreviewsModelDataSource struct {
+	Previews types.Map `tfsdk:"previews"`
+}
+
+type featurePreviewModel struct {
+	Description     types.String `tfsdk:"description"`
+	Introduced      types.String `tfsdk:"introduced"`
+	Deprecated      types.String `tfsdk:"deprecated"`
+	Removed         types.String `tfsdk:"removed"`
+	GlobalAvailable types.Bool   `tfsdk:"global_available"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+}
+
+var featurePreviewAttrTypes = map[string]attr.Type{
+	"description":      types.StringType,
+	"introduced":       types.StringType,
+	"deprecated":       types.StringType,
+	"removed":          types.StringType,
+	"global_available": types.BoolType,
+	"enabled":          types.BoolType,
+}
+
+var (
+	_ datasource.DataSource              = (*FeaturePreviewsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*FeaturePreviewsDataSource)(nil)
+)
+
+func NewFeaturePreviewsDataSource() datasource.DataSource {
+	return &FeaturePreviewsDataSource{}
+}
+
+func (fp *FeaturePreviewsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_feature_previews"
+}
+
+func (fp *FeaturePreviewsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the feature previews supported by the provider and if they are enabled by the current provider configuration.",
+		Attributes: map[string]schema.Attribute{
+			"previews": schema.MapNestedAttribute{
+				Description: "Map of the feature previews, keyed by the name used with the `feature_preview` provider argument.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"description": schema.StringAttribute{
+							Description: "Describes the change made by the feature preview.",
+							Computed:    true,
+						},
+						"introduced": schema.StringAttribute{
+							Description: "The provider version that added the feature preview.",
+							Computed:    true,
+						},
+						"deprecated": schema.StringAttribute{
+							Description: "The provider version that deprecated the feature preview, empty if it is not deprecated.",
+							Computed:    true,
+						},
+						"removed": schema.StringAttribute{
+							Description: "The provider version that removes the feature preview, empty if there is no planned removal.",
+							Computed:    true,
+						},
+						"global_available": schema.BoolAttribute{
+							Description: "Reports if the feature preview is generally available, which enables it unless it is opted out of.",
+							Computed:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Reports if the feature preview is enabled by the current provider configuration.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (fp *FeaturePreviewsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	details, diags := fp.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	var meta any
+	if details != nil {
+		meta = details
+	}
+
+	previews := make(map[string]featurePreviewModel)
+	for name, p := range pmeta.LoadPreviewRegistry(ctx, meta).All() {
+		previews[name] = featurePreviewModel{
+			Description:     types.StringValue(p.Description()),
+			Introduced:      types.StringValue(p.Introduced()),
+			Deprecated:      types.StringValue(p.Deprecated()),
+			Removed:         types.StringValue(p.Removed()),
+			GlobalAvailable: types.BoolValue(p.GlobalAvailable()),
+			Enabled:         types.BoolValue(p.Enabled()),
+		}
+	}
+
+	values, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: featurePreviewAttrTypes}, previews)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &FeaturePreviewsModelDataSource{
+		Previews: values,
+	})...)
+}
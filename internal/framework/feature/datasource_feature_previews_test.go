@@ -0,0 +1,103 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwfeature
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+)
+
+func TestFeaturePreviewsMetadata(t *testing.T) {
+	t.Parallel()
+
+	var resp datasource.MetadataResponse
+	NewFeaturePreviewsDataSource().Metadata(t.Context(), datasource.MetadataRequest{ProviderTypeName: "signalfx"}, &resp)
+
+	assert.Equal(t, "signalfx_feature_previews", resp.TypeName, "Must match the expected name")
+}
+
+func TestFeaturePreviewsSchema(t *testing.T) {
+	t.Parallel()
+
+	var resp datasource.SchemaResponse
+	NewFeaturePreviewsDataSource().Schema(t.Context(), datasource.SchemaRequest{}, &resp)
+
+	assert.NotEmpty(t, resp.Schema.Description, "Must have a description set")
+	assert.Contains(t, resp.Schema.Attributes, "previews", "Must define the previews attribute")
+	assert.Empty(t, resp.Schema.ValidateImplementation(t.Context()), "Must have a valid schema")
+}
+
+func TestFeaturePreviewsRead(t *testing.T) {
+	t.Parallel()
+
+	reg := feature.NewRegistry()
+	reg.MustRegister("example.disabled",
+		feature.WithPreviewDescription("Disabled example"),
+		feature.WithPreviewAddInVersion("v9.1.0"),
+		feature.WithPreviewDeprecatedInVersion("v9.4.0"),
+		feature.WithPreviewRemovedInVersion("v10.0.0"),
+	)
+	reg.MustRegister("example.enabled",
+		feature.WithPreviewDescription("Enabled example"),
+		feature.WithPreviewAddInVersion("v9.2.0"),
+	)
+	reg.MustRegister("example.ga",
+		feature.WithPreviewDescription("Generally available example"),
+		feature.WithPreviewAddInVersion("v9.3.0"),
+		feature.WithPreviewGlobalAvailable(),
+	)
+	require.Empty(t, reg.Configure(t.Context(), "example.enabled", true), "Must enable the preview")
+
+	ds := NewFeaturePreviewsDataSource()
+
+	var configured datasource.ConfigureResponse
+	ds.(datasource.DataSourceWithConfigure).Configure(t.Context(), datasource.ConfigureRequest{
+		ProviderData: &pmeta.Meta{Registry: reg},
+	}, &configured)
+	require.Empty(t, configured.Diagnostics, "Must configure the data source")
+
+	var sr datasource.SchemaResponse
+	ds.Schema(t.Context(), datasource.SchemaRequest{}, &sr)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: sr.Schema,
+			Raw:    tftypes.NewValue(sr.Schema.Type().TerraformType(t.Context()), nil),
+		},
+	}
+	ds.Read(t.Context(), datasource.ReadRequest{}, resp)
+	require.Empty(t, resp.Diagnostics, "Must not return any issues reading previews")
+
+	var state struct {
+		Previews map[string]struct {
+			Description     string `tfsdk:"description"`
+			Introduced      string `tfsdk:"introduced"`
+			Deprecated      string `tfsdk:"deprecated"`
+			Removed         string `tfsdk:"removed"`
+			GlobalAvailable bool   `tfsdk:"global_available"`
+			Enabled         bool   `tfsdk:"enabled"`
+		} `tfsdk:"previews"`
+	}
+	require.Empty(t, resp.State.Get(t.Context(), &state), "Must read the state")
+
+	assert.Len(t, state.Previews, 3, "Must list each registered preview")
+	assert.Equal(t, "Disabled example", state.Previews["example.disabled"].Description, "Must set the description")
+	assert.Equal(t, "v9.1.0", state.Previews["example.disabled"].Introduced, "Must set the version introduced")
+	assert.Equal(t, "v9.4.0", state.Previews["example.disabled"].Deprecated, "Must set the version deprecated")
+	assert.Equal(t, "v10.0.0", state.Previews["example.disabled"].Removed, "Must set the version removed")
+	assert.Empty(t, state.Previews["example.enabled"].Removed, "Must not set a removal version")
+	assert.False(t, state.Previews["example.disabled"].Enabled, "Must report the disabled preview")
+	assert.True(t, state.Previews["example.enabled"].Enabled, "Must report the configured preview")
+	assert.False(t, state.Previews["example.enabled"].GlobalAvailable, "Must report the preview is not generally available")
+	assert.True(t, state.Previews["example.ga"].GlobalAvailable, "Must report the preview is generally available")
+	assert.True(t, state.Previews["example.ga"].Enabled, "Must report the generally available preview as enabled")
+}
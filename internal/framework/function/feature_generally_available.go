@@ -0,0 +1,73 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package internalfunction
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+)
+
+type FeatureGenerallyAvailable struct {
+	registry *feature.Registry
+}
+
+var _ function.Function = (*FeatureGenerallyAvailable)(nil)
+
+// NewFeatureGenerallyAvailable returns the function factory that reports if
+// the feature previews held by the registry are generally available.
+//
+// The provider configuration is not used, since provider functions must
+// return the same result when called during plan and apply.
+func NewFeatureGenerallyAvailable(registry *feature.Registry) func() function.Function {
+	return func() function.Function {
+		return &FeatureGenerallyAvailable{registry: registry}
+	}
+}
+
+func (FeatureGenerallyAvailable) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "feature_generally_available"
+}
+
+func (FeatureGenerallyAvailable) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Reports if a feature preview is generally available",
+		Description: "Returns true when the named feature preview is generally available, and so enabled without setting the `feature_preview` provider argument. " +
+			"The result only depends on the provider version, the `feature_preview` provider argument is not considered. " +
+			"Use the `signalfx_feature_previews` data source to read if a preview is enabled by the provider configuration.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				AllowNullValue: false,
+				Name:           "name",
+				Description:    "The name of the feature preview, as used with the `feature_preview` provider argument.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (fga FeatureGenerallyAvailable) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	if resp.Error = req.Arguments.Get(ctx, &name); resp.Error != nil {
+		return
+	}
+
+	p, ok := fga.registry.Get(name)
+	if !ok {
+		var names []string
+		for n := range fga.registry.All() {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("no preview with id %q found, expected one of: %s", name, strings.Join(names, ", ")))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, p.GlobalAvailable())
+}
@@ -0,0 +1,95 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package internalfunction
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+)
+
+func TestFeatureGenerallyAvailable_Metadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &function.MetadataResponse{}
+	NewFeatureGenerallyAvailable(feature.NewRegistry())().Metadata(t.Context(), function.MetadataRequest{}, resp)
+
+	assert.Equal(t, "feature_generally_available", resp.Name, "Function name must match")
+}
+
+func TestFeatureGenerallyAvailable_Definition(t *testing.T) {
+	t.Parallel()
+
+	resp := &function.DefinitionResponse{}
+	NewFeatureGenerallyAvailable(feature.NewRegistry())().Definition(t.Context(), function.DefinitionRequest{}, resp)
+
+	assert.Equal(t, "Reports if a feature preview is generally available", resp.Definition.Summary, "Summary must match")
+	assert.Len(t, resp.Definition.Parameters, 1, "Must have one parameter")
+	assert.Equal(t, function.BoolReturn{}, resp.Definition.Return, "Must return a bool")
+}
+
+func TestFeatureGenerallyAvailable_Run(t *testing.T) {
+	t.Parallel()
+
+	reg := feature.NewRegistry()
+	reg.MustRegister("example.disabled")
+	reg.MustRegister("example.enabled").SetEnabled(true)
+	reg.MustRegister("example.ga", feature.WithPreviewGlobalAvailable())
+
+	for _, tt := range []struct {
+		name   string
+		arg    string
+		expect *function.RunResponse
+	}{
+		{
+			name: "preview disabled",
+			arg:  "example.disabled",
+			expect: &function.RunResponse{
+				Result: function.NewResultData(types.BoolValue(false)),
+			},
+		},
+		{
+			name: "preview enabled by configuration",
+			arg:  "example.enabled",
+			expect: &function.RunResponse{
+				Result: function.NewResultData(types.BoolValue(false)),
+			},
+		},
+		{
+			name: "preview global available",
+			arg:  "example.ga",
+			expect: &function.RunResponse{
+				Result: function.NewResultData(types.BoolValue(true)),
+			},
+		},
+		{
+			name: "unknown preview",
+			arg:  "example.unknown",
+			expect: &function.RunResponse{
+				Result: function.NewResultData(types.BoolUnknown()),
+				Error: function.NewArgumentFuncError(0,
+					`no preview with id "example.unknown" found, expected one of: example.disabled, example.enabled, example.ga`,
+				),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.BoolUnknown()),
+			}
+			NewFeatureGenerallyAvailable(reg)().Run(t.Context(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.arg)}),
+			}, resp)
+
+			assert.Equal(t, tt.expect, resp, "Must match the expected response")
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package internalfunction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/signalfx/signalfx-go/notification"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+)
+
+// NotificationBuilder creates the comma encoded notification string
+// for a notification type so that the field order is always correct.
+type NotificationBuilder struct {
+	name   string
+	kind   string
+	params []function.StringParameter
+}
+
+var _ function.Function = (*NotificationBuilder)(nil)
+
+// NewNotificationFunctions returns a notification builder for each supported notification type.
+func NewNotificationFunctions() []func() function.Function {
+	credential := function.StringParameter{
+		Name:        "credential_id",
+		Description: "The ID of the integration credential to send notifications with.",
+	}
+	team := function.StringParameter{
+		Name:        "team_id",
+		Description: "The ID of the team to notify.",
+	}
+
+	builders := []*NotificationBuilder{
+		{name: "amazon_eventbridge", kind: common.AmazonEventBrigeNotificationType, params: []function.StringParameter{credential}},
+		{name: "bigpanda", kind: common.BigPandaNotificationType, params: []function.StringParameter{credential}},
+		{name: "email", kind: common.EmailNotificationType, params: []function.StringParameter{
+			{Name: "address", Description: "The email address to send notifications to."},
+		}},
+		{name: "jira", kind: common.JiraNotificationType, params: []function.StringParameter{credential}},
+		{name: "office365", kind: common.Office365NotificationType, params: []function.StringParameter{credential}},
+		{name: "opsgenie", kind: common.OpsgenieNotificationType, params: []function.StringParameter{
+			credential,
+			{Name: "responder_name", Description: "The name of the Opsgenie responder."},
+			{Name: "responder_id", Description: "The ID of the Opsgenie responder."},
+			{Name: "responder_type", Description: "The type of the Opsgenie responder, for example `Team` or `User`."},
+		}},
+		{name: "pagerduty", kind: common.PagerDutyNotificationType, params: []function.StringParameter{credential}},
+		{name: "service_now", kind: common.ServiceNowNotificationType, params: []function.StringParameter{credential}},
+		{name: "slack", kind: common.SlackNotificationType, params: []function.StringParameter{
+			credential,
+			{Name: "channel", Description: "The name of the Slack channel, excluding the leading `#`."},
+		}},
+		{name: "splunk_platform", kind: common.SplunkPlatformNotificationType, params: []function.StringParameter{credential}},
+		{name: "team", kind: common.TeamNotificationType, params: []function.StringParameter{team}},
+		{name: "team_email", kind: common.TeamEmailNotificationType, params: []function.StringParameter{team}},
+		{name: "victor_ops", kind: common.VictorOpsNotificationType, params: []function.StringParameter{
+			credential,
+			{Name: "routing_key", Description: "The VictorOps routing key."},
+		}},
+		{name: "webhook", kind: common.WebhookNotificationType, params: []function.StringParameter{
+			{Name: "credential_id", Description: "The ID of the webhook integration credential, must be empty when `url` is set."},
+			{Name: "secret", Description: "The secret sent along with the webhook request, can be empty."},
+			{Name: "url", Description: "The URL to send the webhook request to, must be empty when `credential_id` is set."},
+		}},
+		{name: "xmatters", kind: common.XMattersNotificationType, params: []function.StringParameter{credential}},
+	}
+
+	funcs := make([]func() function.Function, 0, len(builders))
+	for _, b := range builders {
+		funcs = append(funcs, func() function.Function { return b })
+	}
+	return funcs
+}
+
+func (nb *NotificationBuilder) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "notification_" + nb.name
+}
+
+func (nb *NotificationBuilder) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	params := make([]function.Parameter, len(nb.params))
+	for i, p := range nb.params {
+		params[i] = p
+	}
+	resp.Definition = function.Definition{
+		Summary:     fmt.Sprintf("Create a %s notification string", nb.kind),
+		Description: fmt.Sprintf("Returns the notification string used by `notifications` fields to send notifications using %s, the value is validated so that it is always accepted by the provider.", nb.kind),
+		Parameters:  params,
+		Return:      function.StringReturn{},
+	}
+}
+
+func (nb *NotificationBuilder) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	values := make([]string, 0, len(nb.params)+1)
+	values = append(values, nb.kind)
+	for i := range nb.params {
+		var v string
+		if err := req.Arguments.GetArgument(ctx, i, &v); err != nil {
+			resp.Error = function.ConcatFuncErrors(resp.Error, err)
+			return
+		}
+		if strings.Contains(v, ",") {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(int64(i), fmt.Sprintf("%s must not contain a comma", nb.params[i].Name)))
+			return
+		}
+		values = append(values, v)
+	}
+
+	n, err := common.NewNotificationFromString(strings.Join(values, ","))
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(err.Error()))
+		return
+	}
+
+	strs, err := common.NewNotificationStringList([]*notification.Notification{n})
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(err.Error()))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, strs[0]))
+}
@@ -0,0 +1,166 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package internalfunction
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+)
+
+func lookupNotificationFunction(t *testing.T, name string) function.Function {
+	for _, fn := range NewNotificationFunctions() {
+		f := fn()
+		resp := &function.MetadataResponse{}
+		f.Metadata(t.Context(), function.MetadataRequest{}, resp)
+		if resp.Name == name {
+			return f
+		}
+	}
+	require.FailNow(t, "Must find function", name)
+	return nil
+}
+
+func TestNotificationBuilder_Metadata(t *testing.T) {
+	t.Parallel()
+
+	names := make([]string, 0)
+	for _, fn := range NewNotificationFunctions() {
+		resp := &function.MetadataResponse{}
+		fn().Metadata(t.Context(), function.MetadataRequest{}, resp)
+		names = append(names, resp.Name)
+	}
+
+	assert.Equal(t, []string{
+		"notification_amazon_eventbridge",
+		"notification_bigpanda",
+		"notification_email",
+		"notification_jira",
+		"notification_office365",
+		"notification_opsgenie",
+		"notification_pagerduty",
+		"notification_service_now",
+		"notification_slack",
+		"notification_splunk_platform",
+		"notification_team",
+		"notification_team_email",
+		"notification_victor_ops",
+		"notification_webhook",
+		"notification_xmatters",
+	}, names, "Must match the expected function names")
+}
+
+func TestNotificationBuilder_Definition(t *testing.T) {
+	t.Parallel()
+
+	resp := &function.DefinitionResponse{}
+	lookupNotificationFunction(t, "notification_slack").Definition(t.Context(), function.DefinitionRequest{}, resp)
+
+	assert.Equal(t, "Create a Slack notification string", resp.Definition.Summary, "Summary must match")
+	assert.Equal(t, function.StringReturn{}, resp.Definition.Return, "Must return a string")
+	assert.Equal(t, []function.Parameter{
+		function.StringParameter{
+			Name:        "credential_id",
+			Description: "The ID of the integration credential to send notifications with.",
+		},
+		function.StringParameter{
+			Name:        "channel",
+			Description: "The name of the Slack channel, excluding the leading `#`.",
+		},
+	}, resp.Definition.Parameters, "Parameters must match")
+}
+
+func TestNotificationBuilder_Run(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name   string
+		fn     string
+		args   []string
+		expect string
+		errVal string
+	}{
+		{
+			name:   "slack",
+			fn:     "notification_slack",
+			args:   []string{"credential", "alerts"},
+			expect: "Slack,credential,alerts",
+		},
+		{
+			name:   "slack channel with hash",
+			fn:     "notification_slack",
+			args:   []string{"credential", "#alerts"},
+			errVal: "exclude the # from channel names in \"#alerts\"",
+		},
+		{
+			name:   "email",
+			fn:     "notification_email",
+			args:   []string{"oncall@example.com"},
+			expect: "Email,oncall@example.com",
+		},
+		{
+			name:   "invalid email",
+			fn:     "notification_email",
+			args:   []string{"not an address"},
+			errVal: "mail: no angle-addr",
+		},
+		{
+			name:   "opsgenie",
+			fn:     "notification_opsgenie",
+			args:   []string{"credential", "ops", "responder", "Team"},
+			expect: "Opsgenie,credential,ops,responder,Team",
+		},
+		{
+			name:   "webhook using url",
+			fn:     "notification_webhook",
+			args:   []string{"", "secret", "https://example.com/hook"},
+			expect: "Webhook,,secret,https://example.com/hook",
+		},
+		{
+			name:   "webhook without destination",
+			fn:     "notification_webhook",
+			args:   []string{"", "", ""},
+			errVal: "invalid Webhook notification string, please consult the documentation (use one of URL or credential id)",
+		},
+		{
+			name:   "argument containing comma",
+			fn:     "notification_team",
+			args:   []string{"a,b"},
+			errVal: "team_id must not contain a comma",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			args := make([]attr.Value, len(tt.args))
+			for i, a := range tt.args {
+				args[i] = types.StringValue(a)
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			lookupNotificationFunction(t, tt.fn).Run(t.Context(), function.RunRequest{
+				Arguments: function.NewArgumentsData(args),
+			}, resp)
+
+			if tt.errVal != "" {
+				require.NotNil(t, resp.Error, "Must return an error")
+				assert.Equal(t, tt.errVal, resp.Error.Text, "Must match the expected error")
+				return
+			}
+			require.Nil(t, resp.Error, "Must not return an error")
+			assert.Equal(t, function.NewResultData(types.StringValue(tt.expect)), resp.Result, "Must match the expected result")
+
+			_, err := common.NewNotificationFromString(tt.expect)
+			assert.NoError(t, err, "Must parse the returned notification string")
+		})
+	}
+}
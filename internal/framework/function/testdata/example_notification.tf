@@ -0,0 +1,15 @@
+provider "signalfx" {}
+
+resource "signalfx_detector" "example" {
+  name         = "example"
+  program_text = "detect(when(data('cpu.utilization') > 90)).publish('high cpu')"
+
+  rule {
+    detect_label = "high cpu"
+    severity     = "Critical"
+    notifications = [
+      provider::signalfx::notification_slack("credential-id", "alerts"),
+      provider::signalfx::notification_email("oncall@example.com"),
+    ]
+  }
+}
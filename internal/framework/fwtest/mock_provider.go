@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -26,10 +27,12 @@ type MockProvider struct {
 
 	resources   []func() resource.Resource
 	datasources []func() datasource.DataSource
+	ephemerals  []func() ephemeral.EphemeralResource
 }
 
 var (
-	_ provider.Provider = (*MockProvider)(nil)
+	_ provider.Provider                       = (*MockProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*MockProvider)(nil)
 )
 
 func WithMockResources(resources ...func() resource.Resource) func(*MockProvider) {
@@ -44,6 +47,12 @@ func WithMockDataSources(datasources ...func() datasource.DataSource) func(*Mock
 	}
 }
 
+func WithMockEphemeralResources(ephemerals ...func() ephemeral.EphemeralResource) func(*MockProvider) {
+	return func(mp *MockProvider) {
+		mp.ephemerals = ephemerals
+	}
+}
+
 func NewMockProto5Server(tb testing.TB, endpoints map[string]http.Handler, opts ...func(*MockProvider)) map[string]func() (tfprotov5.ProviderServer, error) {
 	return map[string]func() (tfprotov5.ProviderServer, error){
 		"signalfx": providerserver.NewProtocol5WithError(NewMock(tb, endpoints, opts...)),
@@ -122,3 +131,7 @@ func (mp MockProvider) DataSources(ctx context.Context) []func() datasource.Data
 func (mp MockProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return mp.resources
 }
+
+func (mp MockProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return mp.ephemerals
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/stretchr/testify/require"
@@ -23,10 +24,12 @@ func TestNewMockProviderFactory_WithOptions(t *testing.T) {
 		options    []func(*MockProvider)
 		wantResLen int
 		wantDSLen  int
+		wantEphLen int
 	}
 
 	mockResource := func() resource.Resource { return nil }
 	mockDataSource := func() datasource.DataSource { return nil }
+	mockEphemeral := func() ephemeral.EphemeralResource { return nil }
 
 	tests := []testCase{
 		{
@@ -53,6 +56,13 @@ func TestNewMockProviderFactory_WithOptions(t *testing.T) {
 			wantResLen: 1,
 			wantDSLen:  1,
 		},
+		{
+			name:       "with ephemeral resources",
+			options:    []func(*MockProvider){WithMockEphemeralResources(mockEphemeral)},
+			wantResLen: 0,
+			wantDSLen:  0,
+			wantEphLen: 1,
+		},
 	}
 
 	endpoints := map[string]http.Handler{
@@ -80,6 +90,7 @@ func TestNewMockProviderFactory_WithOptions(t *testing.T) {
 			}
 			require.Len(t, mockProvider.Resources(t.Context()), tc.wantResLen)
 			require.Len(t, mockProvider.DataSources(t.Context()), tc.wantDSLen)
+			require.Len(t, mockProvider.EphemeralResources(t.Context()), tc.wantEphLen)
 		})
 	}
 }
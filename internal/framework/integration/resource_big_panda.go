@@ -14,6 +14,7 @@ import (
 	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwerr"
 	fwshared "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/shared"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
 type ResourceBigPanda struct {
@@ -26,7 +27,11 @@ type resourceBigPandaModel struct {
 	Enabled                       types.Bool   `tfsdk:"enabled"`
 	Name                          types.String `tfsdk:"name"`
 	AppKey                        types.String `tfsdk:"app_key"`
+	AppKeyWO                      types.String `tfsdk:"app_key_wo"`
+	AppKeyWOVersion               types.Int64  `tfsdk:"app_key_wo_version"`
 	Token                         types.String `tfsdk:"token"`
+	TokenWO                       types.String `tfsdk:"token_wo"`
+	TokenWOVersion                types.Int64  `tfsdk:"token_wo_version"`
 	AlertTriggeredPayloadTemplate types.String `tfsdk:"alert_triggered_payload_template"`
 	AlertResolvedPayloadTemplate  types.String `tfsdk:"alert_resolved_payload_template"`
 }
@@ -48,7 +53,7 @@ func (bp *ResourceBigPanda) Metadata(_ context.Context, req resource.MetadataReq
 func (bp *ResourceBigPanda) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Use this resource to manage a BigPanda integration.",
-		Attributes: map[string]schema.Attribute{
+		Attributes: withWriteOnlyCredentials(map[string]schema.Attribute{
 			"id": fwshared.ResourceIDAttribute(),
 			"enabled": schema.BoolAttribute{
 				Required:    true,
@@ -76,18 +81,26 @@ func (bp *ResourceBigPanda) Schema(_ context.Context, _ resource.SchemaRequest,
 				Optional:    true,
 				Description: "A template that Observability Cloud uses to create the BigPanda POST JSON payload when an alert sends a resolved notification to BigPanda. If omitted, Observability Cloud uses the default BigPanda payload.",
 			},
-		},
+		}, "app_key", "token"),
 	}
 }
 
 func (bp *ResourceBigPanda) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var model resourceBigPandaModel
+	var model, config resourceBigPandaModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	details, err := bp.Details().Client.CreateBigPandaIntegration(ctx, model.toIntegration())
+	meta, diags := bp.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_big_panda_integration", "")
+
+	details, err := meta.Client.CreateBigPandaIntegration(ctx, model.toIntegration(config))
 	if resp.Diagnostics.Append(fwerr.ErrorHandler(ctx, resp.State, err)...); resp.Diagnostics.HasError() {
 		return
 	}
@@ -103,7 +116,14 @@ func (bp *ResourceBigPanda) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	details, err := bp.Details().Client.GetBigPandaIntegration(ctx, model.Id.ValueString())
+	meta, diags := bp.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_big_panda_integration", model.Id.ValueString())
+
+	details, err := meta.Client.GetBigPandaIntegration(ctx, model.Id.ValueString())
 	if resp.Diagnostics.Append(fwerr.ErrorHandler(ctx, resp.State, err)...); resp.Diagnostics.HasError() {
 		return
 	}
@@ -118,13 +138,21 @@ func (bp *ResourceBigPanda) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (bp *ResourceBigPanda) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var model resourceBigPandaModel
+	var model, config resourceBigPandaModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	details, err := bp.Details().Client.UpdateBigPandaIntegration(ctx, model.Id.ValueString(), model.toIntegration())
+	meta, diags := bp.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_big_panda_integration", model.Id.ValueString())
+
+	details, err := meta.Client.UpdateBigPandaIntegration(ctx, model.Id.ValueString(), model.toIntegration(config))
 	if resp.Diagnostics.Append(fwerr.ErrorHandler(ctx, resp.State, err)...); resp.Diagnostics.HasError() {
 		return
 	}
@@ -145,17 +173,26 @@ func (bp *ResourceBigPanda) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	err := bp.Details().Client.DeleteBigPandaIntegration(ctx, model.Id.ValueString())
+	meta, diags := bp.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_big_panda_integration", model.Id.ValueString())
+
+	err := meta.Client.DeleteBigPandaIntegration(ctx, model.Id.ValueString())
 	resp.Diagnostics.Append(fwerr.ErrorHandler(ctx, resp.State, err)...)
 }
 
-func (model resourceBigPandaModel) toIntegration() *integration.BigPandaIntegration {
+// toIntegration converts the planned model into the API payload,
+// the config is used to read write only credentials since they are not part of the plan.
+func (model resourceBigPandaModel) toIntegration(config resourceBigPandaModel) *integration.BigPandaIntegration {
 	details := &integration.BigPandaIntegration{
 		Type:    integration.BIG_PANDA,
 		Enabled: model.Enabled.ValueBool(),
 		Name:    model.Name.ValueString(),
-		AppKey:  model.AppKey.ValueString(),
-		Token:   model.Token.ValueString(),
+		AppKey:  credentialValue(model.AppKey, config.AppKeyWO),
+		Token:   credentialValue(model.Token, config.TokenWO),
 	}
 
 	if !model.AlertTriggeredPayloadTemplate.IsNull() && !model.AlertTriggeredPayloadTemplate.IsUnknown() {
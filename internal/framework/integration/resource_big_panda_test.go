@@ -6,6 +6,7 @@ package fwintegration
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"regexp"
@@ -16,11 +17,13 @@ import (
 	testresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/signalfx/signalfx-go/integration"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/fakeapi"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwtest"
 )
 
@@ -180,3 +183,149 @@ func TestResourceBigPandaUnitTest(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceBigPandaFakeAPI(t *testing.T) {
+	t.Parallel()
+
+	api := fakeapi.New(fakeapi.WithSeed(1))
+
+	stored := func(check func(obj map[string]any) error) testresource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			rs, ok := s.RootModule().Resources["signalfx_big_panda_integration.test"]
+			if !ok {
+				return fmt.Errorf("resource not found in state")
+			}
+			obj, ok := api.Get(fakeapi.Integrations, rs.Primary.ID)
+			if !ok {
+				return fmt.Errorf("integration %q not stored by the api", rs.Primary.ID)
+			}
+			return check(obj)
+		}
+	}
+
+	testresource.UnitTest(t, testresource.TestCase{
+		IsUnitTest: true,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.RequireAbove(tfversion.Version0_12_26),
+		},
+		ProtoV5ProviderFactories: fwtest.NewMockProto5Server(
+			t,
+			api.Endpoints(),
+			fwtest.WithMockResources(NewResourceBigPanda),
+		),
+		CheckDestroy: func(*terraform.State) error {
+			if n := api.Len(fakeapi.Integrations); n != 0 {
+				return fmt.Errorf("expected the integration to be deleted, %d remaining", n)
+			}
+			return nil
+		},
+		Steps: []testresource.TestStep{
+			{
+				ConfigFile: config.StaticFile("testdata/00_big_panda.tf"),
+				Check: testresource.ComposeAggregateTestCheckFunc(
+					testresource.TestCheckResourceAttrSet("signalfx_big_panda_integration.test", "id"),
+					testresource.TestCheckResourceAttr("signalfx_big_panda_integration.test", "enabled", "true"),
+					stored(func(obj map[string]any) error {
+						if obj["name"] != "BigPanda - My Team" || obj["enabled"] != true {
+							return fmt.Errorf("unexpected integration stored: %v", obj)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ConfigFile: config.StaticFile("testdata/01_big_panda_with_payloads.tf"),
+				Check: testresource.ComposeAggregateTestCheckFunc(
+					testresource.TestCheckResourceAttr("signalfx_big_panda_integration.test", "enabled", "false"),
+					stored(func(obj map[string]any) error {
+						if obj["name"] != "BigPanda - My Team" || obj["enabled"] != false {
+							return fmt.Errorf("unexpected integration stored: %v", obj)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceBigPandaWriteOnly(t *testing.T) {
+	t.Parallel()
+
+	var (
+		token = "my-token"
+		data  = integration.BigPandaIntegration{
+			Id:      "test-id",
+			Type:    integration.BIG_PANDA,
+			Name:    "BigPanda - My Team",
+			Enabled: true,
+		}
+	)
+
+	write := func(w http.ResponseWriter, r *http.Request) {
+		var in integration.BigPandaIntegration
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		assert.Equal(t, "my-app-key", in.AppKey, "Must send the write only app key")
+		assert.Equal(t, token, in.Token, "Must send the write only token")
+
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	testresource.UnitTest(t, testresource.TestCase{
+		IsUnitTest: true,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV5ProviderFactories: fwtest.NewMockProto5Server(
+			t,
+			map[string]http.Handler{
+				"POST /v2/integration":        http.HandlerFunc(write),
+				"PUT /v2/integration/test-id": http.HandlerFunc(write),
+				"GET /v2/integration/test-id": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if err := json.NewEncoder(w).Encode(data); err != nil {
+						http.Error(w, err.Error(), http.StatusInternalServerError)
+					}
+				}),
+				"DELETE /v2/integration/test-id": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = io.Copy(io.Discard, r.Body)
+					_ = r.Body.Close()
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			},
+			fwtest.WithMockResources(NewResourceBigPanda),
+		),
+		Steps: []testresource.TestStep{
+			{
+				ConfigFile: config.StaticFile("testdata/02_big_panda_write_only.tf"),
+				Check: testresource.ComposeAggregateTestCheckFunc(
+					testresource.TestCheckResourceAttr("signalfx_big_panda_integration.test", "id", "test-id"),
+					testresource.TestCheckNoResourceAttr("signalfx_big_panda_integration.test", "app_key"),
+					testresource.TestCheckNoResourceAttr("signalfx_big_panda_integration.test", "app_key_wo"),
+					testresource.TestCheckNoResourceAttr("signalfx_big_panda_integration.test", "token_wo"),
+					testresource.TestCheckResourceAttr("signalfx_big_panda_integration.test", "token_wo_version", "1"),
+				),
+			},
+			{
+				PreConfig: func() {
+					token = "my-rotated-token"
+				},
+				ConfigFile: config.StaticFile("testdata/03_big_panda_write_only_rotated.tf"),
+				ConfigPlanChecks: testresource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("signalfx_big_panda_integration.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testresource.ComposeAggregateTestCheckFunc(
+					testresource.TestCheckNoResourceAttr("signalfx_big_panda_integration.test", "token_wo"),
+					testresource.TestCheckResourceAttr("signalfx_big_panda_integration.test", "token_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
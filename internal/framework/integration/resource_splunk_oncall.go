@@ -14,6 +14,7 @@ import (
 	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwerr"
 	fwshared "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/shared"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
 )
 
 type ResourceSplunkOncall struct {
@@ -22,10 +23,12 @@ type ResourceSplunkOncall struct {
 }
 
 type resourceSplunkOnCallModel struct {
-	Id      types.String `tfsdk:"id"`
-	Enabled types.Bool   `tfsdk:"enabled"`
-	Name    types.String `tfsdk:"name"`
-	PostURL types.String `tfsdk:"post_url"`
+	Id               types.String `tfsdk:"id"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	Name             types.String `tfsdk:"name"`
+	PostURL          types.String `tfsdk:"post_url"`
+	PostURLWO        types.String `tfsdk:"post_url_wo"`
+	PostURLWOVersion types.Int64  `tfsdk:"post_url_wo_version"`
 }
 
 var (
@@ -45,7 +48,7 @@ func (oncall *ResourceSplunkOncall) Metadata(_ context.Context, req resource.Met
 func (oncall *ResourceSplunkOncall) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Use this resource to manage a Splunk Oncall Integration",
-		Attributes: map[string]schema.Attribute{
+		Attributes: withWriteOnlyCredentials(map[string]schema.Attribute{
 			"id": fwshared.ResourceIDAttribute(),
 			"enabled": schema.BoolAttribute{
 				Required:    true,
@@ -60,18 +63,26 @@ func (oncall *ResourceSplunkOncall) Schema(_ context.Context, _ resource.SchemaR
 				Sensitive:   true,
 				Description: "This is the Splunk OnCall integration URL.",
 			},
-		},
+		}, "post_url"),
 	}
 }
 
 func (oncall *ResourceSplunkOncall) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var model resourceSplunkOnCallModel
+	var model, config resourceSplunkOnCallModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	details, err := oncall.Details().Client.CreateVictorOpsIntegration(
+	meta, diags := oncall.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_integration_splunk_oncall", "")
+
+	details, err := meta.Client.CreateVictorOpsIntegration(
 		ctx,
 		&integration.VictorOpsIntegration{
 			// Internally this still uses the VictorOps details
@@ -79,7 +90,7 @@ func (oncall *ResourceSplunkOncall) Create(ctx context.Context, req resource.Cre
 			Type:    integration.VICTOR_OPS,
 			Enabled: model.Enabled.ValueBool(),
 			Name:    model.Name.ValueString(),
-			PostUrl: model.PostURL.ValueString(),
+			PostUrl: credentialValue(model.PostURL, config.PostURLWO),
 		},
 	)
 
@@ -99,7 +110,14 @@ func (oncall *ResourceSplunkOncall) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	details, err := oncall.Details().Client.GetVictorOpsIntegration(
+	meta, diags := oncall.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_integration_splunk_oncall", model.Id.ValueString())
+
+	details, err := meta.Client.GetVictorOpsIntegration(
 		ctx,
 		model.Id.ValueString(),
 	)
@@ -115,25 +133,33 @@ func (oncall *ResourceSplunkOncall) Read(ctx context.Context, req resource.ReadR
 
 	model.Enabled = types.BoolValue(details.Enabled)
 	model.Name = types.StringValue(details.Name)
-	model.PostURL = types.StringValue(details.PostUrl)
+	model.updatePostURL(details.PostUrl)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 func (oncall *ResourceSplunkOncall) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var model resourceSplunkOnCallModel
+	var model, config resourceSplunkOnCallModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	details, err := oncall.Details().Client.UpdateVictorOpsIntegration(
+	meta, diags := oncall.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_integration_splunk_oncall", model.Id.ValueString())
+
+	details, err := meta.Client.UpdateVictorOpsIntegration(
 		ctx,
 		model.Id.ValueString(),
 		&integration.VictorOpsIntegration{
 			Type:    integration.VICTOR_OPS,
 			Enabled: model.Enabled.ValueBool(),
 			Name:    model.Name.ValueString(),
-			PostUrl: model.PostURL.ValueString(),
+			PostUrl: credentialValue(model.PostURL, config.PostURLWO),
 		},
 	)
 
@@ -148,7 +174,7 @@ func (oncall *ResourceSplunkOncall) Update(ctx context.Context, req resource.Upd
 
 	model.Enabled = types.BoolValue(details.Enabled)
 	model.Name = types.StringValue(details.Name)
-	model.PostURL = types.StringValue(details.PostUrl)
+	model.updatePostURL(details.PostUrl)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
@@ -159,10 +185,25 @@ func (oncall *ResourceSplunkOncall) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
-	err := oncall.Details().Client.DeleteVictorOpsIntegration(
+	meta, diags := oncall.ModuleDetails(ctx, req.ProviderMeta)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tfext.WithResourceAddress(ctx, "signalfx_integration_splunk_oncall", model.Id.ValueString())
+
+	err := meta.Client.DeleteVictorOpsIntegration(
 		ctx,
 		model.Id.ValueString(),
 	)
 
 	resp.Diagnostics.Append(fwerr.ErrorHandler(ctx, resp.State, err)...)
 }
+
+// updatePostURL stores the URL returned by the API unless
+// the write only variant is used, which must never be stored in state.
+func (model *resourceSplunkOnCallModel) updatePostURL(url string) {
+	if model.PostURLWOVersion.IsNull() {
+		model.PostURL = types.StringValue(url)
+	}
+}
@@ -0,0 +1,9 @@
+resource "signalfx_big_panda_integration" "test" {
+  name    = "BigPanda - My Team"
+  enabled = true
+
+  app_key_wo         = "my-app-key"
+  app_key_wo_version = 1
+  token_wo           = "my-token"
+  token_wo_version   = 1
+}
@@ -0,0 +1,61 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwintegration
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// withWriteOnlyCredentials adds a write only variant of each named credential,
+// `<name>_wo`, along with `<name>_wo_version` which is incremented to send a new value.
+// Since the write only value is never stored in the plan or state,
+// the version is what allows Terraform to detect that the credential has been rotated.
+func withWriteOnlyCredentials(attrs map[string]schema.Attribute, names ...string) map[string]schema.Attribute {
+	for _, name := range names {
+		var (
+			field   = attrs[name].(schema.StringAttribute)
+			wo      = name + "_wo"
+			version = name + "_wo_version"
+		)
+
+		field.Required, field.Optional = false, true
+		field.Validators = append(field.Validators, stringvalidator.ExactlyOneOf(path.MatchRoot(wo)))
+		attrs[name] = field
+
+		attrs[wo] = schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			WriteOnly:   true,
+			Description: fmt.Sprintf("Write only variant of `%s` that is never stored in the plan or state, requires Terraform 1.11 or newer.", name),
+			Validators: []validator.String{
+				stringvalidator.AlsoRequires(path.MatchRoot(version)),
+			},
+		}
+		attrs[version] = schema.Int64Attribute{
+			Optional:    true,
+			Description: fmt.Sprintf("Version of `%s`, increment this value to update the credential.", wo),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+				int64validator.AlsoRequires(path.MatchRoot(wo)),
+			},
+		}
+	}
+	return attrs
+}
+
+// credentialValue returns the credential set in the plan, otherwise the
+// write only variant which is only available from the configuration.
+func credentialValue(value, writeOnly types.String) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
+	}
+	return writeOnly.ValueString()
+}
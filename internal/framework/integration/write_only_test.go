@@ -0,0 +1,62 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwintegration
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithWriteOnlyCredentials(t *testing.T) {
+	t.Parallel()
+
+	attrs := withWriteOnlyCredentials(map[string]schema.Attribute{
+		"api_key": schema.StringAttribute{
+			Required:    true,
+			Sensitive:   true,
+			Description: "API key",
+		},
+	}, "api_key")
+
+	require.Len(t, attrs, 3, "Must add the write only attribute and its version")
+
+	field := attrs["api_key"].(schema.StringAttribute)
+	assert.False(t, field.Required, "Must no longer require the credential")
+	assert.True(t, field.Optional, "Must make the credential optional")
+	assert.Len(t, field.Validators, 1, "Must require exactly one of the credentials")
+
+	wo, ok := attrs["api_key_wo"].(schema.StringAttribute)
+	require.True(t, ok, "Must define the write only credential")
+	assert.True(t, wo.WriteOnly, "Must not store the credential")
+	assert.True(t, wo.Sensitive, "Must mark the credential as sensitive")
+
+	_, ok = attrs["api_key_wo_version"].(schema.Int64Attribute)
+	assert.True(t, ok, "Must define the credential version")
+}
+
+func TestCredentialValue(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		value     types.String
+		writeOnly types.String
+		expect    string
+	}{
+		{name: "value set", value: types.StringValue("key"), writeOnly: types.StringNull(), expect: "key"},
+		{name: "write only set", value: types.StringNull(), writeOnly: types.StringValue("wo-key"), expect: "wo-key"},
+		{name: "unknown value", value: types.StringUnknown(), writeOnly: types.StringValue("wo-key"), expect: "wo-key"},
+		{name: "nothing set", value: types.StringNull(), writeOnly: types.StringNull(), expect: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expect, credentialValue(tc.value, tc.writeOnly), "Must return the expected credential")
+		})
+	}
+}
@@ -6,42 +6,47 @@ package internalframework
 import (
 	"context"
 	"fmt"
-	"net"
 	"net/http"
-	"time"
 
-	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/metaschema"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
-	"github.com/signalfx/signalfx-go"
 
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
 	fwalert "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/alert"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/builtincontent"
+	fwfeature "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/feature"
 	internalfunction "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/function"
 	fwintegration "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/integration"
+	fwtoken "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/token"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
-	"github.com/splunk-terraform/terraform-provider-signalfx/internal/track"
 )
 
 type ollyProvider struct {
-	version  string
-	features *feature.Registry
+	version       string
+	features      *feature.Registry
+	wrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 var (
-	_ provider.Provider                   = (*ollyProvider)(nil)
-	_ provider.ProviderWithFunctions      = (*ollyProvider)(nil)
-	_ provider.ProviderWithValidateConfig = (*ollyProvider)(nil)
+	_ provider.Provider                       = (*ollyProvider)(nil)
+	_ provider.ProviderWithFunctions          = (*ollyProvider)(nil)
+	_ provider.ProviderWithValidateConfig     = (*ollyProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*ollyProvider)(nil)
+	_ provider.ProviderWithMetaSchema         = (*ollyProvider)(nil)
 )
 
 func NewProvider(version string, opts ...ProviderOption) provider.Provider {
@@ -66,16 +71,24 @@ func (op *ollyProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 		Attributes: map[string]schema.Attribute{
 			"auth_token": schema.StringAttribute{
 				Optional:    true,
-				Description: "Splunk Observability Cloud auth token",
+				Description: "Splunk Observability Cloud auth token, can also be set with `SFX_AUTH_TOKEN`",
 			},
 			"api_url": schema.StringAttribute{
 				Optional:    true,
-				Description: "API URL for your Splunk Observability Cloud org, may include a realm",
+				Description: "API URL for your Splunk Observability Cloud org, may include a realm. Defaults to https://api.signalfx.com",
+			},
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Description: "Realm of your Splunk Observability Cloud org, used to derive the API and application URLs. Conflicts with `api_url`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(pmeta.Realms...),
+					stringvalidator.ConflictsWith(path.MatchRoot("api_url")),
+				},
 			},
 			"custom_app_url": schema.StringAttribute{
 				Optional:           true,
 				DeprecationMessage: "Remove the definition, the provider will automatically populate the custom app URL as needed",
-				Description:        "Application URL for your Splunk Observability Cloud org, often customized for organizations using SSO",
+				Description:        "Application URL for your Splunk Observability Cloud org, often customized for organizations using SSO. Defaults to https://app.signalfx.com",
 			},
 			"timeout_seconds": schema.Int64Attribute{
 				Optional:    true,
@@ -93,6 +106,57 @@ func (op *ollyProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Description: "Maximum retry wait for a single HTTP call in seconds. Defaults to 30",
 			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API requests made per second, the rate is lowered while the API responds with `429 Too Many Requests`. Defaults to 0 which does not limit the rate",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API requests made at the same time. Defaults to 0 which does not limit the number of requests",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the proxy used for API requests, which may include the proxy credentials. Defaults to the `HTTPS_PROXY` and `NO_PROXY` environment variables",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM encoded certificate authority bundle that is trusted along with the system certificates. Conflicts with `ca_cert_pem`",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem")),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded certificate authority bundle that is trusted along with the system certificates. Conflicts with `ca_cert_file`",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"client_cert": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded client certificate, or the path to one, used for mutual TLS. Requires `client_key`",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of the client certificate, or the path to one. Requires `client_cert`",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skips verifying the certificate of the API, only intended for testing. Defaults to false",
+			},
 			"email": schema.StringAttribute{
 				Optional:    true,
 				Description: "Used to create a session token instead of an API token, it requires the account to be configured to login with Email and Password",
@@ -106,6 +170,10 @@ func (op *ollyProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Description: "Required if the user is configured to be part of multiple organizations",
 			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the profile to read from the provider configuration files, can also be set with `SFX_PROFILE`",
+			},
 			"feature_preview": schema.MapAttribute{
 				ElementType: types.BoolType,
 				Optional:    true,
@@ -125,6 +193,20 @@ func (op *ollyProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 	}
 }
 
+// MetaSchema defines the `provider_meta "signalfx"` block, it must match
+// the SDK provider since the providers are muxed together.
+func (op *ollyProvider) MetaSchema(ctx context.Context, req provider.MetaSchemaRequest, resp *provider.MetaSchemaResponse) {
+	resp.Schema = metaschema.Schema{
+		Attributes: map[string]metaschema.Attribute{
+			"feature_preview": metaschema.MapAttribute{
+				ElementType: types.BoolType,
+				Optional:    true,
+				Description: pmeta.ModuleFeaturePreviewDescription,
+			},
+		},
+	}
+}
+
 func (op *ollyProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	if pv, err := version.NewVersion(req.TerraformVersion); err != nil {
 		tflog.Debug(ctx, "Unable to parse the terraform version used", tfext.ErrorLogFields(err))
@@ -150,120 +232,30 @@ func (op *ollyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
-	model.init()
-
-	meta := &pmeta.Meta{
-		Registry:       op.features,
-		Email:          model.Email.ValueString(),
-		Password:       model.Password.ValueString(),
-		OrganizationID: model.OrganizationID.ValueString(),
-	}
-
-	for _, val := range model.Tags.Elements() {
-		if tag, ok := val.(types.String); ok && !tag.IsNull() {
-			meta.Tags = append(meta.Tags, tag.ValueString())
-		}
-	}
-
-	for _, val := range model.Teams.Elements() {
-		if team, ok := val.(types.String); ok && !team.IsNull() {
-			meta.Teams = append(meta.Teams, team.ValueString())
-		}
-	}
-
-	for _, lookup := range pmeta.NewDefaultProviderLookups() {
-		if err := lookup.Do(ctx, meta); err != nil {
-			tflog.Debug(ctx,
-				"Issue trying to load external provider configuration, skipping",
-				tfext.ErrorLogFields(err),
-			)
-		}
-	}
-
-	if !model.AuthToken.IsNull() {
-		meta.AuthToken = model.AuthToken.ValueString()
-	}
-
-	if !model.APIURL.IsNull() {
-		meta.APIURL = model.APIURL.ValueString()
-	}
-
-	if err := meta.Validate(); err != nil {
-		resp.Diagnostics.AddError("Issue configuring provider", err.Error())
-		return
-	}
-
-	var (
-		attempts = int(model.RetryMaxAttempts.ValueInt32())
-		timeout  = time.Duration(model.TimeoutSeconds.ValueInt64()) * time.Second
-		waitmin  = time.Duration(model.RetryWaitMinSeconds.ValueInt64()) * time.Second
-		waitmax  = time.Duration(model.RetryWaitMaxSeconds.ValueInt64()) * time.Second
-	)
-
-	token, err := meta.LoadSessionToken(ctx)
+	meta, err := pmeta.NewResolver().Resolve(ctx, model.config())
 	if err != nil {
-		resp.Diagnostics.AddError("Issue loading session token", err.Error())
-		return
-	}
-
-	rc := retryablehttp.NewClient()
-	rc.RetryMax = attempts
-	rc.RetryWaitMin = waitmin
-	rc.RetryWaitMax = waitmax
-	rc.HTTPClient.Timeout = timeout
-	rc.HTTPClient.Transport = logging.NewSubsystemLoggingHTTPTransport("signalfx", &http.Transport{
-		Proxy:               http.ProxyFromEnvironment,
-		DialContext:         (&net.Dialer{Timeout: 5 * time.Second}).DialContext,
-		TLSHandshakeTimeout: 5 * time.Second,
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 100,
-	})
-
-	meta.Client, err = signalfx.NewClient(
-		token,
-		signalfx.APIUrl(meta.APIURL),
-		signalfx.HTTPClient(rc.StandardClient()),
-		signalfx.UserAgent(fmt.Sprintf("Terraform %s terraform-provider-signalfx/%s", req.TerraformVersion, op.version)),
-	)
-
-	if err != nil {
-		resp.Diagnostics.AddError("Issue creating Signalfx client", err.Error())
+		resp.Diagnostics.AddError("Issue configuring provider", err.Error())
 		return
 	}
+	meta.Registry = op.features
 
-	tflog.Debug(ctx, "Configured settings for http client", tfext.NewLogFields().
-		Field("attempts", attempts).
-		Duration("timeout", timeout).
-		Duration("wait_min", waitmin).
-		Duration("wait_max", waitmax),
+	meta.WrapTransport = op.wrapTransport
+	previews, err := meta.Finalize(ctx,
+		fmt.Sprintf("Terraform %s terraform-provider-signalfx/%s", req.TerraformVersion, op.version),
+		tfext.NewHTTPLogger("signalfx"),
 	)
-
-	if site, err := meta.DetectCustomAPPURL(ctx); err != nil {
-		if !model.CustomAppURL.IsNull() {
-			meta.CustomAppURL = model.CustomAppURL.ValueString()
+	for _, d := range previews {
+		if d.Severity == feature.SeverityError {
+			resp.Diagnostics.AddAttributeError(path.Root("feature_preview").AtMapKey(d.Feature), d.Summary, d.Detail)
+		} else {
+			resp.Diagnostics.AddAttributeWarning(path.Root("feature_preview").AtMapKey(d.Feature), d.Summary, d.Detail)
 		}
-	} else {
-		meta.CustomAppURL = site
 	}
-
-	for name, val := range model.FeaturePreview.Elements() {
-		active := val.Equal(types.BoolValue(true))
-		if err := pmeta.LoadPreviewRegistry(ctx, meta).Configure(ctx, name, active); err != nil {
-			resp.Diagnostics.AddAttributeWarning(
-				path.Root("feature_preview").AtMapKey(name),
-				"Failed to load feature preview",
-				err.Error(),
-			)
-		}
+	if err != nil {
+		resp.Diagnostics.AddError("Issue configuring provider", err.Error())
 	}
-
-	if gate, ok := pmeta.LoadPreviewRegistry(ctx, meta).Get(feature.PreviewProviderTracking); ok && gate.Enabled() {
-		tracking, err := track.ReadGitDetails(ctx)
-		if err != nil {
-			tflog.Info(ctx, "Unable to load git details, skipping", tfext.ErrorLogFields(err))
-		} else {
-			meta.Tags = append(meta.Tags, tracking.Tags()...)
-		}
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Ensure all the data sources are set so they can be consumed by each of the components.
@@ -277,6 +269,7 @@ func (op *ollyProvider) DataSources(ctx context.Context) []func() datasource.Dat
 	return []func() datasource.DataSource{
 		builtincontent.NewDashboardGroupsDataSource,
 		builtincontent.NewAutoDetectorDataSource,
+		fwfeature.NewFeaturePreviewsDataSource,
 	}
 }
 
@@ -287,12 +280,23 @@ func (op *ollyProvider) Resources(ctx context.Context) []func() resource.Resourc
 	}
 }
 
-func (op *ollyProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{
-		internalfunction.NewTimeRangeParser,
+func (op *ollyProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		fwtoken.NewEphemeralOrgTokenSecret,
+		fwtoken.NewEphemeralSessionToken,
 	}
 }
 
+func (op *ollyProvider) Functions(ctx context.Context) []func() function.Function {
+	return append(
+		[]func() function.Function{
+			internalfunction.NewTimeRangeParser,
+			internalfunction.NewFeatureGenerallyAvailable(op.features),
+		},
+		internalfunction.NewNotificationFunctions()...,
+	)
+}
+
 func (op *ollyProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
 	model := newDefaultOllyProviderModel()
 
@@ -301,9 +305,17 @@ func (op *ollyProvider) ValidateConfig(ctx context.Context, req provider.Validat
 		return
 	}
 
-	model.init()
+	env, err := pmeta.NewResolver().Environment()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid environment variable", err.Error())
+		return
+	}
+
+	// A selected profile is expected to provide the endpoint and credentials
+	// from the provider configuration files.
+	profile := !model.Profile.IsNull() || env.Profile != nil
 
-	if model.APIURL.IsNull() {
+	if model.APIURL.IsNull() && model.Realm.IsNull() && env.APIURL == nil && env.Realm == nil && !profile {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_url"),
 			"Missing API Endpoint",
@@ -312,12 +324,14 @@ func (op *ollyProvider) ValidateConfig(ctx context.Context, req provider.Validat
 	}
 
 	switch {
-	case !model.AuthToken.IsNull():
+	case !model.AuthToken.IsNull() || env.AuthToken != nil:
 		tflog.Debug(ctx, "Using auth token for authentication")
 	case !model.Email.IsNull() &&
 		!model.Password.IsNull() &&
 		!model.OrganizationID.IsNull():
 		tflog.Debug(ctx, "Using email and password for authentication")
+	case profile:
+		tflog.Debug(ctx, "Using provider configuration profile for authentication")
 	default:
 		resp.Diagnostics.AddWarning(
 			"Missing Authentication Method",
@@ -4,64 +4,130 @@
 package internalframework
 
 import (
-	"os"
-
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 )
 
 type OllyProviderModel struct {
-	APIURL              types.String `tfsdk:"api_url"`
-	AuthToken           types.String `tfsdk:"auth_token"`
-	CustomAppURL        types.String `tfsdk:"custom_app_url"`
-	TimeoutSeconds      types.Int64  `tfsdk:"timeout_seconds"`
-	RetryMaxAttempts    types.Int32  `tfsdk:"retry_max_attempts"`
-	RetryWaitMinSeconds types.Int64  `tfsdk:"retry_wait_min_seconds"`
-	RetryWaitMaxSeconds types.Int64  `tfsdk:"retry_wait_max_seconds"`
-	Email               types.String `tfsdk:"email"`
-	Password            types.String `tfsdk:"password"`
-	OrganizationID      types.String `tfsdk:"organization_id"`
-	FeaturePreview      types.Map    `tfsdk:"feature_preview"`
-	Tags                types.List   `tfsdk:"tags"`
-	Teams               types.List   `tfsdk:"teams"`
+	APIURL                types.String  `tfsdk:"api_url"`
+	Realm                 types.String  `tfsdk:"realm"`
+	AuthToken             types.String  `tfsdk:"auth_token"`
+	CustomAppURL          types.String  `tfsdk:"custom_app_url"`
+	TimeoutSeconds        types.Int64   `tfsdk:"timeout_seconds"`
+	RetryMaxAttempts      types.Int32   `tfsdk:"retry_max_attempts"`
+	RetryWaitMinSeconds   types.Int64   `tfsdk:"retry_wait_min_seconds"`
+	RetryWaitMaxSeconds   types.Int64   `tfsdk:"retry_wait_max_seconds"`
+	MaxRequestsPerSecond  types.Float64 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	ProxyURL              types.String  `tfsdk:"proxy_url"`
+	CACertFile            types.String  `tfsdk:"ca_cert_file"`
+	CACertPEM             types.String  `tfsdk:"ca_cert_pem"`
+	ClientCert            types.String  `tfsdk:"client_cert"`
+	ClientKey             types.String  `tfsdk:"client_key"`
+	InsecureSkipVerify    types.Bool    `tfsdk:"insecure_skip_verify"`
+	Email                 types.String  `tfsdk:"email"`
+	Password              types.String  `tfsdk:"password"`
+	OrganizationID        types.String  `tfsdk:"organization_id"`
+	Profile               types.String  `tfsdk:"profile"`
+	FeaturePreview        types.Map     `tfsdk:"feature_preview"`
+	Tags                  types.List    `tfsdk:"tags"`
+	Teams                 types.List    `tfsdk:"teams"`
 }
 
 func newDefaultOllyProviderModel() *OllyProviderModel {
 	return &OllyProviderModel{
-		AuthToken:           types.StringNull(),
-		APIURL:              types.StringNull(),
-		CustomAppURL:        types.StringNull(),
-		TimeoutSeconds:      types.Int64Value(60),
-		RetryMaxAttempts:    types.Int32Value(5),
-		RetryWaitMinSeconds: types.Int64Value(1),
-		RetryWaitMaxSeconds: types.Int64Value(10),
-		Email:               types.StringNull(),
-		Password:            types.StringNull(),
-		OrganizationID:      types.StringNull(),
-		FeaturePreview:      types.MapNull(types.BoolType),
-		Tags:                types.ListNull(types.StringType),
-		Teams:               types.ListNull(types.StringType),
+		AuthToken:             types.StringNull(),
+		APIURL:                types.StringNull(),
+		Realm:                 types.StringNull(),
+		CustomAppURL:          types.StringNull(),
+		TimeoutSeconds:        types.Int64Null(),
+		RetryMaxAttempts:      types.Int32Null(),
+		RetryWaitMinSeconds:   types.Int64Null(),
+		RetryWaitMaxSeconds:   types.Int64Null(),
+		MaxRequestsPerSecond:  types.Float64Null(),
+		MaxConcurrentRequests: types.Int64Null(),
+		ProxyURL:              types.StringNull(),
+		CACertFile:            types.StringNull(),
+		CACertPEM:             types.StringNull(),
+		ClientCert:            types.StringNull(),
+		ClientKey:             types.StringNull(),
+		InsecureSkipVerify:    types.BoolNull(),
+		Email:                 types.StringNull(),
+		Password:              types.StringNull(),
+		OrganizationID:        types.StringNull(),
+		Profile:               types.StringNull(),
+		FeaturePreview:        types.MapNull(types.BoolType),
+		Tags:                  types.ListNull(types.StringType),
+		Teams:                 types.ListNull(types.StringType),
 	}
 }
 
-// init sets the default values for the provider model fields if they are not already set.
-// This is due to the fact that the Terraform Framework does not support default values for provider schema attributes.
-func (model *OllyProviderModel) init() {
-	if data, ok := os.LookupEnv("SFX_AUTH_TOKEN"); ok && model.AuthToken.IsNull() {
-		model.AuthToken = types.StringValue(data)
+// config returns the values explicitly set as part of the provider configuration,
+// the remaining values are resolved by the [pmeta.Resolver] since
+// the Terraform Framework does not support default values for provider schema attributes.
+func (model *OllyProviderModel) config() pmeta.Config {
+	str := func(v types.String) *string {
+		if v.IsNull() || v.IsUnknown() || v.ValueString() == "" {
+			return nil
+		}
+		return v.ValueStringPointer()
 	}
-	if data, ok := os.LookupEnv("SFX_API_URL"); ok && model.APIURL.IsNull() {
-		model.APIURL = types.StringValue(data)
+	num := func(v interface {
+		IsNull() bool
+		IsUnknown() bool
+	}, value int) *int {
+		if v.IsNull() || v.IsUnknown() {
+			return nil
+		}
+		return &value
 	}
-	if model.TimeoutSeconds.IsNull() {
-		model.TimeoutSeconds = types.Int64Value(60)
+	list := func(v types.List) []string {
+		if v.IsNull() || v.IsUnknown() || len(v.Elements()) == 0 {
+			return nil
+		}
+		var values []string
+		for _, val := range v.Elements() {
+			if s, ok := val.(types.String); ok && !s.IsNull() {
+				values = append(values, s.ValueString())
+			}
+		}
+		return values
+	}
+
+	c := pmeta.Config{
+		AuthToken:             str(model.AuthToken),
+		APIURL:                str(model.APIURL),
+		Realm:                 str(model.Realm),
+		CustomAppURL:          str(model.CustomAppURL),
+		Email:                 str(model.Email),
+		Password:              str(model.Password),
+		OrganizationID:        str(model.OrganizationID),
+		Profile:               str(model.Profile),
+		TimeoutSeconds:        num(model.TimeoutSeconds, int(model.TimeoutSeconds.ValueInt64())),
+		RetryMaxAttempts:      num(model.RetryMaxAttempts, int(model.RetryMaxAttempts.ValueInt32())),
+		RetryWaitMinSeconds:   num(model.RetryWaitMinSeconds, int(model.RetryWaitMinSeconds.ValueInt64())),
+		RetryWaitMaxSeconds:   num(model.RetryWaitMaxSeconds, int(model.RetryWaitMaxSeconds.ValueInt64())),
+		MaxConcurrentRequests: num(model.MaxConcurrentRequests, int(model.MaxConcurrentRequests.ValueInt64())),
+		ProxyURL:              str(model.ProxyURL),
+		CACertFile:            str(model.CACertFile),
+		CACertPEM:             str(model.CACertPEM),
+		ClientCert:            str(model.ClientCert),
+		ClientKey:             str(model.ClientKey),
+		Tags:                  list(model.Tags),
+		Teams:                 list(model.Teams),
 	}
-	if model.RetryMaxAttempts.IsNull() {
-		model.RetryMaxAttempts = types.Int32Value(5)
+	if !model.MaxRequestsPerSecond.IsNull() && !model.MaxRequestsPerSecond.IsUnknown() {
+		c.MaxRequestsPerSecond = model.MaxRequestsPerSecond.ValueFloat64Pointer()
 	}
-	if model.RetryWaitMinSeconds.IsNull() {
-		model.RetryWaitMinSeconds = types.Int64Value(1)
+	if !model.InsecureSkipVerify.IsNull() && !model.InsecureSkipVerify.IsUnknown() {
+		c.InsecureSkipVerify = model.InsecureSkipVerify.ValueBoolPointer()
 	}
-	if model.RetryWaitMaxSeconds.IsNull() {
-		model.RetryWaitMaxSeconds = types.Int64Value(10)
+	if !model.FeaturePreview.IsNull() && !model.FeaturePreview.IsUnknown() && len(model.FeaturePreview.Elements()) > 0 {
+		c.FeaturePreview = make(map[string]bool, len(model.FeaturePreview.Elements()))
+		for name, val := range model.FeaturePreview.Elements() {
+			c.FeaturePreview[name] = val.Equal(types.BoolValue(true))
+		}
 	}
+	return c
 }
@@ -3,7 +3,11 @@
 
 package internalframework
 
-import "github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+import (
+	"net/http"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+)
 
 type ProviderOption func(*ollyProvider)
 
@@ -12,3 +16,11 @@ func WithProviderFeatureRegistry(reg *feature.Registry) ProviderOption {
 		p.features = reg
 	}
 }
+
+// WithProviderTransportWrapper wraps the transport of the configured clients,
+// it is only intended for tests that record or replay the API interactions.
+func WithProviderTransportWrapper(wrap func(http.RoundTripper) http.RoundTripper) ProviderOption {
+	return func(p *ollyProvider) {
+		p.wrapTransport = wrap
+	}
+}
@@ -2,3 +2,30 @@
 // SPDX-License-Identifier: MPL-2.0
 
 package internalframework
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithProviderTransportWrapper(t *testing.T) {
+	t.Parallel()
+
+	op, ok := NewProvider("test").(*ollyProvider)
+	require.True(t, ok, "Must be the signalfx provider")
+	assert.Nil(t, op.wrapTransport, "Must not wrap the transport by default")
+
+	wrapped := false
+	op, ok = NewProvider("test", WithProviderTransportWrapper(func(next http.RoundTripper) http.RoundTripper {
+		wrapped = true
+		return next
+	})).(*ollyProvider)
+	require.True(t, ok, "Must be the signalfx provider")
+	require.NotNil(t, op.wrapTransport, "Must set the transport wrapper")
+
+	op.wrapTransport(http.DefaultTransport)
+	assert.True(t, wrapped, "Must use the provided wrapper")
+}
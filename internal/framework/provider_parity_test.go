@@ -0,0 +1,293 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package internalframework
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkprovider "github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/provider"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
+	"github.com/splunk-terraform/terraform-provider-signalfx/signalfx"
+)
+
+// TestProviderResolverParity ensures that the framework provider and the SDK provider
+// resolve the same configuration from the same sources.
+func TestProviderResolverParity(t *testing.T) {
+	// The organization endpoint is not implemented so the
+	// custom app url is not detected by either provider.
+	s := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(s.Close)
+
+	for _, tc := range []struct {
+		name   string
+		config map[string]any
+		env    map[string]string
+		netrc  string
+		expect *pmeta.Meta
+	}{
+		{
+			name: "explicit configuration",
+			config: map[string]any{
+				"auth_token":              "config",
+				"api_url":                 s.URL,
+				"custom_app_url":          "https://app.example.com",
+				"timeout_seconds":         30,
+				"retry_max_attempts":      2,
+				"retry_wait_min_seconds":  3,
+				"retry_wait_max_seconds":  9,
+				"max_requests_per_second": 2.5,
+				"max_concurrent_requests": 4,
+				"tags":                    []any{"env:test"},
+				"teams":                   []any{"team-a"},
+			},
+			expect: &pmeta.Meta{
+				AuthToken:             "config",
+				APIURL:                s.URL,
+				CustomAppURL:          "https://app.example.com",
+				Timeout:               30 * time.Second,
+				RetryMaxAttempts:      2,
+				RetryWaitMin:          3 * time.Second,
+				RetryWaitMax:          9 * time.Second,
+				MaxRequestsPerSecond:  2.5,
+				MaxConcurrentRequests: 4,
+				Tags:                  []string{"env:test"},
+				Teams:                 []string{"team-a"},
+			},
+		},
+		{
+			name:   "environment configuration",
+			config: map[string]any{},
+			env: map[string]string{
+				pmeta.AuthTokenEnvVar:             "env",
+				pmeta.APIURLEnvVar:                s.URL,
+				pmeta.TimeoutSecondsEnvVar:        "45",
+				pmeta.RetryMaxAttemptsEnvVar:      "0",
+				pmeta.RetryWaitMaxSecondsEnvVar:   "5",
+				pmeta.MaxRequestsPerSecondEnvVar:  "10",
+				pmeta.MaxConcurrentRequestsEnvVar: "3",
+				pmeta.TagsEnvVar:                  "env:test,owner:me",
+				pmeta.TeamsEnvVar:                 "team-b",
+			},
+			expect: &pmeta.Meta{
+				AuthToken:             "env",
+				APIURL:                s.URL,
+				CustomAppURL:          pmeta.DefaultCustomAppURL,
+				Timeout:               45 * time.Second,
+				RetryMaxAttempts:      0,
+				RetryWaitMin:          pmeta.DefaultRetryWaitMin,
+				RetryWaitMax:          5 * time.Second,
+				MaxRequestsPerSecond:  10,
+				MaxConcurrentRequests: 3,
+				Tags:                  []string{"env:test", "owner:me"},
+				Teams:                 []string{"team-b"},
+			},
+		},
+		{
+			name: "explicit configuration takes priority over environment",
+			config: map[string]any{
+				"auth_token":         "config",
+				"retry_max_attempts": 1,
+			},
+			env: map[string]string{
+				pmeta.AuthTokenEnvVar:        "env",
+				pmeta.APIURLEnvVar:           s.URL,
+				pmeta.RetryMaxAttemptsEnvVar: "7",
+			},
+			expect: &pmeta.Meta{
+				AuthToken:        "config",
+				APIURL:           s.URL,
+				CustomAppURL:     pmeta.DefaultCustomAppURL,
+				Timeout:          pmeta.DefaultTimeout,
+				RetryMaxAttempts: 1,
+				RetryWaitMin:     pmeta.DefaultRetryWaitMin,
+				RetryWaitMax:     pmeta.DefaultRetryWaitMax,
+			},
+		},
+		{
+			name: "explicit realm takes priority over environment api url",
+			config: map[string]any{
+				"realm": "eu0",
+			},
+			env: map[string]string{
+				pmeta.AuthTokenEnvVar: "env",
+				pmeta.APIURLEnvVar:    s.URL,
+			},
+			expect: &pmeta.Meta{
+				AuthToken:        "env",
+				Realm:            "eu0",
+				APIURL:           "https://api.eu0.observability.splunkcloud.com",
+				IngestURL:        "https://ingest.eu0.observability.splunkcloud.com",
+				StreamURL:        "https://stream.eu0.observability.splunkcloud.com",
+				CustomAppURL:     "https://app.eu0.observability.splunkcloud.com",
+				Timeout:          pmeta.DefaultTimeout,
+				RetryMaxAttempts: pmeta.DefaultRetryMaxAttempts,
+				RetryWaitMin:     pmeta.DefaultRetryWaitMin,
+				RetryWaitMax:     pmeta.DefaultRetryWaitMax,
+			},
+		},
+		{
+			name: "transport configuration",
+			config: map[string]any{
+				"auth_token":           "config",
+				"api_url":              s.URL,
+				"insecure_skip_verify": true,
+			},
+			env: map[string]string{
+				// The proxy is not listening so the custom app url
+				// detection fails the same way for both providers.
+				pmeta.ProxyURLEnvVar: "http://127.0.0.1:1",
+			},
+			expect: &pmeta.Meta{
+				AuthToken:          "config",
+				APIURL:             s.URL,
+				CustomAppURL:       pmeta.DefaultCustomAppURL,
+				Timeout:            pmeta.DefaultTimeout,
+				RetryMaxAttempts:   pmeta.DefaultRetryMaxAttempts,
+				RetryWaitMin:       pmeta.DefaultRetryWaitMin,
+				RetryWaitMax:       pmeta.DefaultRetryWaitMax,
+				ProxyURL:           "http://127.0.0.1:1",
+				InsecureSkipVerify: true,
+			},
+		},
+		{
+			name: "environment takes priority over netrc",
+			config: map[string]any{
+				"api_url": s.URL,
+			},
+			env: map[string]string{
+				pmeta.AuthTokenEnvVar: "env",
+			},
+			netrc: "machine api.signalfx.com login auth_login password netrc",
+			expect: &pmeta.Meta{
+				AuthToken:        "env",
+				APIURL:           s.URL,
+				CustomAppURL:     pmeta.DefaultCustomAppURL,
+				Timeout:          pmeta.DefaultTimeout,
+				RetryMaxAttempts: pmeta.DefaultRetryMaxAttempts,
+				RetryWaitMin:     pmeta.DefaultRetryWaitMin,
+				RetryWaitMax:     pmeta.DefaultRetryWaitMax,
+			},
+		},
+		{
+			name: "netrc only",
+			config: map[string]any{
+				"api_url": s.URL,
+			},
+			netrc: "machine api.signalfx.com login auth_login password netrc",
+			expect: &pmeta.Meta{
+				AuthToken:        "netrc",
+				APIURL:           s.URL,
+				CustomAppURL:     pmeta.DefaultCustomAppURL,
+				Timeout:          pmeta.DefaultTimeout,
+				RetryMaxAttempts: pmeta.DefaultRetryMaxAttempts,
+				RetryWaitMin:     pmeta.DefaultRetryWaitMin,
+				RetryWaitMax:     pmeta.DefaultRetryWaitMax,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Since the environment is modified,
+			// parallel is not enabled.
+			tftest.CleanEnvVars(t)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			netrc := filepath.Join(t.TempDir(), pmeta.NetrcFile)
+			if tc.netrc != "" {
+				require.NoError(t, os.WriteFile(netrc, []byte(tc.netrc), 0o600), "Must not error writing netrc")
+			}
+			t.Setenv("NETRC", netrc)
+
+			fp := NewProvider(t.Name(), WithProviderFeatureRegistry(feature.NewRegistry()))
+			resp := &provider.ConfigureResponse{}
+			fp.Configure(
+				context.Background(),
+				provider.ConfigureRequest{
+					TerraformVersion: "1.11.0",
+					Config:           NewTestConfig(fp, newTestConfigValues(t, tc.config)),
+				},
+				resp,
+			)
+			require.Empty(t, resp.Diagnostics, "Must not return any issues configuring the framework provider")
+
+			sp := sdkprovider.New()
+			require.Empty(t, sp.Configure(context.Background(), terraform.NewResourceConfigRaw(tc.config)), "Must not return any issues configuring the sdk provider")
+
+			for _, meta := range []any{resp.ResourceData, sp.Meta()} {
+				m, ok := meta.(*pmeta.Meta)
+				require.True(t, ok, "Must have configured the provider meta")
+				// Removing the client and registry since they are hard to compare
+				m.Client, m.Registry = nil, nil
+			}
+
+			assert.Equal(t, tc.expect, resp.ResourceData, "Must match the expected framework provider meta")
+			assert.Equal(t, resp.ResourceData, sp.Meta(), "Must resolve the same meta for both providers")
+		})
+	}
+}
+
+// TestProviderMetaSchemaParity ensures that the `provider_meta` schema is the same
+// for each provider since the muxed providers require identical schemas.
+func TestProviderMetaSchemaParity(t *testing.T) {
+	t.Parallel()
+
+	fp, err := providerserver.NewProtocol5WithError(NewProvider(t.Name()))()
+	require.NoError(t, err, "Must create the framework provider server")
+
+	expect, err := fp.GetProviderSchema(t.Context(), &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err, "Must read the framework provider schema")
+	require.NotNil(t, expect.ProviderMeta, "Must define the provider meta schema")
+
+	for name, sp := range map[string]*schema.Provider{
+		"definition": sdkprovider.New(),
+		"legacy":     signalfx.Provider(),
+	} {
+		actual, err := sp.GRPCProvider().GetProviderSchema(t.Context(), &tfprotov5.GetProviderSchemaRequest{})
+		require.NoError(t, err, "Must read the %s provider schema", name)
+		assert.Equal(t, expect.ProviderMeta, actual.ProviderMeta, "Must match the %s provider meta schema", name)
+	}
+}
+
+// newTestConfigValues converts the SDK style configuration into framework values.
+func newTestConfigValues(t *testing.T, config map[string]any) map[string]tftypes.Value {
+	t.Helper()
+
+	values := make(map[string]tftypes.Value, len(config))
+	for k, v := range config {
+		switch v := v.(type) {
+		case string:
+			values[k] = tftypes.NewValue(tftypes.String, v)
+		case int, float64:
+			values[k] = tftypes.NewValue(tftypes.Number, v)
+		case bool:
+			values[k] = tftypes.NewValue(tftypes.Bool, v)
+		case []any:
+			items := make([]tftypes.Value, 0, len(v))
+			for _, item := range v {
+				items = append(items, tftypes.NewValue(tftypes.String, item))
+			}
+			values[k] = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, items)
+		default:
+			require.FailNow(t, "Unsupported configuration value", "key %q has type %T", k, v)
+		}
+	}
+	return values
+}
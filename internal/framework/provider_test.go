@@ -27,19 +27,29 @@ func NewTestConfig(p provider.Provider, values map[string]tftypes.Value) tfsdk.C
 	schema := &provider.SchemaResponse{}
 	p.Schema(context.Background(), provider.SchemaRequest{}, schema)
 	data := map[string]tftypes.Value{
-		"auth_token":             tftypes.NewValue(tftypes.String, nil),
-		"api_url":                tftypes.NewValue(tftypes.String, nil),
-		"custom_app_url":         tftypes.NewValue(tftypes.String, nil),
-		"timeout_seconds":        tftypes.NewValue(tftypes.Number, nil),
-		"retry_max_attempts":     tftypes.NewValue(tftypes.Number, nil),
-		"retry_wait_min_seconds": tftypes.NewValue(tftypes.Number, nil),
-		"retry_wait_max_seconds": tftypes.NewValue(tftypes.Number, nil),
-		"email":                  tftypes.NewValue(tftypes.String, nil),
-		"password":               tftypes.NewValue(tftypes.String, nil),
-		"organization_id":        tftypes.NewValue(tftypes.String, nil),
-		"feature_preview":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.Bool}, nil),
-		"tags":                   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
-		"teams":                  tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"auth_token":              tftypes.NewValue(tftypes.String, nil),
+		"api_url":                 tftypes.NewValue(tftypes.String, nil),
+		"realm":                   tftypes.NewValue(tftypes.String, nil),
+		"custom_app_url":          tftypes.NewValue(tftypes.String, nil),
+		"timeout_seconds":         tftypes.NewValue(tftypes.Number, nil),
+		"retry_max_attempts":      tftypes.NewValue(tftypes.Number, nil),
+		"retry_wait_min_seconds":  tftypes.NewValue(tftypes.Number, nil),
+		"retry_wait_max_seconds":  tftypes.NewValue(tftypes.Number, nil),
+		"max_requests_per_second": tftypes.NewValue(tftypes.Number, nil),
+		"max_concurrent_requests": tftypes.NewValue(tftypes.Number, nil),
+		"proxy_url":               tftypes.NewValue(tftypes.String, nil),
+		"ca_cert_file":            tftypes.NewValue(tftypes.String, nil),
+		"ca_cert_pem":             tftypes.NewValue(tftypes.String, nil),
+		"client_cert":             tftypes.NewValue(tftypes.String, nil),
+		"client_key":              tftypes.NewValue(tftypes.String, nil),
+		"insecure_skip_verify":    tftypes.NewValue(tftypes.Bool, nil),
+		"email":                   tftypes.NewValue(tftypes.String, nil),
+		"password":                tftypes.NewValue(tftypes.String, nil),
+		"organization_id":         tftypes.NewValue(tftypes.String, nil),
+		"profile":                 tftypes.NewValue(tftypes.String, nil),
+		"feature_preview":         tftypes.NewValue(tftypes.Map{ElementType: tftypes.Bool}, nil),
+		"tags":                    tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"teams":                   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
 	}
 	maps.Copy(data, values)
 	return tfsdk.Config{
@@ -47,34 +57,54 @@ func NewTestConfig(p provider.Provider, values map[string]tftypes.Value) tfsdk.C
 		Raw: tftypes.NewValue(
 			tftypes.Object{
 				AttributeTypes: map[string]tftypes.Type{
-					"auth_token":             tftypes.String,
-					"api_url":                tftypes.String,
-					"custom_app_url":         tftypes.String,
-					"timeout_seconds":        tftypes.Number,
-					"retry_max_attempts":     tftypes.Number,
-					"retry_wait_min_seconds": tftypes.Number,
-					"retry_wait_max_seconds": tftypes.Number,
-					"email":                  tftypes.String,
-					"password":               tftypes.String,
-					"organization_id":        tftypes.String,
-					"feature_preview":        tftypes.Map{ElementType: tftypes.Bool},
-					"tags":                   tftypes.List{ElementType: tftypes.String},
-					"teams":                  tftypes.List{ElementType: tftypes.String},
+					"auth_token":              tftypes.String,
+					"api_url":                 tftypes.String,
+					"realm":                   tftypes.String,
+					"custom_app_url":          tftypes.String,
+					"timeout_seconds":         tftypes.Number,
+					"retry_max_attempts":      tftypes.Number,
+					"retry_wait_min_seconds":  tftypes.Number,
+					"retry_wait_max_seconds":  tftypes.Number,
+					"max_requests_per_second": tftypes.Number,
+					"max_concurrent_requests": tftypes.Number,
+					"proxy_url":               tftypes.String,
+					"ca_cert_file":            tftypes.String,
+					"ca_cert_pem":             tftypes.String,
+					"client_cert":             tftypes.String,
+					"client_key":              tftypes.String,
+					"insecure_skip_verify":    tftypes.Bool,
+					"email":                   tftypes.String,
+					"password":                tftypes.String,
+					"organization_id":         tftypes.String,
+					"profile":                 tftypes.String,
+					"feature_preview":         tftypes.Map{ElementType: tftypes.Bool},
+					"tags":                    tftypes.List{ElementType: tftypes.String},
+					"teams":                   tftypes.List{ElementType: tftypes.String},
 				},
 				OptionalAttributes: map[string]struct{}{
-					"auth_token":             {},
-					"api_url":                {},
-					"custom_app_url":         {},
-					"timeout_seconds":        {},
-					"retry_max_attempts":     {},
-					"retry_wait_min_seconds": {},
-					"retry_wait_max_seconds": {},
-					"email":                  {},
-					"password":               {},
-					"organization_id":        {},
-					"feature_preview":        {},
-					"tags":                   {},
-					"teams":                  {},
+					"auth_token":              {},
+					"api_url":                 {},
+					"realm":                   {},
+					"custom_app_url":          {},
+					"timeout_seconds":         {},
+					"retry_max_attempts":      {},
+					"retry_wait_min_seconds":  {},
+					"retry_wait_max_seconds":  {},
+					"max_requests_per_second": {},
+					"max_concurrent_requests": {},
+					"proxy_url":               {},
+					"ca_cert_file":            {},
+					"ca_cert_pem":             {},
+					"client_cert":             {},
+					"client_key":              {},
+					"insecure_skip_verify":    {},
+					"email":                   {},
+					"password":                {},
+					"organization_id":         {},
+					"profile":                 {},
+					"feature_preview":         {},
+					"tags":                    {},
+					"teams":                   {},
 				},
 			},
 			data,
@@ -111,12 +141,20 @@ func TestProviderSchema(t *testing.T) {
 	assert.NotNil(t, resp.Schema, "Schema should not be nil")
 }
 
+func TestProviderEphemeralResources(t *testing.T) {
+	t.Parallel()
+
+	p := NewProvider("1.0.0").(provider.ProviderWithEphemeralResources)
+
+	assert.Len(t, p.EphemeralResources(context.Background()), 2, "Must return exactly two ephemeral resources")
+}
+
 func TestProviderDataSources(t *testing.T) {
 	t.Parallel()
 
 	p := NewProvider("1.0.0")
 
-	assert.Len(t, p.DataSources(context.Background()), 2, "Must return exactly two data sources")
+	assert.Len(t, p.DataSources(context.Background()), 3, "Must return exactly three data sources")
 }
 
 func TestProviderResource(t *testing.T) {
@@ -166,7 +204,24 @@ func TestProviderConfigure(t *testing.T) {
 			issues: diag.Diagnostics{
 				diag.NewErrorDiagnostic(
 					"Issue configuring provider",
-					"missing auth token or email and password; api url is not set",
+					"missing auth token or email and password",
+				),
+			},
+			expect: nil,
+		},
+		{
+			name: "Profile is not defined",
+			data: func(_ *testing.T) map[string]tftypes.Value {
+				return map[string]tftypes.Value{
+					"api_url":    tftypes.NewValue(tftypes.String, "http://localhost"),
+					"auth_token": tftypes.NewValue(tftypes.String, "my-secret-token"),
+					"profile":    tftypes.NewValue(tftypes.String, "does-not-exist"),
+				}
+			},
+			issues: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Issue configuring provider",
+					"profile \"does-not-exist\" was not found in any provider configuration file",
 				),
 			},
 			expect: nil,
@@ -185,6 +240,7 @@ func TestProviderConfigure(t *testing.T) {
 					"email":                  tftypes.NewValue(tftypes.String, nil),
 					"password":               tftypes.NewValue(tftypes.String, nil),
 					"organization_id":        tftypes.NewValue(tftypes.String, nil),
+					"profile":                tftypes.NewValue(tftypes.String, nil),
 					"feature_preview":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.Bool}, nil),
 					"tags":                   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
 					"teams":                  tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
@@ -192,8 +248,9 @@ func TestProviderConfigure(t *testing.T) {
 			},
 			issues: nil,
 			expect: &pmeta.Meta{
-				APIURL:    "http://localhost",
-				AuthToken: "my-secret-token",
+				APIURL:       "http://localhost",
+				AuthToken:    "my-secret-token",
+				CustomAppURL: pmeta.DefaultCustomAppURL,
 			},
 		},
 		{
@@ -214,15 +271,16 @@ func TestProviderConfigure(t *testing.T) {
 				}
 			},
 			expect: &pmeta.Meta{
-				Registry:  feature.GetGlobalRegistry(),
-				APIURL:    "http://localhost",
-				AuthToken: "my-secret-token",
-				Tags:      []string{"tag1", "tag2"},
-				Teams:     []string{"team1", "team2"},
+				Registry:     feature.GetGlobalRegistry(),
+				APIURL:       "http://localhost",
+				AuthToken:    "my-secret-token",
+				CustomAppURL: pmeta.DefaultCustomAppURL,
+				Tags:         []string{"tag1", "tag2"},
+				Teams:        []string{"team1", "team2"},
 			},
 		},
 		{
-			name: "Sets operational values and feature preview",
+			name: "Sets operational values and unknown feature preview",
 			data: func(_ *testing.T) map[string]tftypes.Value {
 				return map[string]tftypes.Value{
 					"api_url":    tftypes.NewValue(tftypes.String, "http://localhost"),
@@ -243,16 +301,10 @@ func TestProviderConfigure(t *testing.T) {
 			issues: []diag.Diagnostic{
 				diag.WithPath(
 					path.Root("feature_preview").AtMapKey("new_feature"),
-					diag.NewWarningDiagnostic("Failed to load feature preview", "no preview with id \"new_feature\" found"),
+					diag.NewErrorDiagnostic("Unknown feature preview", "no preview with id \"new_feature\" found"),
 				),
 			},
-			expect: &pmeta.Meta{
-				Registry:  feature.GetGlobalRegistry(),
-				APIURL:    "http://localhost",
-				AuthToken: "my-secret-token",
-				Tags:      []string{"tag1", "tag2"},
-				Teams:     []string{"team1", "team2"},
-			},
+			expect: nil,
 		},
 		{
 			name: "Custom Domain is set",
@@ -290,6 +342,22 @@ func TestProviderConfigure(t *testing.T) {
 				Teams:        []string{"team1", "team2"},
 			},
 		},
+		{
+			name: "Realm is set",
+			data: func(_ *testing.T) map[string]tftypes.Value {
+				return map[string]tftypes.Value{
+					"realm":      tftypes.NewValue(tftypes.String, "us1"),
+					"auth_token": tftypes.NewValue(tftypes.String, "my-secret-token"),
+				}
+			},
+			issues: nil,
+			expect: &pmeta.Meta{
+				Registry:     feature.GetGlobalRegistry(),
+				APIURL:       "https://api.us1.observability.splunkcloud.com",
+				AuthToken:    "my-secret-token",
+				CustomAppURL: "https://app.us1.observability.splunkcloud.com",
+			},
+		},
 		{
 			name: "Custom Domain is provided from user config",
 			data: func(_ *testing.T) map[string]tftypes.Value {
@@ -513,6 +581,25 @@ func TestProviderValidateConfig(t *testing.T) {
 			},
 			issues: nil,
 		},
+		{
+			name: "Realm values",
+			data: func(_ *testing.T) map[string]tftypes.Value {
+				return map[string]tftypes.Value{
+					"realm":      tftypes.NewValue(tftypes.String, "us1"),
+					"auth_token": tftypes.NewValue(tftypes.String, "my-secret-token"),
+				}
+			},
+			issues: nil,
+		},
+		{
+			name: "Profile values",
+			data: func(_ *testing.T) map[string]tftypes.Value {
+				return map[string]tftypes.Value{
+					"profile": tftypes.NewValue(tftypes.String, "us1"),
+				}
+			},
+			issues: nil,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
@@ -0,0 +1,88 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwtoken
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
+)
+
+type EphemeralOrgTokenSecret struct {
+	fwembed.EphemeralResourceData
+}
+
+type ephemeralOrgTokenSecretModel struct {
+	Name       types.String `tfsdk:"name"`
+	Secret     types.String `tfsdk:"secret"`
+	AuthScopes types.List   `tfsdk:"auth_scopes"`
+	Disabled   types.Bool   `tfsdk:"disabled"`
+}
+
+var (
+	_ ephemeral.EphemeralResource              = (*EphemeralOrgTokenSecret)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*EphemeralOrgTokenSecret)(nil)
+)
+
+func NewEphemeralOrgTokenSecret() ephemeral.EphemeralResource {
+	return &EphemeralOrgTokenSecret{}
+}
+
+func (eots *EphemeralOrgTokenSecret) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_token_secret"
+}
+
+func (eots *EphemeralOrgTokenSecret) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the secret of an org token on demand without storing it in the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the org token to read the secret of",
+			},
+			"secret": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The value of the token used for API actions or sending data",
+			},
+			"auth_scopes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Authentication scopes of the token, ex: INGEST, API, RUM",
+			},
+			"disabled": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the token is disabled and can not be used for authentication",
+			},
+		},
+	}
+}
+
+func (eots *EphemeralOrgTokenSecret) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model ephemeralOrgTokenSecretModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := eots.Details().Client.GetOrgToken(ctx, model.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Issue reading org token", err.Error())
+		return
+	}
+
+	scopes, diags := types.ListValueFrom(ctx, types.StringType, token.AuthScopes)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.Secret = types.StringValue(token.Secret)
+	model.AuthScopes = scopes
+	model.Disabled = types.BoolValue(token.Disabled)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
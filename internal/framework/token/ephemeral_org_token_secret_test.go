@@ -0,0 +1,113 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwtoken
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	testresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/signalfx/signalfx-go/orgtoken"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwtest"
+)
+
+func TestEphemeralOrgTokenSecretMetadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &ephemeral.MetadataResponse{}
+	NewEphemeralOrgTokenSecret().Metadata(t.Context(), ephemeral.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+
+	assert.Equal(t, "signalfx_org_token_secret", resp.TypeName, "Must match the expected name")
+}
+
+func TestEphemeralOrgTokenSecretSchema(t *testing.T) {
+	t.Parallel()
+
+	resp := &ephemeral.SchemaResponse{}
+	NewEphemeralOrgTokenSecret().Schema(t.Context(), ephemeral.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), "Must not error")
+	assert.True(t, resp.Schema.Attributes["secret"].IsSensitive(), "Must mark the secret as sensitive")
+}
+
+func TestEphemeralOrgTokenSecretUnitTest(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		endpoints map[string]http.Handler
+		steps     []testresource.TestStep
+	}{
+		{
+			name: "reads token secret",
+			endpoints: map[string]http.Handler{
+				"GET /v2/token/my-ingest-token": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_ = json.NewEncoder(w).Encode(&orgtoken.Token{
+						Name:       "my-ingest-token",
+						AuthScopes: []string{"INGEST"},
+						Secret:     "token-secret",
+					})
+				}),
+			},
+			steps: []testresource.TestStep{
+				{
+					ConfigFile: config.StaticFile("testdata/org_token_secret.tf"),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("echo.token", tfjsonpath.New("data").AtMapKey("secret"), knownvalue.StringExact("token-secret")),
+						statecheck.ExpectKnownValue("echo.token", tfjsonpath.New("data").AtMapKey("auth_scopes"), knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("INGEST"),
+						})),
+						statecheck.ExpectKnownValue("echo.token", tfjsonpath.New("data").AtMapKey("disabled"), knownvalue.Bool(false)),
+					},
+				},
+			},
+		},
+		{
+			name: "token does not exist",
+			endpoints: map[string]http.Handler{
+				"GET /v2/token/my-ingest-token": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					http.Error(w, "Not Found", http.StatusNotFound)
+				}),
+			},
+			steps: []testresource.TestStep{
+				{
+					ConfigFile:  config.StaticFile("testdata/org_token_secret.tf"),
+					ExpectError: regexp.MustCompile(`Issue reading org token`),
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testresource.UnitTest(t, testresource.TestCase{
+				IsUnitTest: true,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_10_0),
+				},
+				ProtoV5ProviderFactories: fwtest.NewMockProto5Server(
+					t,
+					tc.endpoints,
+					fwtest.WithMockEphemeralResources(NewEphemeralOrgTokenSecret),
+				),
+				ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+					"echo": echoprovider.NewProviderServer(),
+				},
+				Steps: tc.steps,
+			})
+		})
+	}
+}
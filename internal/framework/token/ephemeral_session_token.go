@@ -0,0 +1,126 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwtoken
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/signalfx/signalfx-go/sessiontoken"
+
+	fwembed "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/embed"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// sessionTokenPrivateKey is the private data key used to
+// pass the session token from open to close so it can be deleted.
+const sessionTokenPrivateKey = "session_token"
+
+type EphemeralSessionToken struct {
+	fwembed.EphemeralResourceData
+}
+
+type ephemeralSessionTokenModel struct {
+	Email          types.String `tfsdk:"email"`
+	Password       types.String `tfsdk:"password"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	AccessToken    types.String `tfsdk:"access_token"`
+}
+
+var (
+	_ ephemeral.EphemeralResource              = (*EphemeralSessionToken)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*EphemeralSessionToken)(nil)
+	_ ephemeral.EphemeralResourceWithClose     = (*EphemeralSessionToken)(nil)
+)
+
+func NewEphemeralSessionToken() ephemeral.EphemeralResource {
+	return &EphemeralSessionToken{}
+}
+
+func (est *EphemeralSessionToken) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session_token"
+}
+
+func (est *EphemeralSessionToken) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a short lived session token by logging in with an email and password, the session is deleted once Terraform no longer needs it.",
+		Attributes: map[string]schema.Attribute{
+			"email": schema.StringAttribute{
+				Required:    true,
+				Description: "Email address of the user to login as, the account must be configured to login with Email and Password",
+			},
+			"password": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the user to login as",
+			},
+			"organization_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Required if the user is part of multiple organizations",
+			},
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The session token that can be used to authenticate with the API",
+			},
+		},
+	}
+}
+
+func (est *EphemeralSessionToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model ephemeralSessionTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := est.Details().Client.CreateSessionToken(ctx, &sessiontoken.CreateTokenRequest{
+		Email:          model.Email.ValueString(),
+		Password:       model.Password.ValueString(),
+		OrganizationId: model.OrganizationID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Issue creating session token", err.Error())
+		return
+	}
+
+	tflog.Info(ctx, "Created new session token")
+
+	data, err := json.Marshal(token.AccessToken)
+	if err != nil {
+		resp.Diagnostics.AddError("Issue storing session token", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, sessionTokenPrivateKey, data)...)
+
+	model.AccessToken = types.StringValue(token.AccessToken)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+func (est *EphemeralSessionToken) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	data, diags := req.Private.GetKey(ctx, sessionTokenPrivateKey)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() || len(data) == 0 {
+		return
+	}
+
+	var token string
+	if err := json.Unmarshal(data, &token); err != nil {
+		resp.Diagnostics.AddError("Issue reading session token", err.Error())
+		return
+	}
+
+	if err := est.Details().Client.DeleteSessionToken(ctx, token); err != nil {
+		// The session will expire on its own, so failing to delete it
+		// should not stop the remaining operations from completing.
+		tflog.Warn(ctx, "Unable to delete session token", tfext.ErrorLogFields(err))
+		resp.Diagnostics.AddWarning("Issue deleting session token", err.Error())
+		return
+	}
+
+	tflog.Info(ctx, "Deleted session token")
+}
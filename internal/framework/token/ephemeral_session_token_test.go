@@ -0,0 +1,127 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fwtoken
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	testresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/signalfx/signalfx-go/sessiontoken"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/framework/fwtest"
+)
+
+func TestEphemeralSessionTokenMetadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &ephemeral.MetadataResponse{}
+	NewEphemeralSessionToken().Metadata(t.Context(), ephemeral.MetadataRequest{ProviderTypeName: "signalfx"}, resp)
+
+	assert.Equal(t, "signalfx_session_token", resp.TypeName, "Must match the expected name")
+}
+
+func TestEphemeralSessionTokenSchema(t *testing.T) {
+	t.Parallel()
+
+	resp := &ephemeral.SchemaResponse{}
+	NewEphemeralSessionToken().Schema(t.Context(), ephemeral.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), "Must not error")
+	assert.True(t, resp.Schema.Attributes["password"].IsSensitive(), "Must mark the password as sensitive")
+	assert.True(t, resp.Schema.Attributes["access_token"].IsSensitive(), "Must mark the access token as sensitive")
+}
+
+func TestEphemeralSessionTokenUnitTest(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		deleted bool
+		create  http.HandlerFunc
+		steps   []testresource.TestStep
+	}{
+		{
+			name:    "creates and deletes session",
+			deleted: true,
+			create: func(w http.ResponseWriter, r *http.Request) {
+				var req sessiontoken.CreateTokenRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				assert.Equal(t, "user@example.com", req.Email, "Must send the configured email")
+				assert.Equal(t, "password", req.Password, "Must send the configured password")
+
+				//nolint:gosec // G117: The access token is synthetic data returned by this test-only HTTP server.
+				_ = json.NewEncoder(w).Encode(&sessiontoken.Token{AccessToken: "session-secret"})
+			},
+			steps: []testresource.TestStep{
+				{
+					ConfigFile: config.StaticFile("testdata/session_token.tf"),
+					ConfigStateChecks: []statecheck.StateCheck{
+						statecheck.ExpectKnownValue("echo.session", tfjsonpath.New("data"), knownvalue.StringExact("session-secret")),
+					},
+				},
+			},
+		},
+		{
+			name: "invalid login",
+			create: func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			},
+			steps: []testresource.TestStep{
+				{
+					ConfigFile:  config.StaticFile("testdata/session_token.tf"),
+					ExpectError: regexp.MustCompile(`Issue creating session token`),
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var deleted atomic.Bool
+			endpoints := map[string]http.Handler{
+				"POST /v2/session": tc.create,
+				"DELETE /v2/session": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "session-secret", r.Header.Get("X-SF-Token"), "Must delete the created session")
+					deleted.Store(true)
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			}
+
+			testresource.UnitTest(t, testresource.TestCase{
+				IsUnitTest: true,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_10_0),
+				},
+				ProtoV5ProviderFactories: fwtest.NewMockProto5Server(
+					t,
+					endpoints,
+					fwtest.WithMockEphemeralResources(NewEphemeralSessionToken),
+				),
+				ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+					"echo": echoprovider.NewProviderServer(),
+				},
+				Steps: tc.steps,
+			})
+
+			assert.Equal(t, tc.deleted, deleted.Load(), "Must match the expected session deletion")
+		})
+	}
+}
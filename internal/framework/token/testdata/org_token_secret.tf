@@ -0,0 +1,11 @@
+provider "signalfx" {}
+
+ephemeral "signalfx_org_token_secret" "ingest" {
+  name = "my-ingest-token"
+}
+
+provider "echo" {
+  data = ephemeral.signalfx_org_token_secret.ingest
+}
+
+resource "echo" "token" {}
@@ -0,0 +1,12 @@
+provider "signalfx" {}
+
+ephemeral "signalfx_session_token" "login" {
+  email    = "user@example.com"
+  password = "password"
+}
+
+provider "echo" {
+  data = ephemeral.signalfx_session_token.login.access_token
+}
+
+resource "echo" "session" {}
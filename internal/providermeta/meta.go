@@ -7,12 +7,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"path"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/signalfx/signalfx-go"
@@ -37,14 +40,59 @@ type Meta struct {
 	Registry *feature.Registry `json:"-"`
 	Client   *signalfx.Client  `json:"-"`
 
+	// HTTPClient is used by Client and is set by [Meta.ConfigureClient],
+	// requests to endpoints that Client does not support use it so they
+	// share the same retries, rate limits, transport and logging.
+	HTTPClient *http.Client `json:"-"`
+
+	// WrapTransport is only set by tests, so that the requests made
+	// by the configured clients can be recorded or replayed.
+	WrapTransport func(http.RoundTripper) http.RoundTripper `json:"-"`
+
+	// ReadCache is set when the read cache preview is enabled,
+	// and is used by the client to store the responses of GET requests.
+	ReadCache *ReadCache `json:"-"`
+
+	// Profile is the named profile to read from the provider configuration files,
+	// it is set using [Meta.SelectProfile] and not read from the files themselves.
+	Profile string `json:"-"`
+
 	AuthToken      string   `json:"auth_token"`
 	APIURL         string   `json:"api_url"`
+	IngestURL      string   `json:"ingest_url"`
+	StreamURL      string   `json:"stream_url"`
+	Realm          string   `json:"realm"`
 	CustomAppURL   string   `json:"custom_app_url"`
 	Email          string   `json:"email"`
 	Password       string   `json:"password"`
 	OrganizationID string   `json:"org_id"`
 	Tags           []string `json:"tags"`
 	Teams          []string `json:"teams"`
+
+	// The HTTP client settings and feature previews are only set by the [Resolver]
+	// and are not read from the provider configuration files.
+	Timeout               time.Duration   `json:"-"`
+	RetryMaxAttempts      int             `json:"-"`
+	RetryWaitMin          time.Duration   `json:"-"`
+	RetryWaitMax          time.Duration   `json:"-"`
+	FeaturePreview        map[string]bool `json:"-"`
+	MaxRequestsPerSecond  float64         `json:"-"`
+	MaxConcurrentRequests int             `json:"-"`
+	ProxyURL              string          `json:"-"`
+	CACertFile            string          `json:"-"`
+	CACertPEM             string          `json:"-"`
+	ClientCert            string          `json:"-"`
+	ClientKey             string          `json:"-"`
+	InsecureSkipVerify    bool            `json:"-"`
+
+	profileReason string
+	profileLoaded bool
+
+	// sessionToken is created once the provider is configured,
+	// so that each request does not need to create a new one.
+	sessionToken string
+	// userAgent is sent by the clients created by [Meta.ConfigureClient].
+	userAgent string
 }
 
 // LoadClient returns the configured [signalfx.Client] ready to use.
@@ -85,12 +133,25 @@ func LoadApplicationURL(ctx context.Context, meta any, fragments ...string) stri
 // LoadPreviewRegistry provides an abstraction around loading from either
 // the cached meta provider object or return the global registry.
 func LoadPreviewRegistry(ctx context.Context, meta any) *feature.Registry {
-	if m, ok := meta.(*Meta); ok && m.Registry != nil {
+	if m, ok := meta.(*Meta); ok && m != nil && m.Registry != nil {
 		return m.Registry
 	}
 	return feature.GetGlobalRegistry()
 }
 
+// ConfigurePreviews applies the feature previews set in the provider configuration
+// and returns the diagnostics for each preview, ordered by name.
+func (m *Meta) ConfigurePreviews(ctx context.Context) feature.Diagnostics {
+	var (
+		reg   = LoadPreviewRegistry(ctx, m)
+		diags feature.Diagnostics
+	)
+	for _, name := range slices.Sorted(maps.Keys(m.FeaturePreview)) {
+		diags = append(diags, reg.Configure(ctx, name, m.FeaturePreview[name])...)
+	}
+	return diags
+}
+
 // LoadProviderTags fetches all the configured tags set by the provider.
 //
 // Requires preview to be enabled in order to return values.
@@ -114,12 +175,21 @@ func LoadProviderTags(ctx context.Context, meta any) []string {
 
 // LoadSessionToken will use the provider username and password
 // so that it can be used as the token through the interaction.
+// The token created by [Meta.ConfigureClient] is returned once it is set.
 func (m *Meta) LoadSessionToken(ctx context.Context) (string, error) {
 	if m.AuthToken != "" {
 		return m.AuthToken, nil
 	}
+	if m.sessionToken != "" {
+		return m.sessionToken, nil
+	}
+
+	hc, err := m.newHTTPClient()
+	if err != nil {
+		return "", err
+	}
 
-	client, err := signalfx.NewClient("", signalfx.APIUrl(m.APIURL))
+	client, err := signalfx.NewClient("", signalfx.APIUrl(m.APIURL), signalfx.HTTPClient(hc))
 	if err != nil {
 		return "", err
 	}
@@ -168,6 +238,9 @@ func (m *Meta) Validate() (errs error) {
 	if m.APIURL == "" {
 		errs = multierr.Append(errs, errors.New("api url is not set"))
 	}
+	if m.Profile != "" && !m.profileLoaded {
+		errs = multierr.Append(errs, fmt.Errorf("profile %q was not found in any provider configuration file", m.Profile))
+	}
 	return errs
 }
 
@@ -190,7 +263,12 @@ func (m *Meta) DetectCustomAPPURL(ctx context.Context) (string, error) {
 	req.Header.Set("X-SF-Token", m.AuthToken)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	hc, err := m.newHTTPClient()
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := hc.Do(req)
 	if err != nil {
 		return "", err
 	}
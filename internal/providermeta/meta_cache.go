@@ -0,0 +1,224 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DefaultReadCacheSize is the number of response bytes
+// stored by the read cache before the oldest responses are removed.
+const DefaultReadCacheSize = 64 << 20
+
+// relatedKinds holds the object types that are changed as a side effect
+// of writing to another type, such as creating a dashboard group
+// also creating a dashboard.
+var relatedKinds = map[string][]string{
+	"dashboard":      {"dashboardgroup"},
+	"dashboardgroup": {"dashboard"},
+}
+
+// ReadCache stores the successful responses of GET requests for the
+// duration of a run so that the same object is only read once.
+//
+// Responses are keyed by their route, and any other request made to an object type
+// removes the stored responses of that type. For example, `PUT /v2/team/ABC`
+// removes both `GET /v2/team/ABC` and `GET /v2/team?name=example`.
+// Once the stored responses exceed the size, the least recently used are removed.
+type ReadCache struct {
+	size int
+
+	mu          sync.Mutex
+	used        int
+	entries     map[string]*list.Element
+	order       *list.List
+	generations map[string]uint64
+}
+
+type readCacheEntry struct {
+	key    string
+	kind   string
+	header http.Header
+	body   []byte
+}
+
+// NewReadCache returns a cache that stores up to size bytes of response bodies.
+func NewReadCache(size int) *ReadCache {
+	return &ReadCache{
+		size:        size,
+		entries:     make(map[string]*list.Element),
+		order:       list.New(),
+		generations: make(map[string]uint64),
+	}
+}
+
+var (
+	sharedReadCachesMu sync.Mutex
+	sharedReadCaches   = make(map[string]*ReadCache)
+)
+
+// SharedReadCache returns the cache stored using key, creating it with size the first time.
+//
+// The provider is served as several muxed providers within the same process,
+// so each of them uses the same cache when they are configured the same way
+// so that a write made by one provider invalidates the responses read by another.
+func SharedReadCache(key string, size int) *ReadCache {
+	sharedReadCachesMu.Lock()
+	defer sharedReadCachesMu.Unlock()
+
+	if rc, ok := sharedReadCaches[key]; ok {
+		return rc
+	}
+	rc := NewReadCache(size)
+	sharedReadCaches[key] = rc
+	return rc
+}
+
+// Wrap returns a round tripper that serves GET requests from the cache,
+// and invalidates the object type of any other request made using next.
+func (rc *ReadCache) Wrap(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		kind := routeKind(req.URL.Path)
+		if kind == "" || req.Method == http.MethodHead || req.Method == http.MethodOptions {
+			return next.RoundTrip(req)
+		}
+		if req.Method != http.MethodGet {
+			// The object type is invalidated once the write has completed
+			// so that reads made during the write are not stored.
+			defer rc.Invalidate(kind)
+			return next.RoundTrip(req)
+		}
+
+		key := req.Method + " " + req.URL.RequestURI()
+		if resp, ok := rc.load(key, req); ok {
+			return resp, nil
+		}
+
+		generation := rc.generation(kind)
+		resp, err := next.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			return resp, err
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if cerr := resp.Body.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		rc.store(generation, &readCacheEntry{
+			key:    key,
+			kind:   kind,
+			header: resp.Header.Clone(),
+			body:   body,
+		})
+		return resp, nil
+	})
+}
+
+// Invalidate removes the stored responses for the object type,
+// along with the types that are changed as a side effect of it.
+// It is safe to call when the cache is not enabled.
+func (rc *ReadCache) Invalidate(kind string) {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, k := range append([]string{kind}, relatedKinds[kind]...) {
+		rc.generations[k]++
+		for e := rc.order.Front(); e != nil; {
+			next := e.Next()
+			if entry := e.Value.(*readCacheEntry); entry.kind == k {
+				rc.remove(e)
+			}
+			e = next
+		}
+	}
+}
+
+func (rc *ReadCache) generation(kind string) uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.generations[kind]
+}
+
+func (rc *ReadCache) load(key string, req *http.Request) (*http.Response, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	e, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	rc.order.MoveToFront(e)
+
+	entry := e.Value.(*readCacheEntry)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", http.StatusOK, http.StatusText(http.StatusOK)),
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.body)),
+		ContentLength: int64(len(entry.body)),
+		Request:       req,
+	}, true
+}
+
+func (rc *ReadCache) store(generation uint64, entry *readCacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	// The object type was written to while the response was being read.
+	if rc.generations[entry.kind] != generation || len(entry.body) > rc.size {
+		return
+	}
+	if e, ok := rc.entries[entry.key]; ok {
+		rc.remove(e)
+	}
+	rc.entries[entry.key] = rc.order.PushFront(entry)
+	rc.used += len(entry.body)
+
+	for rc.used > rc.size {
+		rc.remove(rc.order.Back())
+	}
+}
+
+func (rc *ReadCache) remove(e *list.Element) {
+	entry := rc.order.Remove(e).(*readCacheEntry)
+	delete(rc.entries, entry.key)
+	rc.used -= len(entry.body)
+}
+
+// routeKind returns the object type of the API route,
+// for example `/v2/dashboard/ABC` is `dashboard`.
+func routeKind(path string) string {
+	rest, ok := strings.CutPrefix(path, "/v2/")
+	if !ok {
+		return ""
+	}
+	kind, _, _ := strings.Cut(rest, "/")
+	return kind
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
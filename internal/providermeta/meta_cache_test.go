@@ -0,0 +1,194 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCache(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		method string
+		path   string
+		status int
+		cached bool
+	}
+
+	for _, tc := range []struct {
+		name     string
+		size     int
+		requests []request
+	}{
+		{
+			name: "repeated reads are stored",
+			size: DefaultReadCacheSize,
+			requests: []request{
+				{method: http.MethodGet, path: "/v2/team/AAA", status: http.StatusOK, cached: false},
+				{method: http.MethodGet, path: "/v2/team/AAA", status: http.StatusOK, cached: true},
+				{method: http.MethodGet, path: "/v2/team/BBB", status: http.StatusOK, cached: false},
+				{method: http.MethodGet, path: "/v2/team?name=AAA", status: http.StatusOK, cached: false},
+				{method: http.MethodGet, path: "/v2/team?name=AAA", status: http.StatusOK, cached: true},
+			},
+		},
+		{
+			name: "write removes the object type",
+			size: DefaultReadCacheSize,
+			requests: []request{
+				{method: http.MethodGet, path: "/v2/team/AAA", status: http.StatusOK, cached: false},
+				{method: http.MethodGet, path: "/v2/detector/AAA", status: http.StatusOK, cached: false},
+				{method: http.MethodPut, path: "/v2/team/BBB", status: http.StatusOK, cached: false},
+				{method: http.MethodGet, path: "/v2/team/AAA", status: http.StatusOK, cached: false},
+				{method: http.MethodGet, path: "/v2/detector/AAA", status: http.StatusOK, cached: true},
+			},
+		},
+		{
+			name: "write removes the related object types",
+			size: DefaultReadCacheSize,
+			requests: []request{
+				{method: http.MethodGet, path: "/v2/dashboard/AAA", status: http.StatusOK, cached: false},
+				{method: http.MethodPost, path: "/v2/dashboardgroup", status: http.StatusOK, cached: false},
+				{method: http.MethodGet, path: "/v2/dashboard/AAA", status: http.StatusOK, cached: false},
+			},
+		},
+		{
+			name: "failed reads are not stored",
+			size: DefaultReadCacheSize,
+			requests: []request{
+				{method: http.MethodGet, path: "/v2/team/AAA", status: http.StatusNotFound, cached: false},
+				{method: http.MethodGet, path: "/v2/team/AAA", status: http.StatusNotFound, cached: false},
+			},
+		},
+		{
+			name: "routes outside of the api are not stored",
+			size: DefaultReadCacheSize,
+			requests: []request{
+				{method: http.MethodGet, path: "/health", status: http.StatusOK, cached: false},
+				{method: http.MethodGet, path: "/health", status: http.StatusOK, cached: false},
+			},
+		},
+		{
+			name: "least recently used reads are removed",
+			// Each response body is 16 bytes so only two are stored.
+			size: 32,
+			requests: []request{
+				{method: http.MethodGet, path: "/v2/team/AAA", status: http.StatusOK, cached: false},
+				{method: http.MethodGet, path: "/v2/team/BBB", status: http.StatusOK, cached: false},
+				{method: http.MethodGet, path: "/v2/team/AAA", status: http.StatusOK, cached: true},
+				{method: http.MethodGet, path: "/v2/team/CCC", status: http.StatusOK, cached: false},
+				{method: http.MethodGet, path: "/v2/team/AAA", status: http.StatusOK, cached: true},
+				{method: http.MethodGet, path: "/v2/team/BBB", status: http.StatusOK, cached: false},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				calls  atomic.Int32
+				status atomic.Int32
+			)
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(int(status.Load()))
+				_, _ = io.WriteString(w, `{"id":"example"}`)
+			}))
+			t.Cleanup(s.Close)
+
+			rc := NewReadCache(tc.size)
+			client := &http.Client{Transport: rc.Wrap(s.Client().Transport)}
+
+			for i, r := range tc.requests {
+				status.Store(int32(r.status))
+				before := calls.Load()
+
+				req, err := http.NewRequestWithContext(t.Context(), r.method, s.URL+r.path, http.NoBody)
+				require.NoError(t, err, "Must create request")
+				resp, err := client.Do(req)
+				require.NoError(t, err, "Must complete request")
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err, "Must read the response body")
+				require.NoError(t, resp.Body.Close(), "Must close the response body")
+
+				assert.Equal(t, r.status, resp.StatusCode, "Must match the expected status for request %d", i)
+				assert.JSONEq(t, `{"id":"example"}`, string(body), "Must match the expected body for request %d", i)
+				assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Must keep the response headers for request %d", i)
+				assert.Equal(t, r.cached, calls.Load() == before, "Must match if request %d %s %s is cached", i, r.method, r.path)
+			}
+		})
+	}
+}
+
+func TestReadCacheWriteDuringRead(t *testing.T) {
+	t.Parallel()
+
+	rc := NewReadCache(DefaultReadCacheSize)
+	generation := rc.generation("team")
+	rc.Invalidate("team")
+	rc.store(generation, &readCacheEntry{key: "GET /v2/team/AAA", kind: "team", header: http.Header{}, body: []byte("{}")})
+
+	assert.Empty(t, rc.entries, "Must not store a read made before the object type was written")
+	assert.NotPanics(t, func() {
+		var disabled *ReadCache
+		disabled.Invalidate("team")
+	}, "Must allow invalidating a disabled cache")
+}
+
+func TestSharedReadCache(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = io.WriteString(w, `{"id":"example"}`)
+	}))
+	t.Cleanup(s.Close)
+
+	sdk := SharedReadCache(t.Name(), DefaultReadCacheSize)
+	framework := SharedReadCache(t.Name(), DefaultReadCacheSize)
+	require.Same(t, sdk, framework, "Must share the cache for the same key")
+	assert.NotSame(t, sdk, SharedReadCache(t.Name()+"/other", DefaultReadCacheSize), "Must not share the cache with other keys")
+
+	do := func(rc *ReadCache, method string) {
+		req, err := http.NewRequestWithContext(t.Context(), method, s.URL+"/v2/team/AAA", http.NoBody)
+		require.NoError(t, err, "Must create request")
+		resp, err := (&http.Client{Transport: rc.Wrap(s.Client().Transport)}).Do(req)
+		require.NoError(t, err, "Must complete request")
+		_, _ = io.Copy(io.Discard, resp.Body)
+		require.NoError(t, resp.Body.Close(), "Must close the response body")
+	}
+
+	do(framework, http.MethodGet)
+	do(sdk, http.MethodGet)
+	assert.Equal(t, int32(1), calls.Load(), "Must serve the read from the shared cache")
+
+	do(sdk, http.MethodPut)
+	do(framework, http.MethodGet)
+	assert.Equal(t, int32(3), calls.Load(), "Must invalidate the reads of the other provider after a write")
+}
+
+func TestRouteKind(t *testing.T) {
+	t.Parallel()
+
+	for path, expect := range map[string]string{
+		"/v2/dashboard/AAA":        "dashboard",
+		"/v2/dashboard":            "dashboard",
+		"/v2/detector/AAA/events":  "detector",
+		"/v2/token/my%20token":     "token",
+		"/v1/timeserieswindow":     "",
+		"/":                        "",
+		"/v2dashboard/not-a-route": "",
+	} {
+		assert.Equal(t, expect, routeKind(path), "Must match the expected kind for %q", path)
+	}
+}
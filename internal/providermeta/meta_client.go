@@ -0,0 +1,195 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/signalfx/signalfx-go"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/ratelimit"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// ConfigureClient creates the [signalfx.Client] and [http.Client] used by the provider,
+// retrying failed requests and limiting the request rate using the
+// settings resolved for m. The requests are logged using httpLog.
+//
+// The rate limiter and read cache are shared with the other muxed
+// providers configured with the same API, credentials and limits.
+func (m *Meta) ConfigureClient(ctx context.Context, userAgent string, httpLog *tfext.HTTPLogger) error {
+	token, err := m.LoadSessionToken(ctx)
+	if err != nil {
+		return err
+	}
+	m.sessionToken = token
+	m.userAgent = userAgent
+
+	transport, err := m.NewTransport()
+	if err != nil {
+		return err
+	}
+
+	rc := retryablehttp.NewClient()
+	rc.RetryMax = m.RetryMaxAttempts
+	rc.RetryWaitMin = m.RetryWaitMin
+	rc.RetryWaitMax = m.RetryWaitMax
+	rc.HTTPClient.Timeout = m.Timeout
+	rc.HTTPClient.Transport = ratelimit.Shared(m.limiterKey(),
+		ratelimit.WithRequestsPerSecond(m.MaxRequestsPerSecond),
+		ratelimit.WithMaxConcurrentRequests(m.MaxConcurrentRequests),
+	).Wrap(httpLog.Wrap(transport))
+
+	hc := rc.StandardClient()
+	hc.Transport = httpLog.Trace(hc.Transport)
+	if m.WrapTransport != nil {
+		hc.Transport = m.WrapTransport(hc.Transport)
+	}
+
+	if gate, ok := LoadPreviewRegistry(ctx, m).Get(feature.PreviewProviderReadCache); ok && gate.Enabled() {
+		m.ReadCache = SharedReadCache(m.limiterKey(), DefaultReadCacheSize)
+		hc.Transport = m.ReadCache.Wrap(hc.Transport)
+	}
+
+	m.HTTPClient = hc
+	m.Client, err = signalfx.NewClient(
+		token,
+		signalfx.APIUrl(m.APIURL),
+		signalfx.HTTPClient(hc),
+		signalfx.UserAgent(userAgent),
+	)
+	return err
+}
+
+// LoadHTTPClient returns the HTTP client set by [Meta.ConfigureClient],
+// or a client using the transport settings of m when it is not set.
+func (m *Meta) LoadHTTPClient() (*http.Client, error) {
+	if m.HTTPClient != nil {
+		return m.HTTPClient, nil
+	}
+	return m.newHTTPClient()
+}
+
+// DoRequest sends the request to the API route using the provider HTTP client,
+// so that it shares the retries, rate limits and transport settings of the other requests.
+// The body is sent as JSON when it is set, and a successful response is decoded into out
+// when it is set. An unsuccessful response is returned as a [common.ResponseError].
+//
+// It is intended for the API routes that are not yet supported by the go-sdk.
+func DoRequest(ctx context.Context, meta any, method, route string, query url.Values, body, out any) error {
+	m, ok := meta.(*Meta)
+	if !ok || m == nil {
+		return ErrMetaNotProvided
+	}
+
+	auth, err := m.LoadSessionToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	u, err := url.ParseRequestURI(m.APIURL)
+	if err != nil {
+		return err
+	}
+	u = u.JoinPath(route)
+	u.RawQuery = query.Encode()
+
+	var payload io.Reader = http.NoBody
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-SF-Token", auth)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client, err := m.LoadHTTPClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		details, _ := io.ReadAll(resp.Body)
+		return common.NewResponseError(resp.StatusCode, req.URL.Path, string(details))
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+var _ tfext.ResourceAddressScoper = (*Meta)(nil)
+
+// WithResourceAddressContext returns a copy of m whose clients include the
+// resource address set within ctx with each request, so that the operations
+// that do not pass a context to the client are still logged with their address.
+// The meta is returned as is when the clients have not been configured.
+func (m *Meta) WithResourceAddressContext(ctx context.Context) any {
+	addr := tfext.ResourceAddress(ctx)
+	if m == nil || m.HTTPClient == nil || addr == "" {
+		return m
+	}
+
+	hc := *m.HTTPClient
+	hc.Transport = tfext.NewResourceAddressTransport(addr, hc.Transport)
+
+	client, err := signalfx.NewClient(
+		m.sessionToken,
+		signalfx.APIUrl(m.APIURL),
+		signalfx.HTTPClient(&hc),
+		signalfx.UserAgent(m.userAgent),
+	)
+	if err != nil {
+		return m
+	}
+
+	scoped := *m
+	scoped.HTTPClient = &hc
+	scoped.Client = client
+	return &scoped
+}
+
+// limiterKey identifies the API, the credentials and the limits used by m
+// without holding onto the credentials themselves.
+func (m *Meta) limiterKey() string {
+	h := sha256.New()
+	for _, v := range []string{
+		m.APIURL,
+		m.AuthToken,
+		m.Email,
+		m.OrganizationID,
+		strconv.FormatFloat(m.MaxRequestsPerSecond, 'g', -1, 64),
+		strconv.Itoa(m.MaxConcurrentRequests),
+	} {
+		_, _ = h.Write([]byte(v))
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,159 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+func TestMetaLimiterKey(t *testing.T) {
+	t.Parallel()
+
+	base := &Meta{APIURL: DefaultAPIURL, AuthToken: "token", MaxRequestsPerSecond: 20}
+	assert.Equal(t, base.limiterKey(), (&Meta{APIURL: DefaultAPIURL, AuthToken: "token", MaxRequestsPerSecond: 20}).limiterKey(), "Must share the key for the same settings")
+	assert.NotContains(t, base.limiterKey(), "token", "Must not include the credentials")
+
+	for _, m := range []*Meta{
+		{APIURL: "https://api.eu0.signalfx.com", AuthToken: "token", MaxRequestsPerSecond: 20},
+		{APIURL: DefaultAPIURL, AuthToken: "other", MaxRequestsPerSecond: 20},
+		{APIURL: DefaultAPIURL, Email: "user@example.com", Password: "password", MaxRequestsPerSecond: 20},
+		{APIURL: DefaultAPIURL, AuthToken: "token", MaxRequestsPerSecond: 10},
+		{APIURL: DefaultAPIURL, AuthToken: "token", MaxRequestsPerSecond: 20, MaxConcurrentRequests: 5},
+	} {
+		assert.NotEqual(t, base.limiterKey(), m.limiterKey(), "Must use a different key for different settings")
+	}
+}
+
+func TestMetaLoadHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	hc := &http.Client{}
+	actual, err := (&Meta{HTTPClient: hc}).LoadHTTPClient()
+	require.NoError(t, err, "Must not error loading the configured client")
+	assert.Same(t, hc, actual, "Must return the configured client")
+
+	actual, err = (&Meta{Timeout: time.Second}).LoadHTTPClient()
+	require.NoError(t, err, "Must not error creating a client")
+	assert.Equal(t, time.Second, actual.Timeout, "Must use the configured timeout")
+
+	_, err = (&Meta{ProxyURL: "ftp://proxy"}).LoadHTTPClient()
+	assert.Error(t, err, "Must use the configured transport settings")
+}
+
+func TestDoRequest(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-SF-Token"), "Must send the auth token")
+		switch r.URL.Path {
+		case "/v2/token/my token/rotate":
+			assert.Equal(t, "60", r.URL.Query().Get("graceful"), "Must send the query")
+			_, _ = w.Write([]byte(`{"name":"my token"}`))
+		default:
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	meta := &Meta{APIURL: s.URL, AuthToken: "token"}
+
+	err := DoRequest(t.Context(), nil, http.MethodGet, "/v2/token", nil, nil, nil)
+	assert.ErrorIs(t, err, ErrMetaNotProvided, "Must error without a configured provider")
+
+	var out map[string]any
+	err = DoRequest(t.Context(), meta, http.MethodPost, "/v2/token/my%20token/rotate", url.Values{"graceful": {"60"}}, nil, &out)
+	require.NoError(t, err, "Must complete the request")
+	assert.Equal(t, map[string]any{"name": "my token"}, out, "Must decode the response")
+
+	err = DoRequest(t.Context(), meta, http.MethodGet, "/v2/token/missing", nil, nil, &out)
+	var re *common.ResponseError
+	require.ErrorAs(t, err, &re, "Must return a response error")
+	assert.Equal(t, http.StatusNotFound, re.Code(), "Must match the response code")
+	assert.Equal(t, "/v2/token/missing", re.Route(), "Must match the route")
+	assert.EqualError(t, err, "route \"/v2/token/missing\" had issues with status code 404", "Must match the go-sdk error message")
+}
+
+func TestMetaSessionTokenReused(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "should not be called", http.StatusBadRequest)
+	}))
+	t.Cleanup(s.Close)
+
+	m := &Meta{APIURL: s.URL, Email: "user@example.com", Password: "password", sessionToken: "session"}
+	token, err := m.LoadSessionToken(t.Context())
+	require.NoError(t, err, "Must not create a new session token")
+	assert.Equal(t, "session", token, "Must reuse the configured session token")
+}
+
+func TestMetaConfigureClientWrapTransport(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(s.Close)
+
+	var wrapped []string
+	m := &Meta{
+		APIURL:    s.URL,
+		AuthToken: "token",
+		WrapTransport: func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				wrapped = append(wrapped, req.URL.Path)
+				return next.RoundTrip(req)
+			})
+		},
+	}
+	require.NoError(t, m.ConfigureClient(t.Context(), "test", tfext.NewHTTPLogger("signalfx")), "Must configure the client")
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, s.URL+"/v2/team/AAA", http.NoBody)
+	require.NoError(t, err, "Must create the request")
+	resp, err := m.HTTPClient.Do(req)
+	require.NoError(t, err, "Must complete the request")
+	_ = resp.Body.Close()
+
+	assert.Equal(t, []string{"/v2/team/AAA"}, wrapped, "Must send the requests through the wrapped transport")
+}
+
+func TestMetaWithResourceAddressContext(t *testing.T) {
+	t.Parallel()
+
+	var addr string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"AAA","name":"example"}`))
+	}))
+	t.Cleanup(s.Close)
+
+	hc := s.Client()
+	next := hc.Transport
+	hc.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		addr = tfext.ResourceAddress(req.Context())
+		return next.RoundTrip(req)
+	})
+
+	m := &Meta{APIURL: s.URL, HTTPClient: hc, sessionToken: "token"}
+	assert.Same(t, m, m.WithResourceAddressContext(t.Context()), "Must return the meta without an address")
+	assert.Same(t, m, (&Meta{}).WithResourceAddressContext(tfext.WithResourceAddress(t.Context(), "signalfx_team", "AAA")), "Must return the meta without a configured client")
+
+	scoped, ok := m.WithResourceAddressContext(tfext.WithResourceAddress(t.Context(), "signalfx_team", "AAA")).(*Meta)
+	require.True(t, ok, "Must return a meta")
+	require.NotSame(t, m, scoped, "Must return a copy of the meta")
+	assert.Same(t, hc, m.HTTPClient, "Must not modify the original client")
+
+	_, err := scoped.Client.GetTeam(t.Context(), "AAA")
+	require.NoError(t, err, "Must read the team")
+	assert.Equal(t, "signalfx_team.AAA", addr, "Must include the resource address with the request")
+}
@@ -5,7 +5,6 @@ package pmeta
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"io"
 	"os"
@@ -29,15 +28,19 @@ func (fn MetaLookupFunc) Do(ctx context.Context, s *Meta) error {
 	return fn(ctx, s)
 }
 
-// NewDefaultProviderLookups returns the list of the expected default lookups.
+// NewDefaultProviderLookups returns the list of the expected default lookups,
+// ordered so that each lookup replaces the values set by the previous one.
 func NewDefaultProviderLookups() []MetaLookupFunc {
 	return []MetaLookupFunc{
+		NetrcMetaLookupFunc(os.Getenv("NETRC")),
 		FileMetaLookupFunc("/etc/signalfx.conf"),
 		UserMetaLookupFunc(user.Current),
-		NetrcMetaLookupFunc(os.Getenv("NETRC")),
 	}
 }
 
+// FileMetaLookupFunc reads the provider configuration file at path,
+// which is either a single set of values or a collection of named profiles
+// where the profile is selected by [Meta.SelectProfile].
 func FileMetaLookupFunc(path string) MetaLookupFunc {
 	return func(ctx context.Context, s *Meta) error {
 		tflog.Debug(ctx, "Reading provider file", map[string]any{
@@ -54,13 +57,12 @@ func FileMetaLookupFunc(path string) MetaLookupFunc {
 
 		tflog.Debug(ctx, "Reading file content")
 
-		dec := json.NewDecoder(f)
-		dec.DisallowUnknownFields()
+		content, err := io.ReadAll(f)
+		if err != nil {
+			return errors.Join(err, f.Close())
+		}
 
-		if err = dec.Decode(s); err != nil {
-			if errors.Is(err, io.EOF) {
-				err = errors.New("no file content")
-			}
+		if err = decodeConfigFile(ctx, path, content, s, true); err != nil {
 			return errors.Join(err, f.Close())
 		}
 
@@ -0,0 +1,155 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// ModuleFeaturePreviewDescription is shared by each provider since
+// the `provider_meta` schema must be identical when the providers are muxed.
+const ModuleFeaturePreviewDescription = "Overrides the `feature_preview` values set by the provider for the resources within the module."
+
+// ModuleConfig is the `provider_meta "signalfx"` block that is set within a module.
+type ModuleConfig struct {
+	FeaturePreview map[string]bool `cty:"feature_preview"`
+}
+
+// NewProviderMetaSchema returns the SDK schema of the `provider_meta "signalfx"` block.
+func NewProviderMetaSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"feature_preview": {
+			Type: schema.TypeMap,
+			Elem: &schema.Schema{
+				Type: schema.TypeBool,
+			},
+			Optional:    true,
+			Description: ModuleFeaturePreviewDescription,
+		},
+	}
+}
+
+// WithModulePreviews returns a copy of the meta that uses a registry scoped to the module,
+// so that the previews set in the module are checked before the provider configured values.
+// The meta is returned as is when the module does not set any previews.
+func (m *Meta) WithModulePreviews(ctx context.Context, previews map[string]bool) (*Meta, feature.Diagnostics) {
+	if m == nil || len(previews) == 0 {
+		return m, nil
+	}
+
+	reg, diags := LoadPreviewRegistry(ctx, m).Scope(ctx, previews)
+	if diags.HasError() {
+		return m, diags
+	}
+
+	scoped := *m
+	scoped.Registry = reg
+	return &scoped, diags
+}
+
+// PreviewSDKDiagnostics converts the feature preview diagnostics to the SDK diagnostics,
+// each of them set with the path of the configured preview.
+func PreviewSDKDiagnostics(diags feature.Diagnostics) diag.Diagnostics {
+	var issues diag.Diagnostics
+	for _, d := range diags {
+		sev := diag.Warning
+		if d.Severity == feature.SeverityError {
+			sev = diag.Error
+		}
+		issues = append(issues, diag.Diagnostic{
+			Severity:      sev,
+			Summary:       d.Summary,
+			Detail:        d.Detail,
+			AttributePath: cty.GetAttrPath("feature_preview").IndexString(d.Feature),
+		})
+	}
+	return issues
+}
+
+// DecorateProviderMeta updates the operations of res so that the meta
+// passed to each of them uses the previews set by the module's `provider_meta` block.
+//
+// The SDK only sends the `provider_meta` block for managed resources,
+// data sources will always use the provider configured values.
+func DecorateProviderMeta(res *schema.Resource) *schema.Resource {
+	if res == nil {
+		return nil
+	}
+
+	res.Create = wrapModuleMeta(res.Create)
+	res.Read = wrapModuleMeta(res.Read)
+	res.Update = wrapModuleMeta(res.Update)
+	res.Delete = wrapModuleMeta(res.Delete)
+
+	res.CreateContext = wrapModuleMetaContext(res.CreateContext)
+	res.ReadContext = wrapModuleMetaContext(res.ReadContext)
+	res.UpdateContext = wrapModuleMetaContext(res.UpdateContext)
+	res.DeleteContext = wrapModuleMetaContext(res.DeleteContext)
+	return res
+}
+
+func wrapModuleMeta[Func schema.CreateFunc | schema.ReadFunc | schema.UpdateFunc | schema.DeleteFunc](fn Func) Func {
+	if fn == nil {
+		return nil
+	}
+	return func(data *schema.ResourceData, meta any) error {
+		meta, diags, err := loadModuleMeta(context.Background(), data, meta)
+		if err != nil {
+			return err
+		}
+		if diags.HasError() {
+			return diags.Err()
+		}
+		for _, d := range diags {
+			log.Printf("[WARN] SignalFx: provider_meta feature_preview %q: %s", d.Feature, d.Detail)
+		}
+		return fn(data, meta)
+	}
+}
+
+func wrapModuleMetaContext[Func schema.CreateContextFunc | schema.ReadContextFunc | schema.UpdateContextFunc | schema.DeleteContextFunc](fn Func) Func {
+	if fn == nil {
+		return nil
+	}
+	return func(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+		meta, diags, err := loadModuleMeta(ctx, data, meta)
+		if err != nil {
+			return tfext.AsErrorDiagnostics(err)
+		}
+		issues := PreviewSDKDiagnostics(diags)
+		for i := range issues {
+			// The path would refer to the resource rather than the provider_meta block.
+			issues[i].AttributePath = nil
+			issues[i].Detail = fmt.Sprintf("provider_meta feature_preview %q: %s", diags[i].Feature, issues[i].Detail)
+		}
+		if issues.HasError() {
+			return issues
+		}
+		return tfext.AppendDiagnostics(issues, fn(ctx, data, meta)...)
+	}
+}
+
+func loadModuleMeta(ctx context.Context, data *schema.ResourceData, meta any) (any, feature.Diagnostics, error) {
+	m, ok := meta.(*Meta)
+	if !ok {
+		return meta, nil, nil
+	}
+
+	var mc ModuleConfig
+	if err := data.GetProviderMeta(&mc); err != nil {
+		return nil, nil, err
+	}
+
+	scoped, diags := m.WithModulePreviews(ctx, mc.FeaturePreview)
+	return scoped, diags, nil
+}
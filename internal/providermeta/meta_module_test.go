@@ -0,0 +1,118 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+)
+
+func TestPreviewSDKDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, PreviewSDKDiagnostics(nil), "Must not return any diagnostics")
+	assert.Equal(t, diag.Diagnostics{
+		{Severity: diag.Warning, Summary: "summary", Detail: "detail", AttributePath: cty.GetAttrPath("feature_preview").IndexString("provider.tags")},
+		{Severity: diag.Error, Summary: "unknown", Detail: "not found", AttributePath: cty.GetAttrPath("feature_preview").IndexString("provider.tag")},
+	}, PreviewSDKDiagnostics(feature.Diagnostics{
+		{Severity: feature.SeverityWarning, Kind: feature.DiagnosticDeprecated, Feature: "provider.tags", Summary: "summary", Detail: "detail"},
+		{Severity: feature.SeverityError, Kind: feature.DiagnosticUnknown, Feature: "provider.tag", Summary: "unknown", Detail: "not found"},
+	}), "Must match the expected sdk diagnostics")
+}
+
+func TestMetaWithModulePreviews(t *testing.T) {
+	t.Parallel()
+
+	newMeta := func() *Meta {
+		reg := feature.NewRegistry()
+		reg.MustRegister(feature.PreviewProviderTags)
+		return &Meta{Registry: reg, Tags: []string{"env:test"}}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		meta     *Meta
+		previews map[string]bool
+		tags     []string
+		errVal   string
+	}{
+		{
+			name:     "no meta",
+			meta:     nil,
+			previews: map[string]bool{feature.PreviewProviderTags: true},
+			tags:     nil,
+		},
+		{
+			name:     "no module previews",
+			meta:     newMeta(),
+			previews: nil,
+			tags:     nil,
+		},
+		{
+			name:     "module enables preview",
+			meta:     newMeta(),
+			previews: map[string]bool{feature.PreviewProviderTags: true},
+			tags:     []string{"env:test"},
+		},
+		{
+			name:     "unknown module preview",
+			meta:     newMeta(),
+			previews: map[string]bool{"provider.tag": true},
+			tags:     nil,
+			errVal:   "feature_preview \"provider.tag\": no preview with id \"provider.tag\" found, did you mean \"provider.tags\"?",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			scoped, diags := tc.meta.WithModulePreviews(t.Context(), tc.previews)
+			if tc.errVal != "" {
+				assert.EqualError(t, diags.Err(), tc.errVal, "Must match the expected error")
+				return
+			}
+			require.Empty(t, diags, "Must not return any diagnostics")
+			assert.Equal(t, tc.tags, LoadProviderTags(t.Context(), scoped), "Must match the expected tags")
+			if tc.meta != nil {
+				assert.Nil(t, LoadProviderTags(t.Context(), tc.meta), "Must not modify the provider meta")
+			}
+		})
+	}
+}
+
+func TestDecorateProviderMeta(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, DecorateProviderMeta(nil), "Must return nil for an unset resource")
+
+	var calls []any
+	res := DecorateProviderMeta(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Optional: true},
+		},
+		Read: func(_ *schema.ResourceData, meta any) error {
+			calls = append(calls, meta)
+			return nil
+		},
+		DeleteContext: func(_ context.Context, _ *schema.ResourceData, meta any) diag.Diagnostics {
+			calls = append(calls, meta)
+			return nil
+		},
+	})
+	assert.Nil(t, res.Create, "Must not set unset operations")
+	assert.Nil(t, res.CreateContext, "Must not set unset operations")
+
+	meta := &Meta{Registry: feature.NewRegistry()}
+	data := res.TestResourceData()
+	require.NoError(t, res.Read(data, meta), "Must not error reading")
+	require.Empty(t, res.DeleteContext(t.Context(), data, meta), "Must not error deleting")
+	assert.Equal(t, []any{meta, meta}, calls, "Must pass the provider meta when the module does not set any values")
+}
@@ -0,0 +1,156 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+const (
+	// ProfileEnvVar is used to select the profile when
+	// it is not set as part of the provider configuration.
+	ProfileEnvVar = "SFX_PROFILE"
+
+	// DefaultProfileName is the profile used when no profile has been
+	// selected and the configuration file does not set `default_profile`.
+	DefaultProfileName = "default"
+)
+
+var (
+	ErrProfileNotFound = errors.New("profile not found")
+)
+
+// profileFile is the named profile format of the provider configuration file,
+// similar to the AWS shared config, each profile holds the same fields as [Meta]:
+//
+//	{
+//	  "default_profile": "us1",
+//	  "profiles": {
+//	    "us1": {"auth_token": "...", "realm": "us1", "org_id": "..."},
+//	    "eu0": {"auth_token": "...", "realm": "eu0", "org_id": "..."}
+//	  }
+//	}
+type profileFile struct {
+	DefaultProfile string                     `json:"default_profile"`
+	Profiles       map[string]json.RawMessage `json:"profiles"`
+}
+
+// SelectProfile sets the profile to read from the provider configuration files.
+// The value configured as part of the provider takes priority over
+// the `SFX_PROFILE` environment variable.
+func (m *Meta) SelectProfile(configured string) {
+	m.selectProfile(configured, os.LookupEnv)
+}
+
+func (m *Meta) selectProfile(configured string, lookupEnv func(string) (string, bool)) {
+	if configured != "" {
+		m.Profile, m.profileReason = configured, "set by the provider argument"
+		return
+	}
+	if env, ok := lookupEnv(ProfileEnvVar); ok && env != "" {
+		m.Profile, m.profileReason = env, "set by the "+ProfileEnvVar+" environment variable"
+	}
+}
+
+// UnmarshalConfigFile reads the provider configuration file content into s,
+// applying only the selected profile if the file defines named profiles.
+//
+// Unlike [FileMetaLookupFunc], unknown fields are ignored.
+func UnmarshalConfigFile(ctx context.Context, path string, content []byte, s *Meta) error {
+	return decodeConfigFile(ctx, path, content, s, false)
+}
+
+func decodeConfigFile(ctx context.Context, path string, content []byte, s *Meta, strict bool) error {
+	decode := func(data []byte, v any) error {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if strict {
+			dec.DisallowUnknownFields()
+		}
+		if err := dec.Decode(v); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = errors.New("no file content")
+			}
+			return err
+		}
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := decode(content, &fields); err != nil {
+		return err
+	}
+	if _, ok := fields["profiles"]; !ok {
+		return decodeConfigValues(content, s, decode)
+	}
+
+	var pf profileFile
+	if err := decode(content, &pf); err != nil {
+		return err
+	}
+
+	name, reason := s.Profile, s.profileReason
+	switch {
+	case name != "":
+		// Profile has been selected by the provider configuration
+	case pf.DefaultProfile != "":
+		name, reason = pf.DefaultProfile, "set by default_profile in the configuration file"
+	default:
+		name, reason = DefaultProfileName, "no profile was selected"
+	}
+
+	profile, ok := pf.Profiles[name]
+	if !ok {
+		return fmt.Errorf("%w: %q in %s", ErrProfileNotFound, name, path)
+	}
+
+	tflog.Info(ctx, "Using provider configuration profile", tfext.NewLogFields().
+		Field("profile", name).
+		Field("reason", reason).
+		Field("path", path),
+	)
+
+	if err := decodeConfigValues(profile, s, decode); err != nil {
+		return fmt.Errorf("profile %q: %w", name, err)
+	}
+
+	s.profileLoaded = s.profileLoaded || name == s.Profile
+	return nil
+}
+
+// decodeConfigValues reads the values into s, deriving the
+// endpoints from the realm if the values do not set the api url.
+func decodeConfigValues(content []byte, s *Meta, decode func([]byte, any) error) error {
+	var set map[string]json.RawMessage
+	if err := decode(content, &set); err != nil {
+		return err
+	}
+	if err := decode(content, s); err != nil {
+		return err
+	}
+
+	// Values that only set the realm should not
+	// use the urls read from a previous configuration file.
+	_, hasRealm := set["realm"]
+	_, hasURL := set["api_url"]
+	if hasRealm && !hasURL {
+		app := s.CustomAppURL
+		if err := s.SetRealm(s.Realm); err != nil {
+			return err
+		}
+		if _, ok := set["custom_app_url"]; ok {
+			s.CustomAppURL = app
+		}
+	}
+	return nil
+}
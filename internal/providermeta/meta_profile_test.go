@@ -0,0 +1,205 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectProfile(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		configured string
+		env        string
+		expect     string
+		reason     string
+	}{
+		{
+			name:       "no profile selected",
+			configured: "",
+			env:        "",
+			expect:     "",
+			reason:     "",
+		},
+		{
+			name:       "provider argument set",
+			configured: "us1",
+			env:        "",
+			expect:     "us1",
+			reason:     "set by the provider argument",
+		},
+		{
+			name:       "environment variable set",
+			configured: "",
+			env:        "eu0",
+			expect:     "eu0",
+			reason:     "set by the SFX_PROFILE environment variable",
+		},
+		{
+			name:       "provider argument takes priority",
+			configured: "us1",
+			env:        "eu0",
+			expect:     "us1",
+			reason:     "set by the provider argument",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Since the environment is modified,
+			// parallel is not enabled.
+			t.Setenv(ProfileEnvVar, tc.env)
+
+			var m Meta
+			m.SelectProfile(tc.configured)
+			assert.Equal(t, tc.expect, m.Profile, "Must match the expected profile")
+			assert.Equal(t, tc.reason, m.profileReason, "Must match the expected reason")
+		})
+	}
+}
+
+func TestFileProfileLookup(t *testing.T) {
+	t.Parallel()
+
+	const profiles = `{
+		"default_profile": "us1",
+		"profiles": {
+			"default": {"auth_token": "ddd", "api_url": "https://api.signalfx.com"},
+			"us1": {"auth_token": "aaa", "realm": "us1", "org_id": "org-us1"},
+			"eu0": {"auth_token": "bbb", "realm": "eu0", "org_id": "org-eu0"}
+		}
+	}`
+
+	for _, tc := range []struct {
+		name    string
+		content string
+		meta    Meta
+		expect  Meta
+		errVal  string
+	}{
+		{
+			name:    "profile selected",
+			content: profiles,
+			meta:    Meta{Profile: "eu0", profileReason: "set by the provider argument"},
+			expect: Meta{
+				Profile:        "eu0",
+				AuthToken:      "bbb",
+				Realm:          "eu0",
+				APIURL:         "https://api.eu0.observability.splunkcloud.com",
+				IngestURL:      "https://ingest.eu0.observability.splunkcloud.com",
+				StreamURL:      "https://stream.eu0.observability.splunkcloud.com",
+				CustomAppURL:   "https://app.eu0.observability.splunkcloud.com",
+				OrganizationID: "org-eu0",
+				profileReason:  "set by the provider argument",
+				profileLoaded:  true,
+			},
+			errVal: "",
+		},
+		{
+			name:    "file default profile",
+			content: profiles,
+			meta:    Meta{},
+			expect: Meta{
+				AuthToken:      "aaa",
+				Realm:          "us1",
+				APIURL:         "https://api.us1.observability.splunkcloud.com",
+				IngestURL:      "https://ingest.us1.observability.splunkcloud.com",
+				StreamURL:      "https://stream.us1.observability.splunkcloud.com",
+				CustomAppURL:   "https://app.us1.observability.splunkcloud.com",
+				OrganizationID: "org-us1",
+			},
+			errVal: "",
+		},
+		{
+			name:    "default profile",
+			content: `{"profiles": {"default": {"auth_token": "ddd", "api_url": "https://api.signalfx.com"}}}`,
+			meta:    Meta{},
+			expect: Meta{
+				AuthToken: "ddd",
+				APIURL:    "https://api.signalfx.com",
+			},
+			errVal: "",
+		},
+		{
+			name:    "realm replaces previous api url",
+			content: profiles,
+			meta:    Meta{APIURL: "https://api.signalfx.com"},
+			expect: Meta{
+				AuthToken:      "aaa",
+				Realm:          "us1",
+				APIURL:         "https://api.us1.observability.splunkcloud.com",
+				IngestURL:      "https://ingest.us1.observability.splunkcloud.com",
+				StreamURL:      "https://stream.us1.observability.splunkcloud.com",
+				CustomAppURL:   "https://app.us1.observability.splunkcloud.com",
+				OrganizationID: "org-us1",
+			},
+			errVal: "",
+		},
+		{
+			name:    "profile not found",
+			content: profiles,
+			meta:    Meta{Profile: "jp0"},
+			expect:  Meta{Profile: "jp0"},
+			errVal:  "profile not found: \"jp0\" in ",
+		},
+		{
+			name:    "unknown profile field",
+			content: `{"profiles": {"default": {"token": "aaa"}}}`,
+			meta:    Meta{},
+			expect:  Meta{},
+			errVal:  "profile \"default\": json: unknown field \"token\"",
+		},
+		{
+			name:    "unknown realm",
+			content: `{"profiles": {"default": {"auth_token": "aaa", "realm": "mars0"}}}`,
+			meta:    Meta{},
+			expect:  Meta{AuthToken: "aaa", Realm: "mars0"},
+			errVal:  "profile \"default\": realm \"mars0\" is not one of: ",
+		},
+		{
+			name:    "mixed formats",
+			content: `{"auth_token": "aaa", "profiles": {}}`,
+			meta:    Meta{},
+			expect:  Meta{},
+			errVal:  "json: unknown field \"auth_token\"",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := path.Join(t.TempDir(), ".signalfx.conf")
+			require.NoError(t, os.WriteFile(p, []byte(tc.content), 0o600), "Must not error writing file")
+
+			actual := tc.meta
+			if err := FileMetaLookupFunc(p).Do(context.Background(), &actual); tc.errVal != "" {
+				require.ErrorContains(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				require.NoError(t, err, "Must not error reading profile")
+			}
+			assert.Equal(t, tc.expect, actual, "Must match the expected configuration")
+		})
+	}
+}
+
+func TestUnmarshalConfigFile(t *testing.T) {
+	t.Parallel()
+
+	var actual Meta
+	err := UnmarshalConfigFile(
+		context.Background(),
+		"signalfx.conf",
+		[]byte(`{"default_profile": "us1", "profiles": {"us1": {"auth_token": "aaa", "useless_config": "foo"}}}`),
+		&actual,
+	)
+	require.NoError(t, err, "Must ignore unknown fields")
+	assert.Equal(t, Meta{AuthToken: "aaa"}, actual, "Must match the expected configuration")
+
+	actual = Meta{Profile: "eu0"}
+	err = UnmarshalConfigFile(context.Background(), "signalfx.conf", []byte(`{"profiles": {}}`), &actual)
+	require.ErrorIs(t, err, ErrProfileNotFound, "Must report the missing profile")
+}
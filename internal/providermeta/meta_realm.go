@@ -0,0 +1,68 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RealmEnvVar is used to set the realm when
+// it is not set as part of the provider configuration.
+const RealmEnvVar = "SFX_REALM"
+
+// Realms is the list of Splunk Observability Cloud realms
+// that the provider endpoints can be derived from.
+var Realms = []string{
+	"au0",
+	"eu0",
+	"eu1",
+	"eu2",
+	"jp0",
+	"sg0",
+	"us0",
+	"us1",
+	"us2",
+}
+
+// RealmEndpoints holds the URLs of each service within a realm.
+type RealmEndpoints struct {
+	API    string
+	Ingest string
+	Stream string
+	App    string
+}
+
+// NewRealmEndpoints returns the endpoints for the realm,
+// erroring if the realm is not one of [Realms].
+func NewRealmEndpoints(realm string) (RealmEndpoints, error) {
+	if !slices.Contains(Realms, realm) {
+		return RealmEndpoints{}, fmt.Errorf("realm %q is not one of: %s", realm, strings.Join(Realms, ", "))
+	}
+	endpoint := func(service string) string {
+		return fmt.Sprintf("https://%s.%s.observability.splunkcloud.com", service, realm)
+	}
+	return RealmEndpoints{
+		API:    endpoint("api"),
+		Ingest: endpoint("ingest"),
+		Stream: endpoint("stream"),
+		App:    endpoint("app"),
+	}, nil
+}
+
+// SetRealm sets the realm and replaces the API, ingest, stream
+// and application URLs with the ones derived from it.
+func (m *Meta) SetRealm(realm string) error {
+	endpoints, err := NewRealmEndpoints(realm)
+	if err != nil {
+		return err
+	}
+	m.Realm = realm
+	m.APIURL = endpoints.API
+	m.IngestURL = endpoints.Ingest
+	m.StreamURL = endpoints.Stream
+	m.CustomAppURL = endpoints.App
+	return nil
+}
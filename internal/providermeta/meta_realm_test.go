@@ -0,0 +1,80 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRealmEndpoints(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		realm  string
+		expect RealmEndpoints
+		errVal string
+	}{
+		{
+			name:   "no realm",
+			realm:  "",
+			expect: RealmEndpoints{},
+			errVal: "realm \"\" is not one of: au0, eu0, eu1, eu2, jp0, sg0, us0, us1, us2",
+		},
+		{
+			name:   "unknown realm",
+			realm:  "US1",
+			expect: RealmEndpoints{},
+			errVal: "realm \"US1\" is not one of: au0, eu0, eu1, eu2, jp0, sg0, us0, us1, us2",
+		},
+		{
+			name:  "known realm",
+			realm: "us1",
+			expect: RealmEndpoints{
+				API:    "https://api.us1.observability.splunkcloud.com",
+				Ingest: "https://ingest.us1.observability.splunkcloud.com",
+				Stream: "https://stream.us1.observability.splunkcloud.com",
+				App:    "https://app.us1.observability.splunkcloud.com",
+			},
+			errVal: "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := NewRealmEndpoints(tc.realm)
+			if tc.errVal != "" {
+				require.EqualError(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				require.NoError(t, err, "Must not error for a known realm")
+			}
+			assert.Equal(t, tc.expect, actual, "Must match the expected endpoints")
+		})
+	}
+}
+
+func TestMetaSetRealm(t *testing.T) {
+	t.Parallel()
+
+	m := &Meta{
+		AuthToken:    "aaa",
+		APIURL:       "https://api.signalfx.com",
+		CustomAppURL: "https://app.signalfx.com",
+	}
+	require.NoError(t, m.SetRealm("eu0"), "Must not error setting a known realm")
+	assert.Equal(t, &Meta{
+		AuthToken:    "aaa",
+		Realm:        "eu0",
+		APIURL:       "https://api.eu0.observability.splunkcloud.com",
+		IngestURL:    "https://ingest.eu0.observability.splunkcloud.com",
+		StreamURL:    "https://stream.eu0.observability.splunkcloud.com",
+		CustomAppURL: "https://app.eu0.observability.splunkcloud.com",
+	}, m, "Must replace the endpoints with the realm values")
+
+	require.Error(t, m.SetRealm("mars0"), "Must error setting an unknown realm")
+	assert.Equal(t, "eu0", m.Realm, "Must not modify the realm on error")
+}
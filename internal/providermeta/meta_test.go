@@ -134,6 +134,33 @@ func TestLoadPreviewRegistry(t *testing.T) {
 	}
 }
 
+func TestMetaConfigurePreviews(t *testing.T) {
+	t.Parallel()
+
+	reg := feature.NewRegistry(feature.WithRegistryProviderVersion("v10.0.0"))
+	tags := reg.MustRegister(feature.PreviewProviderTags)
+	teams := reg.MustRegister(feature.PreviewProviderTeams, feature.WithPreviewRemovedInVersion("v10.0.0"))
+
+	m := &Meta{
+		Registry: reg,
+		FeaturePreview: map[string]bool{
+			feature.PreviewProviderTeams: true,
+			feature.PreviewProviderTags:  true,
+			"provider.tag":               true,
+		},
+	}
+
+	diags := m.ConfigurePreviews(t.Context())
+	require.Len(t, diags, 2, "Must return a diagnostic for the unknown and removed previews")
+	assert.Equal(t, "provider.tag", diags[0].Feature, "Must be ordered by the preview name")
+	assert.Equal(t, feature.DiagnosticUnknown, diags[0].Kind, "Must report the unknown preview")
+	assert.Equal(t, feature.PreviewProviderTeams, diags[1].Feature, "Must be ordered by the preview name")
+	assert.Equal(t, feature.DiagnosticRemoved, diags[1].Kind, "Must report the removed preview")
+
+	assert.True(t, tags.Enabled(), "Must enable the configured preview")
+	assert.False(t, teams.Enabled(), "Must not enable the removed preview")
+}
+
 func TestMetaValidation(t *testing.T) {
 	t.Parallel()
 
@@ -170,6 +197,24 @@ func TestMetaValidation(t *testing.T) {
 			},
 			errVal: "missing auth token or email and password",
 		},
+		{
+			name: "profile not loaded",
+			meta: Meta{
+				Profile:   "us1",
+				AuthToken: "aaa",
+				APIURL:    "http://api.signalfx.com",
+			},
+			errVal: "profile \"us1\" was not found in any provider configuration file",
+		},
+		{
+			name: "profile loaded",
+			meta: Meta{
+				Profile:       "us1",
+				AuthToken:     "aaa",
+				APIURL:        "http://api.signalfx.com",
+				profileLoaded: true,
+			},
+		},
 	} {
 
 		t.Run(tc.name, func(t *testing.T) {
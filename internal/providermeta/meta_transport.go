@@ -0,0 +1,130 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// NewTransport returns the [http.Transport] used to connect to the API,
+// using the proxy, certificate authorities and client certificate set in m.
+//
+// The proxy is read from the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY`
+// environment variables when m does not set one.
+func (m *Meta) NewTransport() (*http.Transport, error) {
+	proxy := http.ProxyFromEnvironment
+	if m.ProxyURL != "" {
+		u, err := url.Parse(m.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("proxy url: %w", err)
+		}
+		if !slices.Contains([]string{"http", "https", "socks5"}, u.Scheme) || u.Host == "" {
+			return nil, fmt.Errorf("proxy url %q must be an http, https or socks5 url", u.Redacted())
+		}
+		proxy = http.ProxyURL(u)
+	}
+
+	tc, err := m.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Transport{
+		Proxy:               proxy,
+		DialContext:         (&net.Dialer{Timeout: 5 * time.Second}).DialContext,
+		TLSClientConfig:     tc,
+		TLSHandshakeTimeout: 5 * time.Second,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 100,
+	}, nil
+}
+
+// newHTTPClient returns the client used for requests made
+// before the provider client has been created.
+func (m *Meta) newHTTPClient() (*http.Client, error) {
+	t, err := m.NewTransport()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: t, Timeout: m.Timeout}, nil
+}
+
+func (m *Meta) tlsConfig() (*tls.Config, error) {
+	tc := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Skipping verification is an explicit user choice,
+		// intended for testing against a local endpoint.
+		InsecureSkipVerify: m.InsecureSkipVerify, //nolint:gosec // configured by the user
+	}
+
+	var bundle []byte
+	switch {
+	case m.CACertFile != "" && m.CACertPEM != "":
+		return nil, errors.New("ca_cert_file and ca_cert_pem can not both be set")
+	case m.CACertFile != "":
+		content, err := os.ReadFile(m.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("ca_cert_file: %w", err)
+		}
+		bundle = content
+	case m.CACertPEM != "":
+		bundle = []byte(m.CACertPEM)
+	}
+
+	if bundle != nil {
+		// The bundle is added to the system certificates so that a proxy
+		// that re-signs traffic does not prevent other endpoints from being trusted.
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, errors.New("ca certificate bundle does not contain any PEM encoded certificates")
+		}
+		tc.RootCAs = pool
+	}
+
+	if m.ClientCert == "" && m.ClientKey == "" {
+		return tc, nil
+	}
+	if m.ClientCert == "" || m.ClientKey == "" {
+		return nil, errors.New("client_cert and client_key must both be set")
+	}
+
+	cert, err := readPEMValue(m.ClientCert)
+	if err != nil {
+		return nil, fmt.Errorf("client_cert: %w", err)
+	}
+	key, err := readPEMValue(m.ClientKey)
+	if err != nil {
+		return nil, fmt.Errorf("client_key: %w", err)
+	}
+	pair, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return nil, fmt.Errorf("client certificate: %w", err)
+	}
+	tc.Certificates = []tls.Certificate{pair}
+
+	return tc, nil
+}
+
+// readPEMValue returns the value if it is PEM encoded,
+// otherwise the value is read as the path to a PEM encoded file.
+func readPEMValue(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN ") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
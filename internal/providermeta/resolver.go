@@ -0,0 +1,428 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/track"
+)
+
+// The environment variables read by the [Resolver],
+// each one matches the provider argument of the same name.
+const (
+	AuthTokenEnvVar             = "SFX_AUTH_TOKEN"
+	APIURLEnvVar                = "SFX_API_URL"
+	CustomAppURLEnvVar          = "SFX_CUSTOM_APP_URL"
+	EmailEnvVar                 = "SFX_EMAIL"
+	PasswordEnvVar              = "SFX_PASSWORD"
+	OrganizationIDEnvVar        = "SFX_ORGANIZATION_ID"
+	TimeoutSecondsEnvVar        = "SFX_TIMEOUT_SECONDS"
+	RetryMaxAttemptsEnvVar      = "SFX_RETRY_MAX_ATTEMPTS"
+	RetryWaitMinSecondsEnvVar   = "SFX_RETRY_WAIT_MIN_SECONDS"
+	RetryWaitMaxSecondsEnvVar   = "SFX_RETRY_WAIT_MAX_SECONDS"
+	MaxRequestsPerSecondEnvVar  = "SFX_MAX_REQUESTS_PER_SECOND"
+	MaxConcurrentRequestsEnvVar = "SFX_MAX_CONCURRENT_REQUESTS"
+	ProxyURLEnvVar              = "SFX_PROXY_URL"
+	CACertFileEnvVar            = "SFX_CA_CERT_FILE"
+	CACertPEMEnvVar             = "SFX_CA_CERT_PEM"
+	ClientCertEnvVar            = "SFX_CLIENT_CERT"
+	ClientKeyEnvVar             = "SFX_CLIENT_KEY"
+	InsecureSkipVerifyEnvVar    = "SFX_INSECURE_SKIP_VERIFY"
+	FeaturePreviewEnvVar        = "SFX_FEATURE_PREVIEW"
+	TagsEnvVar                  = "SFX_TAGS"
+	TeamsEnvVar                 = "SFX_TEAMS"
+)
+
+// The values used when no configuration source sets them.
+const (
+	DefaultAPIURL           = "https://api.signalfx.com"
+	DefaultCustomAppURL     = "https://app.signalfx.com"
+	DefaultTimeout          = 120 * time.Second
+	DefaultRetryMaxAttempts = 4
+	DefaultRetryWaitMin     = 1 * time.Second
+	DefaultRetryWaitMax     = 30 * time.Second
+)
+
+// Config holds the values set by a single configuration source,
+// where a nil value is considered unset so the next source is used.
+type Config struct {
+	AuthToken             *string
+	APIURL                *string
+	Realm                 *string
+	CustomAppURL          *string
+	Email                 *string
+	Password              *string
+	OrganizationID        *string
+	Profile               *string
+	TimeoutSeconds        *int
+	RetryMaxAttempts      *int
+	RetryWaitMinSeconds   *int
+	RetryWaitMaxSeconds   *int
+	MaxRequestsPerSecond  *float64
+	MaxConcurrentRequests *int
+	ProxyURL              *string
+	CACertFile            *string
+	CACertPEM             *string
+	ClientCert            *string
+	ClientKey             *string
+	InsecureSkipVerify    *bool
+	FeaturePreview        map[string]bool
+	Tags                  []string
+	Teams                 []string
+}
+
+// Resolver builds the provider [Meta] so that each provider
+// resolves the same configuration the same way.
+type Resolver struct {
+	lookups   []MetaLookupFunc
+	lookupEnv func(string) (string, bool)
+}
+
+type ResolverOption func(r *Resolver)
+
+// WithResolverLookups replaces the default lookups used to read
+// the profile files and netrc, ordered from lowest to highest precedence.
+func WithResolverLookups(lookups ...MetaLookupFunc) ResolverOption {
+	return func(r *Resolver) {
+		r.lookups = lookups
+	}
+}
+
+// WithResolverEnvironment replaces the process environment
+// with the provided values, intended to be used with testing.
+func WithResolverEnvironment(env map[string]string) ResolverOption {
+	return func(r *Resolver) {
+		r.lookupEnv = func(key string) (string, bool) {
+			v, ok := env[key]
+			return v, ok
+		}
+	}
+}
+
+func NewResolver(opts ...ResolverOption) *Resolver {
+	r := &Resolver{
+		lookups:   NewDefaultProviderLookups(),
+		lookupEnv: os.LookupEnv,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Resolve returns the [Meta] built from each of the configuration sources.
+// A source only replaces the values it sets, in order of highest precedence:
+//
+//  1. The explicit provider configuration
+//  2. The `SFX_*` environment variables
+//  3. The profile files, `~/.signalfx.conf` then `/etc/signalfx.conf`
+//  4. The netrc file, `$NETRC` or `~/.netrc`
+//
+// Any value that is not set by a source uses its documented default.
+func (r *Resolver) Resolve(ctx context.Context, config Config) (*Meta, error) {
+	env, err := r.Environment()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Meta{
+		APIURL:           DefaultAPIURL,
+		CustomAppURL:     DefaultCustomAppURL,
+		Timeout:          DefaultTimeout,
+		RetryMaxAttempts: DefaultRetryMaxAttempts,
+		RetryWaitMin:     DefaultRetryWaitMin,
+		RetryWaitMax:     DefaultRetryWaitMax,
+	}
+
+	var profile string
+	if config.Profile != nil {
+		profile = *config.Profile
+	}
+	m.selectProfile(profile, r.lookupEnv)
+
+	for _, lookup := range r.lookups {
+		if err := lookup.Do(ctx, m); err != nil {
+			tflog.Debug(ctx, "Issue trying to load external provider configuration, skipping", tfext.ErrorLogFields(err))
+		}
+	}
+
+	for _, source := range []Config{env, config} {
+		if err := source.apply(m); err != nil {
+			return nil, err
+		}
+	}
+
+	tflog.Debug(ctx, "Resolved provider configuration", tfext.NewLogFields().
+		Field("api_url", m.APIURL).
+		Field("realm", m.Realm).
+		Field("profile", m.Profile).
+		Duration("timeout", m.Timeout).
+		Field("retry_max_attempts", m.RetryMaxAttempts).
+		Field("max_requests_per_second", m.MaxRequestsPerSecond).
+		Field("max_concurrent_requests", m.MaxConcurrentRequests),
+	)
+	return m, nil
+}
+
+// Finalize completes the configuration of the resolved meta so that each provider
+// configures it the same way. It validates the settings, configures the feature previews,
+// creates the clients, detects the custom app url and applies the tracking tags.
+//
+// The preview diagnostics are returned so the caller can report them against its own
+// configuration, no further steps are done when they include an error.
+func (m *Meta) Finalize(ctx context.Context, userAgent string, httpLog *tfext.HTTPLogger) (feature.Diagnostics, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	diags := m.ConfigurePreviews(ctx)
+	if diags.HasError() {
+		return diags, nil
+	}
+
+	if err := m.ConfigureClient(ctx, userAgent, httpLog); err != nil {
+		return diags, err
+	}
+
+	tflog.Debug(ctx, "Configured settings for http client", tfext.NewLogFields().
+		Field("attempts", m.RetryMaxAttempts).
+		Duration("timeout", m.Timeout).
+		Duration("wait_min", m.RetryWaitMin).
+		Duration("wait_max", m.RetryWaitMax).
+		Field("max_requests_per_second", m.MaxRequestsPerSecond).
+		Field("max_concurrent_requests", m.MaxConcurrentRequests).
+		Field("insecure_skip_verify", m.InsecureSkipVerify),
+	)
+
+	// The app url is derived from the realm so it does not need to be detected,
+	// otherwise the resolved value is kept when it can not be detected.
+	if m.Realm == "" {
+		if site, err := m.DetectCustomAPPURL(ctx); err != nil {
+			tflog.Debug(ctx, "Unable to detect custom app url, skipping", tfext.ErrorLogFields(err))
+		} else {
+			m.CustomAppURL = site
+		}
+	}
+
+	if gate, ok := LoadPreviewRegistry(ctx, m).Get(feature.PreviewProviderTracking); ok && gate.Enabled() {
+		tracking, err := track.ReadGitDetails(ctx)
+		if err != nil {
+			tflog.Info(ctx, "Unable to load git details, skipping", tfext.ErrorLogFields(err))
+		} else {
+			m.Tags = append(m.Tags, tracking.Tags()...)
+		}
+	}
+
+	return diags, nil
+}
+
+// Environment returns the values set by the `SFX_*` environment variables.
+func (r *Resolver) Environment() (Config, error) {
+	var (
+		c    Config
+		errs []error
+	)
+
+	str := func(key string) *string {
+		if v, ok := r.lookupEnv(key); ok && v != "" {
+			return &v
+		}
+		return nil
+	}
+	num := func(key string) *int {
+		v := str(key)
+		if v == nil {
+			return nil
+		}
+		n, err := strconv.Atoi(*v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("environment variable %s: %w", key, err))
+			return nil
+		}
+		return &n
+	}
+	float := func(key string) *float64 {
+		v := str(key)
+		if v == nil {
+			return nil
+		}
+		f, err := strconv.ParseFloat(*v, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("environment variable %s: %w", key, err))
+			return nil
+		}
+		return &f
+	}
+	boolean := func(key string) *bool {
+		v := str(key)
+		if v == nil {
+			return nil
+		}
+		b, err := strconv.ParseBool(*v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("environment variable %s: %w", key, err))
+			return nil
+		}
+		return &b
+	}
+	list := func(key string) []string {
+		v := str(key)
+		if v == nil {
+			return nil
+		}
+		var values []string
+		for item := range strings.SplitSeq(*v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
+	}
+
+	c.AuthToken = str(AuthTokenEnvVar)
+	c.APIURL = str(APIURLEnvVar)
+	c.Realm = str(RealmEnvVar)
+	c.CustomAppURL = str(CustomAppURLEnvVar)
+	c.Email = str(EmailEnvVar)
+	c.Password = str(PasswordEnvVar)
+	c.OrganizationID = str(OrganizationIDEnvVar)
+	c.Profile = str(ProfileEnvVar)
+	c.TimeoutSeconds = num(TimeoutSecondsEnvVar)
+	c.RetryMaxAttempts = num(RetryMaxAttemptsEnvVar)
+	c.RetryWaitMinSeconds = num(RetryWaitMinSecondsEnvVar)
+	c.RetryWaitMaxSeconds = num(RetryWaitMaxSecondsEnvVar)
+	c.MaxRequestsPerSecond = float(MaxRequestsPerSecondEnvVar)
+	c.MaxConcurrentRequests = num(MaxConcurrentRequestsEnvVar)
+	c.ProxyURL = str(ProxyURLEnvVar)
+	c.CACertFile = str(CACertFileEnvVar)
+	c.CACertPEM = str(CACertPEMEnvVar)
+	c.ClientCert = str(ClientCertEnvVar)
+	c.ClientKey = str(ClientKeyEnvVar)
+	c.InsecureSkipVerify = boolean(InsecureSkipVerifyEnvVar)
+	c.Tags = list(TagsEnvVar)
+	c.Teams = list(TeamsEnvVar)
+
+	// Feature previews are set as a list of `name=bool`,
+	// where a name without a value enables the preview.
+	for _, item := range list(FeaturePreviewEnvVar) {
+		name, value, found := strings.Cut(item, "=")
+		enabled := true
+		if found {
+			var err error
+			if enabled, err = strconv.ParseBool(strings.TrimSpace(value)); err != nil {
+				errs = append(errs, fmt.Errorf("environment variable %s: preview %q: %w", FeaturePreviewEnvVar, name, err))
+				continue
+			}
+		}
+		if c.FeaturePreview == nil {
+			c.FeaturePreview = make(map[string]bool)
+		}
+		c.FeaturePreview[strings.TrimSpace(name)] = enabled
+	}
+
+	if len(errs) > 0 {
+		return Config{}, errors.Join(errs...)
+	}
+	return c, nil
+}
+
+// apply replaces the values within m that are set by the config.
+func (c Config) apply(m *Meta) error {
+	set := func(dst, v *string) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	seconds := func(dst *time.Duration, v *int) {
+		if v != nil {
+			*dst = time.Duration(*v) * time.Second
+		}
+	}
+
+	set(&m.AuthToken, c.AuthToken)
+	set(&m.Email, c.Email)
+	set(&m.Password, c.Password)
+	set(&m.OrganizationID, c.OrganizationID)
+
+	// The api url is used over the realm when a source sets both.
+	switch {
+	case c.APIURL != nil:
+		// The URLs derived from a realm set by a lower precedence source
+		// do not match the api url, so they are reset to their defaults.
+		// URLs that were set explicitly are kept.
+		if endpoints, err := NewRealmEndpoints(m.Realm); err == nil {
+			if m.IngestURL == endpoints.Ingest {
+				m.IngestURL = ""
+			}
+			if m.StreamURL == endpoints.Stream {
+				m.StreamURL = ""
+			}
+			if m.CustomAppURL == endpoints.App {
+				m.CustomAppURL = DefaultCustomAppURL
+			}
+		}
+		m.APIURL, m.Realm = *c.APIURL, ""
+	case c.Realm != nil:
+		if err := m.SetRealm(*c.Realm); err != nil {
+			return err
+		}
+	}
+	set(&m.CustomAppURL, c.CustomAppURL)
+
+	seconds(&m.Timeout, c.TimeoutSeconds)
+	seconds(&m.RetryWaitMin, c.RetryWaitMinSeconds)
+	seconds(&m.RetryWaitMax, c.RetryWaitMaxSeconds)
+	if c.RetryMaxAttempts != nil {
+		m.RetryMaxAttempts = *c.RetryMaxAttempts
+	}
+	if c.MaxRequestsPerSecond != nil {
+		m.MaxRequestsPerSecond = *c.MaxRequestsPerSecond
+	}
+	if c.MaxConcurrentRequests != nil {
+		m.MaxConcurrentRequests = *c.MaxConcurrentRequests
+	}
+
+	set(&m.ProxyURL, c.ProxyURL)
+	set(&m.ClientCert, c.ClientCert)
+	set(&m.ClientKey, c.ClientKey)
+	// The certificate authority is replaced as a whole,
+	// so a file set by one source is not combined with the pem of another.
+	switch {
+	case c.CACertFile != nil && c.CACertPEM != nil:
+		return errors.New("ca_cert_file and ca_cert_pem can not both be set")
+	case c.CACertFile != nil:
+		m.CACertFile, m.CACertPEM = *c.CACertFile, ""
+	case c.CACertPEM != nil:
+		m.CACertFile, m.CACertPEM = "", *c.CACertPEM
+	}
+	if c.InsecureSkipVerify != nil {
+		m.InsecureSkipVerify = *c.InsecureSkipVerify
+	}
+
+	if c.FeaturePreview != nil {
+		if m.FeaturePreview == nil {
+			m.FeaturePreview = make(map[string]bool, len(c.FeaturePreview))
+		}
+		maps.Copy(m.FeaturePreview, c.FeaturePreview)
+	}
+	if c.Tags != nil {
+		m.Tags = c.Tags
+	}
+	if c.Teams != nil {
+		m.Teams = c.Teams
+	}
+	return nil
+}
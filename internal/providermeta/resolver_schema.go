@@ -0,0 +1,81 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+)
+
+// NewConfigFromResourceData returns the [Config] of the values
+// explicitly set as part of the SDK provider configuration.
+func NewConfigFromResourceData(data *schema.ResourceData) Config {
+	str := func(key string) *string {
+		if v, ok := data.GetOk(key); ok {
+			s := v.(string)
+			return &s
+		}
+		return nil
+	}
+	num := func(key string) *int {
+		if v, ok := data.GetOkExists(key); ok {
+			n := v.(int)
+			return &n
+		}
+		return nil
+	}
+	float := func(key string) *float64 {
+		if v, ok := data.GetOkExists(key); ok {
+			f := v.(float64)
+			return &f
+		}
+		return nil
+	}
+	boolean := func(key string) *bool {
+		if v, ok := data.GetOkExists(key); ok {
+			b := v.(bool)
+			return &b
+		}
+		return nil
+	}
+	list := func(key string) []string {
+		if v, ok := data.GetOk(key); ok {
+			return convert.SliceAll(v.([]any), convert.ToString)
+		}
+		return nil
+	}
+
+	c := Config{
+		AuthToken:             str("auth_token"),
+		APIURL:                str("api_url"),
+		Realm:                 str("realm"),
+		CustomAppURL:          str("custom_app_url"),
+		Email:                 str("email"),
+		Password:              str("password"),
+		OrganizationID:        str("organization_id"),
+		Profile:               str("profile"),
+		TimeoutSeconds:        num("timeout_seconds"),
+		RetryMaxAttempts:      num("retry_max_attempts"),
+		RetryWaitMinSeconds:   num("retry_wait_min_seconds"),
+		RetryWaitMaxSeconds:   num("retry_wait_max_seconds"),
+		MaxRequestsPerSecond:  float("max_requests_per_second"),
+		MaxConcurrentRequests: num("max_concurrent_requests"),
+		ProxyURL:              str("proxy_url"),
+		CACertFile:            str("ca_cert_file"),
+		CACertPEM:             str("ca_cert_pem"),
+		ClientCert:            str("client_cert"),
+		ClientKey:             str("client_key"),
+		InsecureSkipVerify:    boolean("insecure_skip_verify"),
+		Tags:                  list("tags"),
+		Teams:                 list("teams"),
+	}
+	if v, ok := data.GetOk("feature_preview"); ok {
+		c.FeaturePreview = make(map[string]bool)
+		for name, enabled := range v.(map[string]any) {
+			c.FeaturePreview[name] = enabled.(bool)
+		}
+	}
+	return c
+}
@@ -0,0 +1,349 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pmeta
+
+import (
+	"cmp"
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+func TestResolverResolve(t *testing.T) {
+	t.Parallel()
+
+	defaults := func(m Meta) *Meta {
+		m.APIURL = cmp.Or(m.APIURL, DefaultAPIURL)
+		m.CustomAppURL = cmp.Or(m.CustomAppURL, DefaultCustomAppURL)
+		m.Timeout = DefaultTimeout
+		m.RetryMaxAttempts = DefaultRetryMaxAttempts
+		m.RetryWaitMin = DefaultRetryWaitMin
+		m.RetryWaitMax = DefaultRetryWaitMax
+		return &m
+	}
+	ptr := func(s string) *string { return &s }
+
+	for _, tc := range []struct {
+		name   string
+		netrc  string
+		file   string
+		env    map[string]string
+		config Config
+		expect *Meta
+		errVal string
+	}{
+		{
+			name:   "no values set",
+			config: Config{},
+			expect: defaults(Meta{}),
+			errVal: "",
+		},
+		{
+			name:   "netrc only",
+			netrc:  "machine api.signalfx.com login auth_login password netrc",
+			config: Config{},
+			expect: defaults(Meta{AuthToken: "netrc"}),
+			errVal: "",
+		},
+		{
+			name:   "file takes priority over netrc",
+			netrc:  "machine api.signalfx.com login auth_login password netrc",
+			file:   `{"auth_token": "file", "api_url": "https://api.file.example.com"}`,
+			config: Config{},
+			expect: defaults(Meta{AuthToken: "file", APIURL: "https://api.file.example.com"}),
+			errVal: "",
+		},
+		{
+			name:  "environment takes priority over file",
+			netrc: "machine api.signalfx.com login auth_login password netrc",
+			file:  `{"auth_token": "file", "api_url": "https://api.file.example.com"}`,
+			env: map[string]string{
+				AuthTokenEnvVar: "env",
+			},
+			config: Config{},
+			expect: defaults(Meta{AuthToken: "env", APIURL: "https://api.file.example.com"}),
+			errVal: "",
+		},
+		{
+			name:  "config takes priority over environment",
+			netrc: "machine api.signalfx.com login auth_login password netrc",
+			file:  `{"auth_token": "file", "api_url": "https://api.file.example.com"}`,
+			env: map[string]string{
+				AuthTokenEnvVar: "env",
+			},
+			config: Config{AuthToken: ptr("config")},
+			expect: defaults(Meta{AuthToken: "config", APIURL: "https://api.file.example.com"}),
+			errVal: "",
+		},
+		{
+			name: "environment sets all values",
+			env: map[string]string{
+				AuthTokenEnvVar:             "env",
+				RealmEnvVar:                 "eu0",
+				TimeoutSecondsEnvVar:        "300",
+				RetryMaxAttemptsEnvVar:      "10",
+				RetryWaitMinSecondsEnvVar:   "2",
+				RetryWaitMaxSecondsEnvVar:   "60",
+				MaxRequestsPerSecondEnvVar:  "12.5",
+				MaxConcurrentRequestsEnvVar: "8",
+				FeaturePreviewEnvVar:        "provider.tags, provider.tracking=false",
+				TagsEnvVar:                  "team:a, env:prod",
+				TeamsEnvVar:                 "aaa",
+			},
+			config: Config{},
+			expect: &Meta{
+				AuthToken:             "env",
+				Realm:                 "eu0",
+				APIURL:                "https://api.eu0.observability.splunkcloud.com",
+				IngestURL:             "https://ingest.eu0.observability.splunkcloud.com",
+				StreamURL:             "https://stream.eu0.observability.splunkcloud.com",
+				CustomAppURL:          "https://app.eu0.observability.splunkcloud.com",
+				Timeout:               300 * time.Second,
+				RetryMaxAttempts:      10,
+				RetryWaitMin:          2 * time.Second,
+				RetryWaitMax:          60 * time.Second,
+				MaxRequestsPerSecond:  12.5,
+				MaxConcurrentRequests: 8,
+				FeaturePreview:        map[string]bool{"provider.tags": true, "provider.tracking": false},
+				Tags:                  []string{"team:a", "env:prod"},
+				Teams:                 []string{"aaa"},
+			},
+			errVal: "",
+		},
+		{
+			name: "environment api url takes priority over environment realm",
+			env: map[string]string{
+				APIURLEnvVar: "https://api.env.example.com",
+				RealmEnvVar:  "eu0",
+			},
+			config: Config{},
+			expect: defaults(Meta{APIURL: "https://api.env.example.com"}),
+			errVal: "",
+		},
+		{
+			name: "config api url resets the environment realm urls",
+			env: map[string]string{
+				RealmEnvVar: "eu0",
+			},
+			config: Config{APIURL: ptr("https://api.config.example.com")},
+			expect: defaults(Meta{APIURL: "https://api.config.example.com"}),
+			errVal: "",
+		},
+		{
+			name: "config api url keeps the environment app url",
+			env: map[string]string{
+				RealmEnvVar:        "eu0",
+				CustomAppURLEnvVar: "https://app.env.example.com",
+			},
+			config: Config{APIURL: ptr("https://api.config.example.com")},
+			expect: defaults(Meta{
+				APIURL:       "https://api.config.example.com",
+				CustomAppURL: "https://app.env.example.com",
+			}),
+			errVal: "",
+		},
+		{
+			name: "config realm takes priority over environment api url",
+			env: map[string]string{
+				APIURLEnvVar:       "https://api.env.example.com",
+				CustomAppURLEnvVar: "https://app.env.example.com",
+			},
+			config: Config{Realm: ptr("jp0")},
+			expect: &Meta{
+				Realm:            "jp0",
+				APIURL:           "https://api.jp0.observability.splunkcloud.com",
+				IngestURL:        "https://ingest.jp0.observability.splunkcloud.com",
+				StreamURL:        "https://stream.jp0.observability.splunkcloud.com",
+				CustomAppURL:     "https://app.jp0.observability.splunkcloud.com",
+				Timeout:          DefaultTimeout,
+				RetryMaxAttempts: DefaultRetryMaxAttempts,
+				RetryWaitMin:     DefaultRetryWaitMin,
+				RetryWaitMax:     DefaultRetryWaitMax,
+			},
+			errVal: "",
+		},
+		{
+			name: "feature previews are merged",
+			env: map[string]string{
+				FeaturePreviewEnvVar: "provider.tags,provider.tracking",
+			},
+			config: Config{FeaturePreview: map[string]bool{"provider.tracking": false}},
+			expect: defaults(Meta{
+				FeaturePreview: map[string]bool{"provider.tags": true, "provider.tracking": false},
+			}),
+			errVal: "",
+		},
+		{
+			name: "environment selects the profile",
+			file: `{"profiles": {"default": {"auth_token": "default"}, "us1": {"auth_token": "us1", "realm": "us1"}}}`,
+			env: map[string]string{
+				ProfileEnvVar: "us1",
+			},
+			config: Config{},
+			expect: &Meta{
+				Profile:          "us1",
+				AuthToken:        "us1",
+				Realm:            "us1",
+				APIURL:           "https://api.us1.observability.splunkcloud.com",
+				IngestURL:        "https://ingest.us1.observability.splunkcloud.com",
+				StreamURL:        "https://stream.us1.observability.splunkcloud.com",
+				CustomAppURL:     "https://app.us1.observability.splunkcloud.com",
+				Timeout:          DefaultTimeout,
+				RetryMaxAttempts: DefaultRetryMaxAttempts,
+				RetryWaitMin:     DefaultRetryWaitMin,
+				RetryWaitMax:     DefaultRetryWaitMax,
+				profileReason:    "set by the SFX_PROFILE environment variable",
+				profileLoaded:    true,
+			},
+			errVal: "",
+		},
+		{
+			name: "invalid environment values",
+			env: map[string]string{
+				TimeoutSecondsEnvVar: "two minutes",
+				FeaturePreviewEnvVar: "provider.tags=maybe",
+			},
+			config: Config{},
+			expect: nil,
+			errVal: "environment variable SFX_TIMEOUT_SECONDS: strconv.Atoi: parsing \"two minutes\": invalid syntax\n" +
+				"environment variable SFX_FEATURE_PREVIEW: preview \"provider.tags\": strconv.ParseBool: parsing \"maybe\": invalid syntax",
+		},
+		{
+			name: "config certificate authority replaces environment",
+			env: map[string]string{
+				ProxyURLEnvVar:           "http://proxy.example.com:3128",
+				CACertFileEnvVar:         "/etc/ssl/proxy.pem",
+				InsecureSkipVerifyEnvVar: "true",
+			},
+			config: Config{CACertPEM: ptr("-----BEGIN CERTIFICATE-----")},
+			expect: defaults(Meta{
+				ProxyURL:           "http://proxy.example.com:3128",
+				CACertPEM:          "-----BEGIN CERTIFICATE-----",
+				InsecureSkipVerify: true,
+			}),
+			errVal: "",
+		},
+		{
+			name: "environment sets both certificate authorities",
+			env: map[string]string{
+				CACertFileEnvVar: "/etc/ssl/proxy.pem",
+				CACertPEMEnvVar:  "-----BEGIN CERTIFICATE-----",
+			},
+			config: Config{},
+			expect: nil,
+			errVal: "ca_cert_file and ca_cert_pem can not both be set",
+		},
+		{
+			name: "invalid insecure skip verify",
+			env: map[string]string{
+				InsecureSkipVerifyEnvVar: "sometimes",
+			},
+			config: Config{},
+			expect: nil,
+			errVal: "environment variable SFX_INSECURE_SKIP_VERIFY: strconv.ParseBool: parsing \"sometimes\": invalid syntax",
+		},
+		{
+			name:   "invalid realm",
+			config: Config{Realm: ptr("mars0")},
+			expect: nil,
+			errVal: "realm \"mars0\" is not one of: au0, eu0, eu1, eu2, jp0, sg0, us0, us1, us2",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			netrc, file := path.Join(dir, ".netrc"), path.Join(dir, ".signalfx.conf")
+			if tc.netrc != "" {
+				require.NoError(t, os.WriteFile(netrc, []byte(tc.netrc), 0o600), "Must not error writing netrc")
+			}
+			if tc.file != "" {
+				require.NoError(t, os.WriteFile(file, []byte(tc.file), 0o600), "Must not error writing file")
+			}
+
+			actual, err := NewResolver(
+				WithResolverLookups(NetrcMetaLookupFunc(netrc), FileMetaLookupFunc(file)),
+				WithResolverEnvironment(tc.env),
+			).Resolve(context.Background(), tc.config)
+			if tc.errVal != "" {
+				require.EqualError(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				require.NoError(t, err, "Must not error resolving configuration")
+			}
+			assert.Equal(t, tc.expect, actual, "Must match the expected configuration")
+		})
+	}
+}
+
+func TestResolverEnvironment(t *testing.T) {
+	t.Parallel()
+
+	ptr := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+
+	actual, err := NewResolver(WithResolverEnvironment(map[string]string{
+		AuthTokenEnvVar:        "token",
+		APIURLEnvVar:           "",
+		EmailEnvVar:            "example@example.com",
+		RetryMaxAttemptsEnvVar: "0",
+		TagsEnvVar:             " , ",
+	})).Environment()
+	require.NoError(t, err, "Must not error reading environment")
+	assert.Equal(t, Config{
+		AuthToken:        ptr("token"),
+		Email:            ptr("example@example.com"),
+		RetryMaxAttempts: num(0),
+	}, actual, "Must only set the values from the environment")
+}
+
+func TestMetaFinalize(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		meta    *Meta
+		errVal  string
+		preview bool
+		client  bool
+	}{
+		{
+			name:   "invalid settings",
+			meta:   &Meta{APIURL: DefaultAPIURL},
+			errVal: "missing auth token or email and password",
+		},
+		{
+			name:    "unknown preview",
+			meta:    &Meta{APIURL: DefaultAPIURL, AuthToken: "token", FeaturePreview: map[string]bool{"unknown.preview": true}},
+			preview: true,
+		},
+		{
+			name:   "configured",
+			meta:   &Meta{APIURL: "https://api.jp0.observability.splunkcloud.com", CustomAppURL: "https://app.jp0.observability.splunkcloud.com", Realm: "jp0", AuthToken: "token"},
+			client: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags, err := tc.meta.Finalize(context.Background(), "test", tfext.NewHTTPLogger("signalfx"))
+			if tc.errVal != "" {
+				require.EqualError(t, err, tc.errVal, "Must match the expected error")
+			} else {
+				require.NoError(t, err, "Must not error finalizing the configuration")
+			}
+			assert.Equal(t, tc.preview, diags.HasError(), "Must report the preview issues")
+			assert.Equal(t, tc.client, tc.meta.HTTPClient != nil, "Must only create the clients once the settings are valid")
+			if tc.client {
+				assert.Equal(t, "https://app.jp0.observability.splunkcloud.com", tc.meta.CustomAppURL, "Must keep the app url derived from the realm")
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package ratelimit provides a client side limiter for the requests
+// made to the Splunk Observability Cloud API so that large applies
+// slow down before the API starts to reject requests.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket that limits the rate and the number of concurrent
+// requests made using the wrapped transport.
+//
+// The rate is halved each time the API responds with `429 Too Many Requests`,
+// and recovers towards the configured rate with each successful response.
+// All requests are paused until the time set by the `Retry-After` header,
+// or by the `X-RateLimit-Reset` header once `X-RateLimit-Remaining` reaches zero.
+type Limiter struct {
+	limit float64
+	slots chan struct{}
+
+	mu          sync.Mutex
+	rate        float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	summary     Summary
+}
+
+type Option func(l *Limiter)
+
+// WithRequestsPerSecond limits the rate of requests,
+// a value of zero or less does not limit the rate.
+func WithRequestsPerSecond(rps float64) Option {
+	return func(l *Limiter) {
+		l.limit = max(rps, 0)
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests in flight,
+// a value of zero or less does not limit the concurrency.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(l *Limiter) {
+		l.slots = nil
+		if n > 0 {
+			l.slots = make(chan struct{}, n)
+		}
+	}
+}
+
+// New returns a limiter that is added to the run [Summary].
+func New(opts ...Option) *Limiter {
+	l := &Limiter{}
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.rate = l.limit
+	l.tokens = l.burst()
+	l.last = time.Now()
+	l.summary.MinRate = l.limit
+
+	register(l)
+	return l
+}
+
+var (
+	sharedMu sync.Mutex
+	shared   = make(map[string]*Limiter)
+)
+
+// Shared returns the limiter stored using key, creating it with opts the first time.
+//
+// The provider is served as several muxed providers within the same process,
+// so each of them uses the same limiter when they are configured the same way
+// rather than each one allowing the configured rate.
+func Shared(key string, opts ...Option) *Limiter {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if l, ok := shared[key]; ok {
+		return l
+	}
+	l := New(opts...)
+	shared[key] = l
+	return l
+}
+
+// Wrap returns a round tripper that waits for the limiter before calling next.
+func (l *Limiter) Wrap(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		release, err := l.acquire(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		resp, err := next.RoundTrip(req)
+		if err == nil {
+			l.observe(resp)
+		}
+		return resp, err
+	})
+}
+
+// Summary returns the throttling details of the requests made so far.
+func (l *Limiter) Summary() Summary {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.summary
+}
+
+// burst allows up to a second of requests to be made at once.
+func (l *Limiter) burst() float64 {
+	return max(l.limit, 1)
+}
+
+func (l *Limiter) acquire(ctx context.Context) (release func(), err error) {
+	start := time.Now()
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release = func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	for {
+		delay := l.reserve(time.Now())
+		if delay <= 0 {
+			break
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.summary.Requests++
+	if waited := time.Since(start); waited > time.Millisecond {
+		l.summary.Delayed++
+		l.summary.Waited += waited
+	}
+	return release, nil
+}
+
+// reserve takes a token from the bucket if one is available,
+// otherwise it returns how long to wait before trying again.
+func (l *Limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.tokens = min(l.burst(), l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// observe adjusts the rate using the response status and headers.
+func (l *Limiter) observe(resp *http.Response) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		l.summary.Throttled++
+		if l.limit > 0 {
+			l.rate = max(l.rate/2, math.Min(l.limit, 1))
+			l.summary.MinRate = min(l.summary.MinRate, l.rate)
+		}
+	} else if resp.StatusCode < http.StatusBadRequest && l.rate < l.limit {
+		l.rate = min(l.rate+l.limit/10, l.limit)
+	}
+
+	if d := RetryAfter(resp, now); d > 0 {
+		if until := now.Add(d); until.After(l.pausedUntil) {
+			l.summary.Paused += until.Sub(maxTime(now, l.pausedUntil))
+			l.pausedUntil = until
+		}
+	}
+}
+
+// RetryAfter returns how long the API has asked for requests to be paused,
+// read from the `Retry-After` header on a throttled response, or the
+// `X-RateLimit-Reset` header when `X-RateLimit-Remaining` reaches zero.
+// The values are either a number of seconds, an HTTP date for `Retry-After`,
+// or a unix timestamp in seconds for `X-RateLimit-Reset`.
+func RetryAfter(resp *http.Response, now time.Time) time.Duration {
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		if v := resp.Header.Get("Retry-After"); v != "" {
+			if s, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return max(time.Duration(s)*time.Second, 0)
+			}
+			if t, err := http.ParseTime(v); err == nil {
+				return max(t.Sub(now), 0)
+			}
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || reset <= 0 {
+		return 0
+	}
+	// Values that are larger than a day are considered to be a timestamp.
+	if reset > int64((24 * time.Hour).Seconds()) {
+		return max(time.Unix(reset, 0).Sub(now), 0)
+	}
+	return time.Duration(reset) * time.Second
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
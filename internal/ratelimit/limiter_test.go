@@ -0,0 +1,243 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doRequests(t *testing.T, client *http.Client, url string, n int) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	for range n {
+		wg.Go(func() {
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, http.NoBody)
+			if !assert.NoError(t, err, "Must create request") {
+				return
+			}
+			resp, err := client.Do(req)
+			if assert.NoError(t, err, "Must complete request") {
+				_ = resp.Body.Close()
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestLimiterRequestsPerSecond(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+
+	l := New(WithRequestsPerSecond(20))
+	client := &http.Client{Transport: l.Wrap(s.Client().Transport)}
+
+	start := time.Now()
+	doRequests(t, client, s.URL, 30)
+
+	// The first 20 requests use the burst,
+	// the remaining 10 are spread over half a second.
+	assert.GreaterOrEqual(t, time.Since(start), 450*time.Millisecond, "Must limit the rate of requests")
+
+	summary := l.Summary()
+	assert.Equal(t, 30, summary.Requests, "Must count each request")
+	assert.Positive(t, summary.Delayed, "Must count the delayed requests")
+	assert.Positive(t, summary.Waited, "Must record the time waited")
+	assert.Zero(t, summary.Throttled, "Must not have any throttled requests")
+}
+
+func TestShared(t *testing.T) {
+	t.Parallel()
+
+	a := Shared(t.Name()+"/a", WithRequestsPerSecond(10))
+	assert.Same(t, a, Shared(t.Name()+"/a", WithRequestsPerSecond(20)), "Must return the existing limiter")
+	assert.NotSame(t, a, Shared(t.Name()+"/b", WithRequestsPerSecond(10)), "Must create a limiter for each key")
+	assert.Equal(t, float64(10), a.limit, "Must keep the options of the first limiter")
+}
+
+func TestLimiterMaxConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var inflight, peak atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+
+	l := New(WithMaxConcurrentRequests(2))
+	doRequests(t, &http.Client{Transport: l.Wrap(s.Client().Transport)}, s.URL, 10)
+
+	assert.LessOrEqual(t, peak.Load(), int32(2), "Must not exceed the max concurrent requests")
+	assert.Equal(t, 10, l.Summary().Requests, "Must count each request")
+}
+
+func TestLimiterRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+
+	l := New(WithRequestsPerSecond(10))
+	client := &http.Client{Transport: l.Wrap(s.Client().Transport)}
+
+	doRequests(t, client, s.URL, 1)
+	assert.Equal(t, 5.0, l.Summary().MinRate, "Must halve the rate once throttled")
+
+	start := time.Now()
+	doRequests(t, client, s.URL, 1)
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond, "Must pause until the retry after has passed")
+
+	summary := l.Summary()
+	assert.Equal(t, 2, summary.Requests, "Must count each request")
+	assert.Equal(t, 1, summary.Throttled, "Must count the throttled response")
+	assert.Equal(t, time.Second, summary.Paused.Round(100*time.Millisecond), "Must record the paused time")
+	assert.Equal(t, 6.0, l.rate, "Must recover the rate after a successful response")
+}
+
+func TestLimiterContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	l := New(WithMaxConcurrentRequests(1))
+	l.pausedUntil = time.Now().Add(time.Hour)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost", http.NoBody)
+	require.NoError(t, err, "Must create request")
+
+	_, err = l.Wrap(nil).RoundTrip(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded, "Must return the context error")
+	assert.Empty(t, l.slots, "Must release the concurrent request slot")
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name    string
+		status  int
+		headers map[string]string
+		expect  time.Duration
+	}{
+		{
+			name:    "no headers",
+			status:  http.StatusTooManyRequests,
+			headers: map[string]string{},
+			expect:  0,
+		},
+		{
+			name:    "retry after seconds",
+			status:  http.StatusTooManyRequests,
+			headers: map[string]string{"Retry-After": "30"},
+			expect:  30 * time.Second,
+		},
+		{
+			name:    "retry after date",
+			status:  http.StatusServiceUnavailable,
+			headers: map[string]string{"Retry-After": now.Add(time.Minute).Format(http.TimeFormat)},
+			expect:  time.Minute,
+		},
+		{
+			name:    "retry after ignored on success",
+			status:  http.StatusOK,
+			headers: map[string]string{"Retry-After": "30"},
+			expect:  0,
+		},
+		{
+			name:    "invalid retry after",
+			status:  http.StatusTooManyRequests,
+			headers: map[string]string{"Retry-After": "soon"},
+			expect:  0,
+		},
+		{
+			name:   "rate limit reset seconds",
+			status: http.StatusOK,
+			headers: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "5",
+			},
+			expect: 5 * time.Second,
+		},
+		{
+			name:   "rate limit reset timestamp",
+			status: http.StatusOK,
+			headers: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "1704067210",
+			},
+			expect: 10 * time.Second,
+		},
+		{
+			name:   "rate limit remaining",
+			status: http.StatusOK,
+			headers: map[string]string{
+				"X-RateLimit-Remaining": "10",
+				"X-RateLimit-Reset":     "5",
+			},
+			expect: 0,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &http.Response{StatusCode: tc.status, Header: make(http.Header)}
+			for k, v := range tc.headers {
+				resp.Header.Set(k, v)
+			}
+			assert.Equal(t, tc.expect, RetryAfter(resp, now), "Must match the expected duration")
+		})
+	}
+}
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	s := Summary{Requests: 2, MinRate: 0}.add(Summary{
+		Requests:  3,
+		Delayed:   1,
+		Throttled: 1,
+		Waited:    1500 * time.Millisecond,
+		Paused:    time.Second,
+		MinRate:   2.5,
+	})
+	assert.Equal(t,
+		"5 requests, 1 delayed for 1.5s, 1 throttled by the API, paused for 1s, lowest rate 2.50 requests per second",
+		s.String(),
+		"Must match the expected summary",
+	)
+	assert.Contains(t, Summary{}.String(), "lowest rate unlimited", "Must report an unlimited rate")
+}
@@ -0,0 +1,119 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
+)
+
+// Summary holds the throttling details of the requests made by a [Limiter].
+type Summary struct {
+	// Requests is the number of requests made.
+	Requests int
+	// Delayed is the number of requests that waited for the limiter.
+	Delayed int
+	// Throttled is the number of responses with `429 Too Many Requests`.
+	Throttled int
+	// Waited is the total time requests spent waiting for the limiter.
+	Waited time.Duration
+	// Paused is the total time requests were paused by the API headers.
+	Paused time.Duration
+	// MinRate is the lowest requests per second used,
+	// zero when the rate is not limited.
+	MinRate float64
+}
+
+func (s Summary) add(o Summary) Summary {
+	s.Requests += o.Requests
+	s.Delayed += o.Delayed
+	s.Throttled += o.Throttled
+	s.Waited += o.Waited
+	s.Paused += o.Paused
+	if s.MinRate == 0 || (o.MinRate > 0 && o.MinRate < s.MinRate) {
+		s.MinRate = o.MinRate
+	}
+	return s
+}
+
+func (s Summary) String() string {
+	rate := "unlimited"
+	if s.MinRate > 0 {
+		rate = fmt.Sprintf("%.2f requests per second", s.MinRate)
+	}
+	return fmt.Sprintf(
+		"%d requests, %d delayed for %s, %d throttled by the API, paused for %s, lowest rate %s",
+		s.Requests,
+		s.Delayed,
+		s.Waited.Round(time.Millisecond),
+		s.Throttled,
+		s.Paused.Round(time.Millisecond),
+		rate,
+	)
+}
+
+var (
+	mu       sync.Mutex
+	limiters []*Limiter
+)
+
+func register(l *Limiter) {
+	mu.Lock()
+	defer mu.Unlock()
+	limiters = append(limiters, l)
+}
+
+// RunSummary returns the combined [Summary] of every limiter created.
+func RunSummary() Summary {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var s Summary
+	for _, l := range limiters {
+		s = s.add(l.Summary())
+	}
+	return s
+}
+
+// LogSummary logs the [RunSummary] using the logger set within ctx,
+// nothing is logged if no requests were made.
+func LogSummary(ctx context.Context) {
+	s := RunSummary()
+	if s.Requests == 0 {
+		return
+	}
+	tflog.Info(ctx, "API rate limiter summary", tfext.NewLogFields().
+		Field("requests", s.Requests).
+		Field("delayed", s.Delayed).
+		Duration("waited", s.Waited).
+		Field("throttled", s.Throttled).
+		Duration("paused", s.Paused).
+		Field("min_rate", s.MinRate).
+		Field("summary", s.String()),
+	)
+}
+
+// WithSummaryOnStop returns the provider server that logs the [RunSummary]
+// once Terraform stops the provider, while the provider output is still captured.
+func WithSummaryOnStop(server func() tfprotov5.ProviderServer) func() tfprotov5.ProviderServer {
+	return func() tfprotov5.ProviderServer {
+		return &summaryServer{ProviderServer: server()}
+	}
+}
+
+type summaryServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s *summaryServer) StopProvider(ctx context.Context, req *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error) {
+	LogSummary(ctx)
+	return s.ProviderServer.StopProvider(ctx, req)
+}
@@ -0,0 +1,53 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stopServer struct {
+	tfprotov5.ProviderServer
+
+	stopped bool
+}
+
+func (s *stopServer) StopProvider(context.Context, *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error) {
+	s.stopped = true
+	return &tfprotov5.StopProviderResponse{}, nil
+}
+
+func TestWithSummaryOnStop(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+
+	doRequests(t, &http.Client{Transport: New(WithRequestsPerSecond(100)).Wrap(s.Client().Transport)}, s.URL, 1)
+
+	var (
+		buf  bytes.Buffer
+		next = &stopServer{}
+	)
+	server := WithSummaryOnStop(func() tfprotov5.ProviderServer { return next })()
+
+	_, err := server.StopProvider(tflogtest.RootLogger(t.Context(), &buf), &tfprotov5.StopProviderRequest{})
+	require.NoError(t, err, "Must stop the provider")
+	assert.True(t, next.stopped, "Must stop the wrapped provider")
+
+	entries, err := tflogtest.MultilineJSONDecode(&buf)
+	require.NoError(t, err, "Must decode the log entries")
+	require.Len(t, entries, 1, "Must log the summary once")
+	assert.Equal(t, "API rate limiter summary", entries[0]["@message"], "Must log the summary")
+	assert.Positive(t, entries[0]["requests"], "Must include the requests made")
+}
@@ -0,0 +1,288 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+// Node is any element of the parsed program.
+type Node interface {
+	Pos() Pos
+}
+
+// Stmt is a single statement within the program.
+type Stmt interface {
+	Node
+	stmtNode()
+}
+
+// Expr is any expression that results in a value.
+type Expr interface {
+	Node
+	exprNode()
+}
+
+// Program is the root of the parsed program text.
+type Program struct {
+	Body []Stmt
+}
+
+type (
+	// ExprStmt is an expression used as a statement,
+	// for example `detect(when(A > 10)).publish('alert')`
+	ExprStmt struct {
+		X Expr
+	}
+
+	// AssignStmt covers both plain and augmented assignments,
+	// chained assignments have more than one target.
+	AssignStmt struct {
+		At      Pos
+		Targets []Expr
+		Op      string
+		Value   Expr
+	}
+
+	// FuncDef is a user defined function.
+	FuncDef struct {
+		At     Pos
+		Name   *Ident
+		Params []*Param
+		Body   []Stmt
+	}
+
+	ReturnStmt struct {
+		At    Pos
+		Value Expr
+	}
+
+	// IfStmt holds `elif` branches as a nested IfStmt within Else.
+	IfStmt struct {
+		At   Pos
+		Cond Expr
+		Body []Stmt
+		Else []Stmt
+	}
+
+	ForStmt struct {
+		At     Pos
+		Target Expr
+		Iter   Expr
+		Body   []Stmt
+	}
+
+	PassStmt struct {
+		At Pos
+	}
+
+	// ImportStmt covers both `import a.b as c`
+	// and `from a.b import c as d` statements.
+	ImportStmt struct {
+		At     Pos
+		Module string
+		Names  []*ImportName
+	}
+)
+
+type (
+	Ident struct {
+		At   Pos
+		Name string
+	}
+
+	NumberLit struct {
+		At    Pos
+		Value string
+	}
+
+	// StringLit holds the decoded value of the string,
+	// adjacent string literals are joined together.
+	StringLit struct {
+		At    Pos
+		Value string
+	}
+
+	// ConstLit is one of `None`, `True`, or `False`.
+	ConstLit struct {
+		At    Pos
+		Value string
+	}
+
+	ListLit struct {
+		At   Pos
+		Elts []Expr
+	}
+
+	TupleLit struct {
+		At   Pos
+		Elts []Expr
+	}
+
+	DictLit struct {
+		At     Pos
+		Keys   []Expr
+		Values []Expr
+	}
+
+	UnaryExpr struct {
+		At Pos
+		Op string
+		X  Expr
+	}
+
+	// BinaryExpr covers arithmetic, comparison, and boolean operators.
+	BinaryExpr struct {
+		At Pos
+		Op string
+		X  Expr
+		Y  Expr
+	}
+
+	// CondExpr is the inline `Body if Cond else Else` expression.
+	CondExpr struct {
+		At   Pos
+		Body Expr
+		Cond Expr
+		Else Expr
+	}
+
+	LambdaExpr struct {
+		At     Pos
+		Params []*Param
+		Body   Expr
+	}
+
+	CallExpr struct {
+		At       Pos
+		Func     Expr
+		Args     []Expr
+		Keywords []*Keyword
+	}
+
+	AttrExpr struct {
+		At   Pos
+		X    Expr
+		Name *Ident
+	}
+
+	IndexExpr struct {
+		At    Pos
+		X     Expr
+		Index Expr
+	}
+
+	// SliceExpr is only valid as the index of an IndexExpr,
+	// any of the values can be nil when omitted.
+	SliceExpr struct {
+		At   Pos
+		Low  Expr
+		High Expr
+		Step Expr
+	}
+
+	// StarExpr is an unpacked argument, such as `*args` or `**kwargs`.
+	StarExpr struct {
+		At Pos
+		Op string
+		X  Expr
+	}
+
+	// CompExpr is a list comprehension.
+	CompExpr struct {
+		At    Pos
+		Elt   Expr
+		Loops []*CompLoop
+	}
+)
+
+type (
+	Param struct {
+		Name    *Ident
+		Default Expr
+	}
+
+	Keyword struct {
+		Name  *Ident
+		Value Expr
+	}
+
+	// ImportName is the imported path and the optional alias it is bound to.
+	ImportName struct {
+		Path  string
+		Alias *Ident
+		At    Pos
+	}
+
+	CompLoop struct {
+		Target Expr
+		Iter   Expr
+		Ifs    []Expr
+	}
+)
+
+// Bound returns the name that the import is made available as.
+func (in *ImportName) Bound() string {
+	if in.Alias != nil {
+		return in.Alias.Name
+	}
+	for i := 0; i < len(in.Path); i++ {
+		if in.Path[i] == '.' {
+			return in.Path[:i]
+		}
+	}
+	return in.Path
+}
+
+func (p *Program) Pos() Pos { return Pos{Line: 1, Column: 1} }
+
+func (s *ExprStmt) Pos() Pos   { return s.X.Pos() }
+func (s *AssignStmt) Pos() Pos { return s.At }
+func (s *FuncDef) Pos() Pos    { return s.At }
+func (s *ReturnStmt) Pos() Pos { return s.At }
+func (s *IfStmt) Pos() Pos     { return s.At }
+func (s *ForStmt) Pos() Pos    { return s.At }
+func (s *PassStmt) Pos() Pos   { return s.At }
+func (s *ImportStmt) Pos() Pos { return s.At }
+
+func (*ExprStmt) stmtNode()   {}
+func (*AssignStmt) stmtNode() {}
+func (*FuncDef) stmtNode()    {}
+func (*ReturnStmt) stmtNode() {}
+func (*IfStmt) stmtNode()     {}
+func (*ForStmt) stmtNode()    {}
+func (*PassStmt) stmtNode()   {}
+func (*ImportStmt) stmtNode() {}
+
+func (e *Ident) Pos() Pos      { return e.At }
+func (e *NumberLit) Pos() Pos  { return e.At }
+func (e *StringLit) Pos() Pos  { return e.At }
+func (e *ConstLit) Pos() Pos   { return e.At }
+func (e *ListLit) Pos() Pos    { return e.At }
+func (e *TupleLit) Pos() Pos   { return e.At }
+func (e *DictLit) Pos() Pos    { return e.At }
+func (e *UnaryExpr) Pos() Pos  { return e.At }
+func (e *BinaryExpr) Pos() Pos { return e.At }
+func (e *CondExpr) Pos() Pos   { return e.At }
+func (e *LambdaExpr) Pos() Pos { return e.At }
+func (e *CallExpr) Pos() Pos   { return e.At }
+func (e *AttrExpr) Pos() Pos   { return e.At }
+func (e *IndexExpr) Pos() Pos  { return e.At }
+func (e *SliceExpr) Pos() Pos  { return e.At }
+func (e *StarExpr) Pos() Pos   { return e.At }
+func (e *CompExpr) Pos() Pos   { return e.At }
+
+func (*Ident) exprNode()      {}
+func (*NumberLit) exprNode()  {}
+func (*StringLit) exprNode()  {}
+func (*ConstLit) exprNode()   {}
+func (*ListLit) exprNode()    {}
+func (*TupleLit) exprNode()   {}
+func (*DictLit) exprNode()    {}
+func (*UnaryExpr) exprNode()  {}
+func (*BinaryExpr) exprNode() {}
+func (*CondExpr) exprNode()   {}
+func (*LambdaExpr) exprNode() {}
+func (*CallExpr) exprNode()   {}
+func (*AttrExpr) exprNode()   {}
+func (*IndexExpr) exprNode()  {}
+func (*SliceExpr) exprNode()  {}
+func (*StarExpr) exprNode()   {}
+func (*CompExpr) exprNode()   {}
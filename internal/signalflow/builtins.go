@@ -0,0 +1,92 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+// builtins are the functions that are available to every program
+// without needing to be defined or imported.
+var builtins = map[string]struct{}{
+	// Stream functions
+	"abs":              {},
+	"alerts":           {},
+	"bottom":           {},
+	"ceil":             {},
+	"combine":          {},
+	"const":            {},
+	"count":            {},
+	"data":             {},
+	"delta":            {},
+	"detect":           {},
+	"dimensions":       {},
+	"double_ewma":      {},
+	"duration":         {},
+	"ewma":             {},
+	"events":           {},
+	"exp":              {},
+	"field":            {},
+	"fill":             {},
+	"filter":           {},
+	"floor":            {},
+	"graphite":         {},
+	"histogram":        {},
+	"integrate":        {},
+	"kpss":             {},
+	"log":              {},
+	"log10":            {},
+	"logs":             {},
+	"map":              {},
+	"max":              {},
+	"mean":             {},
+	"mean_plus_stddev": {},
+	"median":           {},
+	"min":              {},
+	"newrelic":         {},
+	"partition_filter": {},
+	"percentile":       {},
+	"pow":              {},
+	"print":            {},
+	"random":           {},
+	"rateofchange":     {},
+	"sample_stddev":    {},
+	"sample_variance":  {},
+	"size":             {},
+	"sqrt":             {},
+	"stddev":           {},
+	"sum":              {},
+	"threshold":        {},
+	"timeshift":        {},
+	"top":              {},
+	"union":            {},
+	"variance":         {},
+	"when":             {},
+
+	// Python builtins supported within SignalFlow
+	"all":        {},
+	"any":        {},
+	"bool":       {},
+	"dict":       {},
+	"enumerate":  {},
+	"float":      {},
+	"getattr":    {},
+	"hasattr":    {},
+	"int":        {},
+	"isinstance": {},
+	"len":        {},
+	"list":       {},
+	"range":      {},
+	"repr":       {},
+	"reversed":   {},
+	"round":      {},
+	"set":        {},
+	"sorted":     {},
+	"str":        {},
+	"tuple":      {},
+	"type":       {},
+	"zip":        {},
+}
+
+// IsBuiltin reports if the name is a known SignalFlow function.
+func IsBuiltin(name string) bool {
+	_, ok := builtins[name]
+	return ok
+}
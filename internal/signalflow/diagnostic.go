@@ -0,0 +1,104 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pos is the location within the program text,
+// both line and column start from 1.
+type Pos struct {
+	Line   int
+	Column int
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("line %d, column %d", p.Line, p.Column)
+}
+
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "unknown"
+}
+
+// Diagnostic is a single issue found within the program text.
+type Diagnostic struct {
+	Pos      Pos
+	Severity Severity
+	Message  string
+}
+
+var _ error = (*Diagnostic)(nil)
+
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("%s: %s", d.Pos, d.Message)
+}
+
+// Diagnostics is the collection of issues found within program text.
+type Diagnostics []*Diagnostic
+
+var _ error = (Diagnostics)(nil)
+
+func (ds Diagnostics) Error() string {
+	var sb strings.Builder
+	for i, d := range ds {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(d.Error())
+	}
+	return sb.String()
+}
+
+// Unwrap allows each diagnostic to be reported separately.
+func (ds Diagnostics) Unwrap() []error {
+	errs := make([]error, 0, len(ds))
+	for _, d := range ds {
+		errs = append(errs, d)
+	}
+	return errs
+}
+
+// HasError returns true when any of the diagnostics are errors.
+func (ds Diagnostics) HasError() bool {
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns only the diagnostics that are errors.
+func (ds Diagnostics) Errors() Diagnostics {
+	var errs Diagnostics
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			errs = append(errs, d)
+		}
+	}
+	return errs
+}
+
+func newErrorf(pos Pos, format string, args ...any) *Diagnostic {
+	return &Diagnostic{Pos: pos, Severity: SeverityError, Message: fmt.Sprintf(format, args...)}
+}
+
+func newWarningf(pos Pos, format string, args ...any) *Diagnostic {
+	return &Diagnostic{Pos: pos, Severity: SeverityWarning, Message: fmt.Sprintf(format, args...)}
+}
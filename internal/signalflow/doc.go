@@ -0,0 +1,12 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package signalflow provides an offline lexer, parser, and linter
+// for SignalFlow program text so that common mistakes can be reported
+// during validation without needing to make a call to the API.
+//
+// The supported grammar is the python like subset that SignalFlow
+// programs are written in, the linter is intentionally conservative
+// so that valid programs are never rejected because of a function
+// that is not yet known to the provider.
+package signalflow
@@ -0,0 +1,65 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+// PublishedLabels returns the unique labels set by `publish` within the program
+// in the order they are first seen.
+//
+// The returned value complete is false whenever a label can not be determined offline,
+// for example when it is computed, omitted, or when imported modules are used, since
+// those could publish streams on behalf of the program.
+func PublishedLabels(prog *Program) (labels []string, complete bool) {
+	complete = true
+	seen := make(map[string]struct{})
+
+	Inspect(prog, func(n Node) bool {
+		switch v := n.(type) {
+		case *ImportStmt:
+			complete = false
+		case *CallExpr:
+			attr, ok := v.Func.(*AttrExpr)
+			if !ok || attr.Name.Name != "publish" {
+				return true
+			}
+			label, ok := publishLabel(v)
+			if !ok {
+				complete = false
+				return true
+			}
+			if _, exist := seen[label]; !exist {
+				seen[label] = struct{}{}
+				labels = append(labels, label)
+			}
+		}
+		return true
+	})
+
+	return labels, complete
+}
+
+// ProgramLabels parses the program text and returns its published labels,
+// complete is false when the program fails to parse.
+func ProgramLabels(program string) (labels []string, complete bool) {
+	prog, err := Parse(program)
+	if err != nil {
+		return nil, false
+	}
+	return PublishedLabels(prog)
+}
+
+func publishLabel(call *CallExpr) (string, bool) {
+	var value Expr
+	for _, kw := range call.Keywords {
+		if kw.Name.Name == "label" {
+			value = kw.Value
+		}
+	}
+	if value == nil && len(call.Args) > 0 {
+		value = call.Args[0]
+	}
+	if s, ok := value.(*StringLit); ok {
+		return s.Value, true
+	}
+	return "", false
+}
@@ -0,0 +1,72 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgramLabels(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		program  string
+		labels   []string
+		complete bool
+	}{
+		{
+			name:     "no published streams",
+			program:  "A = data('cpu')",
+			labels:   nil,
+			complete: true,
+		},
+		{
+			name:     "positional and keyword labels",
+			program:  "A = data('cpu').publish(label='A')\ndetect(when(A > 10)).publish('CPU high')\ndetect(when(A > 90)).publish('CPU high', enable=False)",
+			labels:   []string{"A", "CPU high"},
+			complete: true,
+		},
+		{
+			name:     "unlabelled publish",
+			program:  "A = data('cpu').publish(label='A')\ndata('mem').publish()",
+			labels:   []string{"A"},
+			complete: false,
+		},
+		{
+			name:     "computed label",
+			program:  "name = 'cpu'\ndata(name).publish(label=name)",
+			labels:   nil,
+			complete: false,
+		},
+		{
+			name:     "published within function",
+			program:  "def f(s):\n    return s.publish('inner')\nf(data('cpu'))",
+			labels:   []string{"inner"},
+			complete: true,
+		},
+		{
+			name:     "imported modules",
+			program:  "from signalfx.detectors.against_recent import against_recent\nagainst_recent.detector_mean_std(data('cpu')).publish('anomaly')",
+			labels:   []string{"anomaly"},
+			complete: false,
+		},
+		{
+			name:     "invalid program",
+			program:  "data('cpu'.publish('A')",
+			labels:   nil,
+			complete: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			labels, complete := ProgramLabels(tc.program)
+			assert.Equal(t, tc.labels, labels, "Must match the expected labels")
+			assert.Equal(t, tc.complete, complete, "Must match the expected completeness")
+		})
+	}
+}
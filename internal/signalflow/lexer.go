@@ -0,0 +1,391 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import (
+	"strings"
+	"unicode"
+)
+
+type lexer struct {
+	src  []rune
+	off  int
+	line int
+	col  int
+
+	tokens   []Token
+	indents  []int
+	brackets []Token
+}
+
+// Lex converts the program text into a list of tokens,
+// any lexical issue is returned as a diagnostic with its position.
+func Lex(program string) ([]Token, *Diagnostic) {
+	l := &lexer{
+		src:  []rune(program),
+		line: 1,
+		col:  1,
+	}
+	if err := l.run(); err != nil {
+		return nil, err
+	}
+	return l.tokens, nil
+}
+
+func (l *lexer) pos() Pos {
+	return Pos{Line: l.line, Column: l.col}
+}
+
+func (l *lexer) peek(n int) rune {
+	if l.off+n >= len(l.src) {
+		return 0
+	}
+	return l.src[l.off+n]
+}
+
+func (l *lexer) eof() bool {
+	return l.off >= len(l.src)
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.off]
+	l.off++
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+func (l *lexer) emit(kind TokenKind, value string, pos Pos) {
+	l.tokens = append(l.tokens, Token{Kind: kind, Value: value, Raw: value, Pos: pos})
+}
+
+func (l *lexer) lastKind() TokenKind {
+	if len(l.tokens) == 0 {
+		return TokenNewline
+	}
+	return l.tokens[len(l.tokens)-1].Kind
+}
+
+func (l *lexer) run() *Diagnostic {
+	atLineStart := true
+	for !l.eof() {
+		if atLineStart && len(l.brackets) == 0 {
+			if err := l.indentation(); err != nil {
+				return err
+			}
+			atLineStart = false
+			continue
+		}
+
+		switch r := l.peek(0); {
+		case r == '\n':
+			pos := l.pos()
+			l.advance()
+			if len(l.brackets) == 0 {
+				if l.lastKind() != TokenNewline {
+					l.emit(TokenNewline, "", pos)
+				}
+				atLineStart = true
+			}
+		case r == ' ' || r == '\t' || r == '\r' || r == '\f':
+			l.advance()
+		case r == '#':
+			for !l.eof() && l.peek(0) != '\n' {
+				l.advance()
+			}
+		case r == '\\':
+			pos := l.pos()
+			l.advance()
+			if l.peek(0) == '\r' {
+				l.advance()
+			}
+			if l.peek(0) != '\n' {
+				return newErrorf(pos, "unexpected character after line continuation")
+			}
+			l.advance()
+		case r == '\'' || r == '"':
+			if err := l.string(l.pos(), ""); err != nil {
+				return err
+			}
+		case isDigit(r) || (r == '.' && isDigit(l.peek(1))):
+			if err := l.number(); err != nil {
+				return err
+			}
+		case isNameStart(r):
+			if err := l.name(); err != nil {
+				return err
+			}
+		default:
+			if err := l.operator(); err != nil {
+				return err
+			}
+		}
+	}
+
+	pos := l.pos()
+	if n := len(l.brackets); n > 0 {
+		open := l.brackets[n-1]
+		return newErrorf(open.Pos, "%q was never closed", open.Value)
+	}
+	if l.lastKind() != TokenNewline {
+		l.emit(TokenNewline, "", pos)
+	}
+	for len(l.indents) > 1 {
+		l.indents = l.indents[:len(l.indents)-1]
+		l.emit(TokenDedent, "", pos)
+	}
+	l.emit(TokenEOF, "", pos)
+	return nil
+}
+
+// indentation reads the leading whitespace of a line and
+// emits the indent and dedent tokens, blank lines and lines
+// with only comments are ignored.
+func (l *lexer) indentation() *Diagnostic {
+	width := 0
+	for !l.eof() {
+		switch l.peek(0) {
+		case ' ':
+			width++
+		case '\t':
+			width += 8 - (width % 8)
+		case '\f', '\r':
+		default:
+			goto measured
+		}
+		l.advance()
+	}
+measured:
+	if l.eof() || l.peek(0) == '\n' || l.peek(0) == '#' {
+		return nil
+	}
+
+	pos := l.pos()
+	if len(l.indents) == 0 {
+		// The first statement of the program sets the base indentation
+		// so that program text embedded within heredocs is accepted.
+		l.indents = append(l.indents, width)
+		return nil
+	}
+
+	current := l.indents[len(l.indents)-1]
+	switch {
+	case width > current:
+		l.indents = append(l.indents, width)
+		l.emit(TokenIndent, "", pos)
+	case width < current:
+		for len(l.indents) > 0 && l.indents[len(l.indents)-1] > width {
+			l.indents = l.indents[:len(l.indents)-1]
+			l.emit(TokenDedent, "", pos)
+		}
+		if len(l.indents) == 0 || l.indents[len(l.indents)-1] != width {
+			return newErrorf(pos, "unindent does not match any outer indentation level")
+		}
+	}
+	return nil
+}
+
+func (l *lexer) name() *Diagnostic {
+	pos := l.pos()
+	start := l.off
+	for !l.eof() && isNameChar(l.peek(0)) {
+		l.advance()
+	}
+	value := string(l.src[start:l.off])
+
+	if q := l.peek(0); (q == '\'' || q == '"') && isStringPrefix(value) {
+		return l.string(pos, value)
+	}
+
+	if _, ok := keywords[value]; ok {
+		l.emit(TokenKeyword, value, pos)
+		return nil
+	}
+	l.emit(TokenName, value, pos)
+	return nil
+}
+
+func (l *lexer) number() *Diagnostic {
+	pos := l.pos()
+	start := l.off
+
+	if l.peek(0) == '0' && strings.ContainsRune("xXoObB", l.peek(1)) {
+		l.advance()
+		l.advance()
+		for !l.eof() && (isHexDigit(l.peek(0)) || l.peek(0) == '_') {
+			l.advance()
+		}
+	} else {
+		for !l.eof() && (isDigit(l.peek(0)) || l.peek(0) == '_') {
+			l.advance()
+		}
+		if l.peek(0) == '.' {
+			l.advance()
+			for !l.eof() && (isDigit(l.peek(0)) || l.peek(0) == '_') {
+				l.advance()
+			}
+		}
+		if e := l.peek(0); e == 'e' || e == 'E' {
+			n := 1
+			if s := l.peek(1); s == '+' || s == '-' {
+				n = 2
+			}
+			if !isDigit(l.peek(n)) {
+				return newErrorf(l.pos(), "invalid number literal")
+			}
+			for ; n > 0; n-- {
+				l.advance()
+			}
+			for !l.eof() && isDigit(l.peek(0)) {
+				l.advance()
+			}
+		}
+	}
+
+	if !l.eof() && isNameStart(l.peek(0)) {
+		return newErrorf(pos, "invalid number literal")
+	}
+	l.emit(TokenNumber, string(l.src[start:l.off]), pos)
+	return nil
+}
+
+func (l *lexer) string(pos Pos, prefix string) *Diagnostic {
+	raw := strings.ContainsAny(prefix, "rR")
+	quote := l.peek(0)
+	triple := l.peek(1) == quote && l.peek(2) == quote
+
+	start := l.off - len([]rune(prefix))
+	delim := 1
+	if triple {
+		delim = 3
+	}
+	for i := 0; i < delim; i++ {
+		l.advance()
+	}
+
+	var sb strings.Builder
+	for {
+		if l.eof() {
+			return newErrorf(pos, "unterminated string literal")
+		}
+		r := l.peek(0)
+		switch {
+		case r == quote && (!triple || (l.peek(1) == quote && l.peek(2) == quote)):
+			for i := 0; i < delim; i++ {
+				l.advance()
+			}
+			l.tokens = append(l.tokens, Token{
+				Kind:  TokenString,
+				Value: sb.String(),
+				Raw:   string(l.src[start:l.off]),
+				Pos:   pos,
+			})
+			return nil
+		case r == '\n' && !triple:
+			return newErrorf(pos, "unterminated string literal")
+		case r == '\\':
+			l.advance()
+			if l.eof() {
+				return newErrorf(pos, "unterminated string literal")
+			}
+			next := l.advance()
+			if raw {
+				sb.WriteRune('\\')
+				sb.WriteRune(next)
+				continue
+			}
+			switch next {
+			case 'n':
+				sb.WriteRune('\n')
+			case 't':
+				sb.WriteRune('\t')
+			case 'r':
+				sb.WriteRune('\r')
+			case '\\', '\'', '"':
+				sb.WriteRune(next)
+			case '\n':
+				// escaped newlines are removed from the value
+			default:
+				sb.WriteRune('\\')
+				sb.WriteRune(next)
+			}
+		default:
+			sb.WriteRune(l.advance())
+		}
+	}
+}
+
+func (l *lexer) operator() *Diagnostic {
+	pos := l.pos()
+	for _, op := range operators {
+		if !l.hasPrefix(op) {
+			continue
+		}
+		for range op {
+			l.advance()
+		}
+		l.emit(TokenOperator, op, pos)
+
+		switch op {
+		case "(", "[", "{":
+			l.brackets = append(l.brackets, l.tokens[len(l.tokens)-1])
+		case ")", "]", "}":
+			n := len(l.brackets)
+			if n == 0 {
+				return newErrorf(pos, "unmatched %q", op)
+			}
+			if open := l.brackets[n-1]; closingBracket[open.Value] != op {
+				return newErrorf(pos, "closing %q does not match opening %q at %s", op, open.Value, open.Pos)
+			}
+			l.brackets = l.brackets[:n-1]
+		}
+		return nil
+	}
+	return newErrorf(pos, "unexpected character %q", l.peek(0))
+}
+
+func (l *lexer) hasPrefix(op string) bool {
+	i := 0
+	for _, r := range op {
+		if l.peek(i) != r {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+var closingBracket = map[string]string{
+	"(": ")",
+	"[": "]",
+	"{": "}",
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isHexDigit(r rune) bool {
+	return isDigit(r) || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+}
+
+func isNameStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isNameChar(r rune) bool {
+	return isNameStart(r) || unicode.IsDigit(r)
+}
+
+func isStringPrefix(s string) bool {
+	switch strings.ToLower(s) {
+	case "r", "u", "b", "rb", "br":
+		return true
+	}
+	return false
+}
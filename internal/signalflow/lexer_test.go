@@ -0,0 +1,129 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLex(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		program string
+		expect  []Token
+		errVal  string
+	}{
+		{
+			name:    "empty program",
+			program: "",
+			expect: []Token{
+				{Kind: TokenEOF, Pos: Pos{Line: 1, Column: 1}},
+			},
+		},
+		{
+			name:    "assignment",
+			program: "A = data('cpu', rollup=\"max\")",
+			expect: []Token{
+				{Kind: TokenName, Value: "A", Raw: "A", Pos: Pos{Line: 1, Column: 1}},
+				{Kind: TokenOperator, Value: "=", Raw: "=", Pos: Pos{Line: 1, Column: 3}},
+				{Kind: TokenName, Value: "data", Raw: "data", Pos: Pos{Line: 1, Column: 5}},
+				{Kind: TokenOperator, Value: "(", Raw: "(", Pos: Pos{Line: 1, Column: 9}},
+				{Kind: TokenString, Value: "cpu", Raw: "'cpu'", Pos: Pos{Line: 1, Column: 10}},
+				{Kind: TokenOperator, Value: ",", Raw: ",", Pos: Pos{Line: 1, Column: 15}},
+				{Kind: TokenName, Value: "rollup", Raw: "rollup", Pos: Pos{Line: 1, Column: 17}},
+				{Kind: TokenOperator, Value: "=", Raw: "=", Pos: Pos{Line: 1, Column: 23}},
+				{Kind: TokenString, Value: "max", Raw: "\"max\"", Pos: Pos{Line: 1, Column: 24}},
+				{Kind: TokenOperator, Value: ")", Raw: ")", Pos: Pos{Line: 1, Column: 29}},
+				{Kind: TokenNewline, Pos: Pos{Line: 1, Column: 30}},
+				{Kind: TokenEOF, Pos: Pos{Line: 1, Column: 30}},
+			},
+		},
+		{
+			name:    "brackets join lines and comments are ignored",
+			program: "# comment\nx = (1 >=\n  2.5e3) # trailing\n",
+			expect: []Token{
+				{Kind: TokenName, Value: "x", Raw: "x", Pos: Pos{Line: 2, Column: 1}},
+				{Kind: TokenOperator, Value: "=", Raw: "=", Pos: Pos{Line: 2, Column: 3}},
+				{Kind: TokenOperator, Value: "(", Raw: "(", Pos: Pos{Line: 2, Column: 5}},
+				{Kind: TokenNumber, Value: "1", Raw: "1", Pos: Pos{Line: 2, Column: 6}},
+				{Kind: TokenOperator, Value: ">=", Raw: ">=", Pos: Pos{Line: 2, Column: 8}},
+				{Kind: TokenNumber, Value: "2.5e3", Raw: "2.5e3", Pos: Pos{Line: 3, Column: 3}},
+				{Kind: TokenOperator, Value: ")", Raw: ")", Pos: Pos{Line: 3, Column: 8}},
+				{Kind: TokenNewline, Pos: Pos{Line: 3, Column: 20}},
+				{Kind: TokenEOF, Pos: Pos{Line: 4, Column: 1}},
+			},
+		},
+		{
+			name:    "indented block",
+			program: "def f(x):\n  return x\n",
+			expect: []Token{
+				{Kind: TokenKeyword, Value: "def", Raw: "def", Pos: Pos{Line: 1, Column: 1}},
+				{Kind: TokenName, Value: "f", Raw: "f", Pos: Pos{Line: 1, Column: 5}},
+				{Kind: TokenOperator, Value: "(", Raw: "(", Pos: Pos{Line: 1, Column: 6}},
+				{Kind: TokenName, Value: "x", Raw: "x", Pos: Pos{Line: 1, Column: 7}},
+				{Kind: TokenOperator, Value: ")", Raw: ")", Pos: Pos{Line: 1, Column: 8}},
+				{Kind: TokenOperator, Value: ":", Raw: ":", Pos: Pos{Line: 1, Column: 9}},
+				{Kind: TokenNewline, Pos: Pos{Line: 1, Column: 10}},
+				{Kind: TokenIndent, Pos: Pos{Line: 2, Column: 3}},
+				{Kind: TokenKeyword, Value: "return", Raw: "return", Pos: Pos{Line: 2, Column: 3}},
+				{Kind: TokenName, Value: "x", Raw: "x", Pos: Pos{Line: 2, Column: 10}},
+				{Kind: TokenNewline, Pos: Pos{Line: 2, Column: 11}},
+				{Kind: TokenDedent, Pos: Pos{Line: 3, Column: 1}},
+				{Kind: TokenEOF, Pos: Pos{Line: 3, Column: 1}},
+			},
+		},
+		{
+			name:    "escaped string",
+			program: `'it\'s'`,
+			expect: []Token{
+				{Kind: TokenString, Value: "it's", Raw: `'it\'s'`, Pos: Pos{Line: 1, Column: 1}},
+				{Kind: TokenNewline, Pos: Pos{Line: 1, Column: 8}},
+				{Kind: TokenEOF, Pos: Pos{Line: 1, Column: 8}},
+			},
+		},
+		{
+			name:    "unterminated string",
+			program: "A = 'cpu",
+			errVal:  "line 1, column 5: unterminated string literal",
+		},
+		{
+			name:    "unclosed bracket",
+			program: "A = data('cpu'",
+			errVal:  "line 1, column 9: \"(\" was never closed",
+		},
+		{
+			name:    "mismatched bracket",
+			program: "A = [1, 2)",
+			errVal:  "line 1, column 10: closing \")\" does not match opening \"[\" at line 1, column 5",
+		},
+		{
+			name:    "inconsistent dedent",
+			program: "if x:\n    y = 1\n  z = 2\n",
+			errVal:  "line 3, column 3: unindent does not match any outer indentation level",
+		},
+		{
+			name:    "invalid character",
+			program: "A = $B",
+			errVal:  "line 1, column 5: unexpected character '$'",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokens, err := Lex(tc.program)
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+				return
+			}
+			if !assert.Nil(t, err, "Must not return an error") {
+				return
+			}
+			assert.Equal(t, tc.expect, tokens, "Must match the expected tokens")
+		})
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+type scope struct {
+	names  map[string]struct{}
+	parent *scope
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{names: make(map[string]struct{}), parent: parent}
+}
+
+func (s *scope) define(name string) {
+	s.names[name] = struct{}{}
+}
+
+func (s *scope) defined(name string) bool {
+	for ; s != nil; s = s.parent {
+		if _, ok := s.names[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+type linter struct {
+	diags Diagnostics
+	// deferred holds the function bodies that are checked
+	// once all the program level names have been defined.
+	deferred []func()
+}
+
+// Check parses and lints the program text, returning all the diagnostics found.
+func Check(program string) Diagnostics {
+	prog, err := Parse(program)
+	if err != nil {
+		return Diagnostics{err}
+	}
+	return Lint(prog)
+}
+
+// Validate returns an error when the program text
+// contains syntax errors or references undefined variables.
+// Warnings are not returned since they do not stop the program from running.
+func Validate(program string) error {
+	if errs := Check(program).Errors(); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Lint checks the program for references to undefined variables, which are reported as errors,
+// and calls to unknown functions which are reported as warnings since the list of
+// known functions may fall behind what is supported by the API.
+func Lint(prog *Program) Diagnostics {
+	l := &linter{}
+	l.stmts(newScope(nil), prog.Body)
+	for len(l.deferred) > 0 {
+		fn := l.deferred[0]
+		l.deferred = l.deferred[1:]
+		fn()
+	}
+	return l.diags
+}
+
+func (l *linter) stmts(s *scope, body []Stmt) {
+	for _, stmt := range body {
+		l.stmt(s, stmt)
+	}
+}
+
+func (l *linter) stmt(s *scope, stmt Stmt) {
+	switch v := stmt.(type) {
+	case *ExprStmt:
+		l.expr(s, v.X)
+	case *AssignStmt:
+		if v.Op != "=" {
+			l.expr(s, v.Targets[0])
+		}
+		l.expr(s, v.Value)
+		for _, target := range v.Targets {
+			l.bind(s, target)
+		}
+	case *FuncDef:
+		for _, param := range v.Params {
+			if param.Default != nil {
+				l.expr(s, param.Default)
+			}
+		}
+		s.define(v.Name.Name)
+		l.deferred = append(l.deferred, func() {
+			fs := newScope(s)
+			for _, param := range v.Params {
+				fs.define(param.Name.Name)
+			}
+			// Any name assigned within the function is local to it
+			// so it is defined upfront to match the runtime behaviour.
+			for _, name := range bindings(v.Body) {
+				fs.define(name)
+			}
+			l.stmts(fs, v.Body)
+		})
+	case *ReturnStmt:
+		if v.Value != nil {
+			l.expr(s, v.Value)
+		}
+	case *IfStmt:
+		l.expr(s, v.Cond)
+		l.stmts(s, v.Body)
+		l.stmts(s, v.Else)
+	case *ForStmt:
+		l.expr(s, v.Iter)
+		l.bind(s, v.Target)
+		l.stmts(s, v.Body)
+	case *ImportStmt:
+		for _, name := range v.Names {
+			s.define(name.Bound())
+		}
+	}
+}
+
+// bind defines the names being assigned to within the scope.
+func (l *linter) bind(s *scope, target Expr) {
+	switch v := target.(type) {
+	case *Ident:
+		s.define(v.Name)
+	case *TupleLit:
+		for _, e := range v.Elts {
+			l.bind(s, e)
+		}
+	case *ListLit:
+		for _, e := range v.Elts {
+			l.bind(s, e)
+		}
+	case *StarExpr:
+		l.bind(s, v.X)
+	default:
+		// Attribute and index assignments modify
+		// an existing value so it must already be defined.
+		l.expr(s, target)
+	}
+}
+
+func (l *linter) expr(s *scope, expr Expr) {
+	switch v := expr.(type) {
+	case *Ident:
+		if !s.defined(v.Name) && !IsBuiltin(v.Name) {
+			l.diags = append(l.diags, newErrorf(v.At, "undefined variable %q", v.Name))
+		}
+	case *CallExpr:
+		if fn, ok := v.Func.(*Ident); ok {
+			if !s.defined(fn.Name) && !IsBuiltin(fn.Name) {
+				l.diags = append(l.diags, newWarningf(fn.At, "unknown function %q", fn.Name))
+			}
+		} else {
+			l.expr(s, v.Func)
+		}
+		for _, arg := range v.Args {
+			l.expr(s, arg)
+		}
+		for _, kw := range v.Keywords {
+			l.expr(s, kw.Value)
+		}
+	case *AttrExpr:
+		l.expr(s, v.X)
+	case *IndexExpr:
+		l.expr(s, v.X)
+		l.expr(s, v.Index)
+	case *SliceExpr:
+		for _, e := range []Expr{v.Low, v.High, v.Step} {
+			if e != nil {
+				l.expr(s, e)
+			}
+		}
+	case *ListLit:
+		for _, e := range v.Elts {
+			l.expr(s, e)
+		}
+	case *TupleLit:
+		for _, e := range v.Elts {
+			l.expr(s, e)
+		}
+	case *DictLit:
+		for i := range v.Keys {
+			l.expr(s, v.Keys[i])
+			l.expr(s, v.Values[i])
+		}
+	case *UnaryExpr:
+		l.expr(s, v.X)
+	case *BinaryExpr:
+		l.expr(s, v.X)
+		l.expr(s, v.Y)
+	case *CondExpr:
+		l.expr(s, v.Cond)
+		l.expr(s, v.Body)
+		l.expr(s, v.Else)
+	case *StarExpr:
+		l.expr(s, v.X)
+	case *LambdaExpr:
+		ls := newScope(s)
+		for _, param := range v.Params {
+			if param.Default != nil {
+				l.expr(s, param.Default)
+			}
+			ls.define(param.Name.Name)
+		}
+		l.expr(ls, v.Body)
+	case *CompExpr:
+		cs := newScope(s)
+		for _, loop := range v.Loops {
+			l.expr(cs, loop.Iter)
+			l.bind(cs, loop.Target)
+			for _, cond := range loop.Ifs {
+				l.expr(cs, cond)
+			}
+		}
+		l.expr(cs, v.Elt)
+	}
+}
+
+// bindings returns all the names that are assigned within the body
+// without descending into nested function definitions.
+func bindings(body []Stmt) []string {
+	var names []string
+	var targets func(Expr)
+	targets = func(e Expr) {
+		switch v := e.(type) {
+		case *Ident:
+			names = append(names, v.Name)
+		case *TupleLit:
+			for _, elt := range v.Elts {
+				targets(elt)
+			}
+		case *ListLit:
+			for _, elt := range v.Elts {
+				targets(elt)
+			}
+		case *StarExpr:
+			targets(v.X)
+		}
+	}
+	for _, stmt := range body {
+		switch v := stmt.(type) {
+		case *AssignStmt:
+			for _, t := range v.Targets {
+				targets(t)
+			}
+		case *FuncDef:
+			names = append(names, v.Name.Name)
+		case *ForStmt:
+			targets(v.Target)
+			names = append(names, bindings(v.Body)...)
+		case *IfStmt:
+			names = append(names, bindings(v.Body)...)
+			names = append(names, bindings(v.Else)...)
+		case *ImportStmt:
+			for _, name := range v.Names {
+				names = append(names, name.Bound())
+			}
+		}
+	}
+	return names
+}
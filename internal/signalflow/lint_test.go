@@ -0,0 +1,110 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		program string
+		expect  Diagnostics
+	}{
+		{
+			name:    "valid program",
+			program: "signal = data('app.delay').max().publish('app delay')\ndetect(when(signal > 60, '5m')).publish('Processing old messages 5m')\n",
+			expect:  nil,
+		},
+		{
+			name:    "syntax error",
+			program: "A = data('cpu'",
+			expect: Diagnostics{
+				{Pos: Pos{Line: 1, Column: 9}, Severity: SeverityError, Message: "\"(\" was never closed"},
+			},
+		},
+		{
+			name:    "undefined variable",
+			program: "A = data('cpu').publish('A')\ndetect(when(B > 10)).publish('high')",
+			expect: Diagnostics{
+				{Pos: Pos{Line: 2, Column: 13}, Severity: SeverityError, Message: "undefined variable \"B\""},
+			},
+		},
+		{
+			name:    "variable used before assignment",
+			program: "B = A * 2\nA = data('cpu')",
+			expect: Diagnostics{
+				{Pos: Pos{Line: 1, Column: 5}, Severity: SeverityError, Message: "undefined variable \"A\""},
+			},
+		},
+		{
+			name:    "unknown function",
+			program: "A = dat('cpu').publish(label='A')",
+			expect: Diagnostics{
+				{Pos: Pos{Line: 1, Column: 5}, Severity: SeverityWarning, Message: "unknown function \"dat\""},
+			},
+		},
+		{
+			name:    "methods are not checked",
+			program: "data('cpu').not_a_method().publish()",
+			expect:  nil,
+		},
+		{
+			name:    "imported names are defined",
+			program: "from signalfx.detectors.against_recent import against_recent\nimport signalfx.detectors.aperiodic as aperiodic\nagainst_recent.detector_mean_std(data('cpu')).publish('x')\naperiodic.above(data('cpu')).publish('y')",
+			expect:  nil,
+		},
+		{
+			name:    "function bodies can refer to later definitions",
+			program: "def f(s):\n    local = s * scale\n    return local\nscale = 2\nf(data('cpu')).publish()",
+			expect:  nil,
+		},
+		{
+			name:    "function locals do not leak",
+			program: "def f(s):\n    local = s\n    return local\nlocal.publish()",
+			expect: Diagnostics{
+				{Pos: Pos{Line: 4, Column: 1}, Severity: SeverityError, Message: "undefined variable \"local\""},
+			},
+		},
+		{
+			name:    "lambda and comprehension scopes",
+			program: "m = data('cpu').map(lambda x: x * 2)\nl = [v for v in range(3) if v > 0]\nv.publish()",
+			expect: Diagnostics{
+				{Pos: Pos{Line: 3, Column: 1}, Severity: SeverityError, Message: "undefined variable \"v\""},
+			},
+		},
+		{
+			name:    "multiple issues",
+			program: "A = foo(B)\nC = A + D",
+			expect: Diagnostics{
+				{Pos: Pos{Line: 1, Column: 5}, Severity: SeverityWarning, Message: "unknown function \"foo\""},
+				{Pos: Pos{Line: 1, Column: 9}, Severity: SeverityError, Message: "undefined variable \"B\""},
+				{Pos: Pos{Line: 2, Column: 9}, Severity: SeverityError, Message: "undefined variable \"D\""},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expect, Check(tc.program), "Must match the expected diagnostics")
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Validate("data('cpu').publish()"), "Must not error with a valid program")
+	assert.NoError(t, Validate("dat('cpu').publish()"), "Must not error with only warnings")
+	assert.EqualError(t,
+		Validate("A = B\nC = D"),
+		"line 1, column 5: undefined variable \"B\"; line 2, column 5: undefined variable \"D\"",
+		"Must report all errors",
+	)
+}
@@ -0,0 +1,677 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import "strings"
+
+type parser struct {
+	tokens []Token
+	off    int
+}
+
+// bailout is used to unwind the parser once the
+// first syntax error has been found.
+type bailout struct {
+	diag *Diagnostic
+}
+
+// Parse converts the program text into its syntax tree,
+// the first syntax error found is returned as a diagnostic.
+func Parse(program string) (prog *Program, err *Diagnostic) {
+	tokens, err := Lex(program)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	defer func() {
+		if r := recover(); r != nil {
+			b, ok := r.(bailout)
+			if !ok {
+				panic(r)
+			}
+			prog, err = nil, b.diag
+		}
+	}()
+
+	return p.program(), nil
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.off]
+}
+
+func (p *parser) peekAt(n int) Token {
+	if p.off+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.off+n]
+}
+
+func (p *parser) next() Token {
+	t := p.tokens[p.off]
+	if t.Kind != TokenEOF {
+		p.off++
+	}
+	return t
+}
+
+func (p *parser) failf(pos Pos, format string, args ...any) {
+	panic(bailout{diag: newErrorf(pos, format, args...)})
+}
+
+func (p *parser) unexpected(t Token) {
+	p.failf(t.Pos, "unexpected %s", t)
+}
+
+// accept consumes the token if it matches the kind and value.
+func (p *parser) accept(kind TokenKind, value string) bool {
+	if p.peek().is(kind, value) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind TokenKind, value string) Token {
+	t := p.next()
+	if !t.is(kind, value) {
+		p.failf(t.Pos, "expected %q but found %s", value, t)
+	}
+	return t
+}
+
+func (p *parser) expectKind(kind TokenKind) Token {
+	t := p.next()
+	if t.Kind != kind {
+		p.failf(t.Pos, "expected %s but found %s", kind, t)
+	}
+	return t
+}
+
+func (p *parser) isOp(values ...string) bool {
+	t := p.peek()
+	if t.Kind != TokenOperator {
+		return false
+	}
+	for _, v := range values {
+		if t.Value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) isKeyword(value string) bool {
+	return p.peek().is(TokenKeyword, value)
+}
+
+func (p *parser) program() *Program {
+	prog := &Program{}
+	for p.peek().Kind != TokenEOF {
+		if p.accept(TokenNewline, "") {
+			continue
+		}
+		prog.Body = append(prog.Body, p.statement()...)
+	}
+	return prog
+}
+
+func (p *parser) statement() []Stmt {
+	switch t := p.peek(); {
+	case t.is(TokenKeyword, "def"):
+		return []Stmt{p.funcDef()}
+	case t.is(TokenKeyword, "if"):
+		return []Stmt{p.ifStmt()}
+	case t.is(TokenKeyword, "for"):
+		return []Stmt{p.forStmt()}
+	case t.Kind == TokenIndent:
+		p.failf(t.Pos, "unexpected indent")
+	}
+	return p.simpleStatements()
+}
+
+func (p *parser) simpleStatements() []Stmt {
+	stmts := []Stmt{p.smallStatement()}
+	for p.accept(TokenOperator, ";") {
+		if p.peek().Kind == TokenNewline {
+			break
+		}
+		stmts = append(stmts, p.smallStatement())
+	}
+	if t := p.next(); t.Kind != TokenNewline {
+		p.unexpected(t)
+	}
+	return stmts
+}
+
+func (p *parser) smallStatement() Stmt {
+	t := p.peek()
+	switch {
+	case t.is(TokenKeyword, "pass"):
+		p.next()
+		return &PassStmt{At: t.Pos}
+	case t.is(TokenKeyword, "return"):
+		p.next()
+		stmt := &ReturnStmt{At: t.Pos}
+		if p.peek().Kind != TokenNewline && !p.isOp(";") {
+			stmt.Value = p.testList()
+		}
+		return stmt
+	case t.is(TokenKeyword, "import"):
+		return p.importStmt()
+	case t.is(TokenKeyword, "from"):
+		return p.fromImportStmt()
+	}
+
+	x := p.testList()
+	switch op := p.peek(); {
+	case op.is(TokenOperator, "="):
+		stmt := &AssignStmt{At: x.Pos(), Op: "="}
+		targets := []Expr{x}
+		for p.accept(TokenOperator, "=") {
+			targets = append(targets, p.testList())
+		}
+		stmt.Value = targets[len(targets)-1]
+		stmt.Targets = targets[:len(targets)-1]
+		for _, target := range stmt.Targets {
+			p.checkTarget(target)
+		}
+		return stmt
+	case op.Kind == TokenOperator && len(op.Value) > 1 && strings.HasSuffix(op.Value, "=") &&
+		op.Value != "==" && op.Value != "!=" && op.Value != "<=" && op.Value != ">=":
+		p.next()
+		if _, ok := x.(*TupleLit); ok {
+			p.failf(x.Pos(), "illegal expression for augmented assignment")
+		}
+		p.checkTarget(x)
+		return &AssignStmt{At: x.Pos(), Targets: []Expr{x}, Op: op.Value, Value: p.testList()}
+	}
+	return &ExprStmt{X: x}
+}
+
+// checkTarget ensures the expression can be assigned to.
+func (p *parser) checkTarget(x Expr) {
+	switch v := x.(type) {
+	case *Ident, *AttrExpr, *IndexExpr:
+	case *TupleLit:
+		for _, e := range v.Elts {
+			p.checkTarget(e)
+		}
+	case *ListLit:
+		for _, e := range v.Elts {
+			p.checkTarget(e)
+		}
+	case *StarExpr:
+		p.checkTarget(v.X)
+	default:
+		p.failf(x.Pos(), "cannot assign to expression")
+	}
+}
+
+func (p *parser) dottedName() (string, Pos) {
+	first := p.expectKind(TokenName)
+	parts := []string{first.Value}
+	for p.accept(TokenOperator, ".") {
+		parts = append(parts, p.expectKind(TokenName).Value)
+	}
+	return strings.Join(parts, "."), first.Pos
+}
+
+func (p *parser) importStmt() Stmt {
+	stmt := &ImportStmt{At: p.expect(TokenKeyword, "import").Pos}
+	for {
+		path, pos := p.dottedName()
+		name := &ImportName{Path: path, At: pos}
+		if p.accept(TokenKeyword, "as") {
+			alias := p.expectKind(TokenName)
+			name.Alias = &Ident{At: alias.Pos, Name: alias.Value}
+		}
+		stmt.Names = append(stmt.Names, name)
+		if !p.accept(TokenOperator, ",") {
+			return stmt
+		}
+	}
+}
+
+func (p *parser) fromImportStmt() Stmt {
+	stmt := &ImportStmt{At: p.expect(TokenKeyword, "from").Pos}
+	stmt.Module, _ = p.dottedName()
+	p.expect(TokenKeyword, "import")
+
+	parens := p.accept(TokenOperator, "(")
+	for {
+		t := p.expectKind(TokenName)
+		name := &ImportName{Path: t.Value, At: t.Pos}
+		if p.accept(TokenKeyword, "as") {
+			alias := p.expectKind(TokenName)
+			name.Alias = &Ident{At: alias.Pos, Name: alias.Value}
+		}
+		stmt.Names = append(stmt.Names, name)
+		if !p.accept(TokenOperator, ",") {
+			break
+		}
+		if parens && p.isOp(")") {
+			break
+		}
+	}
+	if parens {
+		p.expect(TokenOperator, ")")
+	}
+	return stmt
+}
+
+func (p *parser) funcDef() Stmt {
+	stmt := &FuncDef{At: p.expect(TokenKeyword, "def").Pos}
+	name := p.expectKind(TokenName)
+	stmt.Name = &Ident{At: name.Pos, Name: name.Value}
+	p.expect(TokenOperator, "(")
+	stmt.Params = p.params(")")
+	p.expect(TokenOperator, ")")
+	p.expect(TokenOperator, ":")
+	stmt.Body = p.suite()
+	return stmt
+}
+
+// params reads the parameter list until the closing value is found
+// without consuming it.
+func (p *parser) params(closing string) []*Param {
+	var params []*Param
+	seen := make(map[string]struct{})
+	for !p.isOp(closing) {
+		if p.isOp("*", "**") {
+			p.next()
+		}
+		t := p.expectKind(TokenName)
+		if _, ok := seen[t.Value]; ok {
+			p.failf(t.Pos, "duplicate argument %q in function definition", t.Value)
+		}
+		seen[t.Value] = struct{}{}
+
+		param := &Param{Name: &Ident{At: t.Pos, Name: t.Value}}
+		if p.accept(TokenOperator, "=") {
+			param.Default = p.test()
+		}
+		params = append(params, param)
+		if !p.accept(TokenOperator, ",") {
+			break
+		}
+	}
+	return params
+}
+
+func (p *parser) suite() []Stmt {
+	if p.peek().Kind != TokenNewline {
+		return p.simpleStatements()
+	}
+	p.next()
+	if t := p.next(); t.Kind != TokenIndent {
+		p.failf(t.Pos, "expected an indented block")
+	}
+	var body []Stmt
+	for p.peek().Kind != TokenDedent && p.peek().Kind != TokenEOF {
+		body = append(body, p.statement()...)
+	}
+	p.next()
+	return body
+}
+
+func (p *parser) ifStmt() Stmt {
+	stmt := &IfStmt{At: p.next().Pos}
+	stmt.Cond = p.test()
+	p.expect(TokenOperator, ":")
+	stmt.Body = p.suite()
+
+	switch {
+	case p.isKeyword("elif"):
+		stmt.Else = []Stmt{p.ifStmt()}
+	case p.accept(TokenKeyword, "else"):
+		p.expect(TokenOperator, ":")
+		stmt.Else = p.suite()
+	}
+	return stmt
+}
+
+func (p *parser) forStmt() Stmt {
+	stmt := &ForStmt{At: p.expect(TokenKeyword, "for").Pos}
+	stmt.Target = p.targetList()
+	p.expect(TokenKeyword, "in")
+	stmt.Iter = p.testList()
+	p.expect(TokenOperator, ":")
+	stmt.Body = p.suite()
+	return stmt
+}
+
+// targetList reads the loop targets which stop before the `in` keyword.
+func (p *parser) targetList() Expr {
+	first := p.bitOr()
+	if !p.isOp(",") {
+		p.checkTarget(first)
+		return first
+	}
+	tuple := &TupleLit{At: first.Pos(), Elts: []Expr{first}}
+	for p.accept(TokenOperator, ",") && !p.isKeyword("in") {
+		tuple.Elts = append(tuple.Elts, p.bitOr())
+	}
+	p.checkTarget(tuple)
+	return tuple
+}
+
+// testList reads one or more comma separated expressions,
+// more than one value results in a tuple.
+func (p *parser) testList() Expr {
+	first := p.test()
+	if !p.isOp(",") {
+		return first
+	}
+	tuple := &TupleLit{At: first.Pos(), Elts: []Expr{first}}
+	for p.accept(TokenOperator, ",") {
+		if !p.startsExpr() {
+			break
+		}
+		tuple.Elts = append(tuple.Elts, p.test())
+	}
+	return tuple
+}
+
+// startsExpr reports if the next token can begin an expression.
+func (p *parser) startsExpr() bool {
+	switch t := p.peek(); t.Kind {
+	case TokenName, TokenNumber, TokenString:
+		return true
+	case TokenKeyword:
+		switch t.Value {
+		case "None", "True", "False", "not", "lambda":
+			return true
+		}
+	case TokenOperator:
+		switch t.Value {
+		case "(", "[", "{", "-", "+", "~", "*":
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) test() Expr {
+	if p.isKeyword("lambda") {
+		return p.lambda()
+	}
+	x := p.orTest()
+	if p.accept(TokenKeyword, "if") {
+		cond := &CondExpr{At: x.Pos(), Body: x, Cond: p.orTest()}
+		p.expect(TokenKeyword, "else")
+		cond.Else = p.test()
+		return cond
+	}
+	return x
+}
+
+func (p *parser) lambda() Expr {
+	expr := &LambdaExpr{At: p.next().Pos}
+	expr.Params = p.params(":")
+	p.expect(TokenOperator, ":")
+	expr.Body = p.test()
+	return expr
+}
+
+func (p *parser) orTest() Expr {
+	x := p.andTest()
+	for p.isKeyword("or") {
+		p.next()
+		x = &BinaryExpr{At: x.Pos(), Op: "or", X: x, Y: p.andTest()}
+	}
+	return x
+}
+
+func (p *parser) andTest() Expr {
+	x := p.notTest()
+	for p.isKeyword("and") {
+		p.next()
+		x = &BinaryExpr{At: x.Pos(), Op: "and", X: x, Y: p.notTest()}
+	}
+	return x
+}
+
+func (p *parser) notTest() Expr {
+	if t := p.peek(); t.is(TokenKeyword, "not") {
+		p.next()
+		return &UnaryExpr{At: t.Pos, Op: "not", X: p.notTest()}
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() Expr {
+	x := p.bitOr()
+	for {
+		var op string
+		switch t := p.peek(); {
+		case p.isOp("<", ">", "==", ">=", "<=", "!="):
+			op = p.next().Value
+		case t.is(TokenKeyword, "in"):
+			op = p.next().Value
+		case t.is(TokenKeyword, "not") && p.peekAt(1).is(TokenKeyword, "in"):
+			p.next()
+			p.next()
+			op = "not in"
+		case t.is(TokenKeyword, "is"):
+			p.next()
+			op = "is"
+			if p.accept(TokenKeyword, "not") {
+				op = "is not"
+			}
+		default:
+			return x
+		}
+		x = &BinaryExpr{At: x.Pos(), Op: op, X: x, Y: p.bitOr()}
+	}
+}
+
+// binary reads a left associative chain of operators,
+// using operand to read each side of the expression.
+func (p *parser) binary(operand func() Expr, ops ...string) Expr {
+	x := operand()
+	for p.isOp(ops...) {
+		op := p.next().Value
+		x = &BinaryExpr{At: x.Pos(), Op: op, X: x, Y: operand()}
+	}
+	return x
+}
+
+func (p *parser) bitOr() Expr  { return p.binary(p.bitXor, "|") }
+func (p *parser) bitXor() Expr { return p.binary(p.bitAnd, "^") }
+func (p *parser) bitAnd() Expr { return p.binary(p.shift, "&") }
+func (p *parser) shift() Expr  { return p.binary(p.arith, "<<", ">>") }
+func (p *parser) arith() Expr  { return p.binary(p.term, "+", "-") }
+func (p *parser) term() Expr   { return p.binary(p.factor, "*", "/", "//", "%", "@") }
+
+func (p *parser) factor() Expr {
+	if p.isOp("+", "-", "~") {
+		t := p.next()
+		return &UnaryExpr{At: t.Pos, Op: t.Value, X: p.factor()}
+	}
+	return p.power()
+}
+
+func (p *parser) power() Expr {
+	x := p.primary()
+	if p.accept(TokenOperator, "**") {
+		return &BinaryExpr{At: x.Pos(), Op: "**", X: x, Y: p.factor()}
+	}
+	return x
+}
+
+func (p *parser) primary() Expr {
+	x := p.atom()
+	for {
+		switch {
+		case p.isOp("("):
+			x = p.call(x)
+		case p.isOp("["):
+			p.next()
+			x = &IndexExpr{At: x.Pos(), X: x, Index: p.subscript()}
+			p.expect(TokenOperator, "]")
+		case p.isOp("."):
+			p.next()
+			t := p.expectKind(TokenName)
+			x = &AttrExpr{At: x.Pos(), X: x, Name: &Ident{At: t.Pos, Name: t.Value}}
+		default:
+			return x
+		}
+	}
+}
+
+func (p *parser) call(fn Expr) Expr {
+	p.expect(TokenOperator, "(")
+	call := &CallExpr{At: fn.Pos(), Func: fn}
+	seen := make(map[string]struct{})
+	for !p.isOp(")") {
+		switch t := p.peek(); {
+		case t.Kind == TokenName && p.peekAt(1).is(TokenOperator, "="):
+			p.next()
+			p.next()
+			if _, ok := seen[t.Value]; ok {
+				p.failf(t.Pos, "keyword argument %q repeated", t.Value)
+			}
+			seen[t.Value] = struct{}{}
+			call.Keywords = append(call.Keywords, &Keyword{
+				Name:  &Ident{At: t.Pos, Name: t.Value},
+				Value: p.test(),
+			})
+		case p.isOp("*", "**"):
+			p.next()
+			call.Args = append(call.Args, &StarExpr{At: t.Pos, Op: t.Value, X: p.test()})
+		default:
+			if len(call.Keywords) > 0 {
+				p.failf(t.Pos, "positional argument follows keyword argument")
+			}
+			call.Args = append(call.Args, p.test())
+		}
+		if !p.accept(TokenOperator, ",") {
+			break
+		}
+	}
+	p.expect(TokenOperator, ")")
+	return call
+}
+
+func (p *parser) subscript() Expr {
+	start := p.peek().Pos
+	var low Expr
+	if !p.isOp(":") {
+		low = p.test()
+		if !p.isOp(":") {
+			return low
+		}
+	}
+	slice := &SliceExpr{At: start, Low: low}
+	p.expect(TokenOperator, ":")
+	if !p.isOp(":", "]") {
+		slice.High = p.test()
+	}
+	if p.accept(TokenOperator, ":") && !p.isOp("]") {
+		slice.Step = p.test()
+	}
+	return slice
+}
+
+func (p *parser) atom() Expr {
+	t := p.next()
+	switch t.Kind {
+	case TokenName:
+		return &Ident{At: t.Pos, Name: t.Value}
+	case TokenNumber:
+		return &NumberLit{At: t.Pos, Value: t.Value}
+	case TokenString:
+		value := t.Value
+		for p.peek().Kind == TokenString {
+			value += p.next().Value
+		}
+		return &StringLit{At: t.Pos, Value: value}
+	case TokenKeyword:
+		switch t.Value {
+		case "None", "True", "False":
+			return &ConstLit{At: t.Pos, Value: t.Value}
+		}
+	case TokenOperator:
+		switch t.Value {
+		case "(":
+			return p.parens(t)
+		case "[":
+			return p.list(t)
+		case "{":
+			return p.dict(t)
+		}
+	}
+	p.unexpected(t)
+	return nil
+}
+
+func (p *parser) parens(open Token) Expr {
+	if p.accept(TokenOperator, ")") {
+		return &TupleLit{At: open.Pos}
+	}
+	first := p.test()
+	if p.isKeyword("for") {
+		comp := p.comprehension(open.Pos, first)
+		p.expect(TokenOperator, ")")
+		return comp
+	}
+	if p.accept(TokenOperator, ")") {
+		return first
+	}
+	tuple := &TupleLit{At: open.Pos, Elts: []Expr{first}}
+	for p.accept(TokenOperator, ",") && !p.isOp(")") {
+		tuple.Elts = append(tuple.Elts, p.test())
+	}
+	p.expect(TokenOperator, ")")
+	return tuple
+}
+
+func (p *parser) list(open Token) Expr {
+	list := &ListLit{At: open.Pos}
+	if p.accept(TokenOperator, "]") {
+		return list
+	}
+	first := p.test()
+	if p.isKeyword("for") {
+		comp := p.comprehension(open.Pos, first)
+		p.expect(TokenOperator, "]")
+		return comp
+	}
+	list.Elts = append(list.Elts, first)
+	for p.accept(TokenOperator, ",") && !p.isOp("]") {
+		list.Elts = append(list.Elts, p.test())
+	}
+	p.expect(TokenOperator, "]")
+	return list
+}
+
+func (p *parser) dict(open Token) Expr {
+	dict := &DictLit{At: open.Pos}
+	for !p.isOp("}") {
+		dict.Keys = append(dict.Keys, p.test())
+		p.expect(TokenOperator, ":")
+		dict.Values = append(dict.Values, p.test())
+		if !p.accept(TokenOperator, ",") {
+			break
+		}
+	}
+	p.expect(TokenOperator, "}")
+	return dict
+}
+
+func (p *parser) comprehension(pos Pos, elt Expr) Expr {
+	comp := &CompExpr{At: pos, Elt: elt}
+	for p.accept(TokenKeyword, "for") {
+		loop := &CompLoop{Target: p.targetList()}
+		p.expect(TokenKeyword, "in")
+		loop.Iter = p.orTest()
+		for p.accept(TokenKeyword, "if") {
+			loop.Ifs = append(loop.Ifs, p.orTest())
+		}
+		comp.Loops = append(comp.Loops, loop)
+	}
+	return comp
+}
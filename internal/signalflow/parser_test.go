@@ -0,0 +1,217 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		program string
+		expect  *Program
+		errVal  string
+	}{
+		{
+			name:    "empty program",
+			program: "\n# nothing to see\n",
+			expect:  &Program{},
+		},
+		{
+			name:    "published stream",
+			program: "A = data('cpu').mean(by=['host']).publish(label='A')",
+			expect: &Program{Body: []Stmt{
+				&AssignStmt{
+					At: Pos{Line: 1, Column: 1},
+					Op: "=",
+					Targets: []Expr{
+						&Ident{At: Pos{Line: 1, Column: 1}, Name: "A"},
+					},
+					Value: &CallExpr{
+						At: Pos{Line: 1, Column: 5},
+						Func: &AttrExpr{
+							At: Pos{Line: 1, Column: 5},
+							X: &CallExpr{
+								At: Pos{Line: 1, Column: 5},
+								Func: &AttrExpr{
+									At: Pos{Line: 1, Column: 5},
+									X: &CallExpr{
+										At:   Pos{Line: 1, Column: 5},
+										Func: &Ident{At: Pos{Line: 1, Column: 5}, Name: "data"},
+										Args: []Expr{
+											&StringLit{At: Pos{Line: 1, Column: 10}, Value: "cpu"},
+										},
+									},
+									Name: &Ident{At: Pos{Line: 1, Column: 17}, Name: "mean"},
+								},
+								Keywords: []*Keyword{
+									{
+										Name: &Ident{At: Pos{Line: 1, Column: 22}, Name: "by"},
+										Value: &ListLit{
+											At: Pos{Line: 1, Column: 25},
+											Elts: []Expr{
+												&StringLit{At: Pos{Line: 1, Column: 26}, Value: "host"},
+											},
+										},
+									},
+								},
+							},
+							Name: &Ident{At: Pos{Line: 1, Column: 35}, Name: "publish"},
+						},
+						Keywords: []*Keyword{
+							{
+								Name:  &Ident{At: Pos{Line: 1, Column: 43}, Name: "label"},
+								Value: &StringLit{At: Pos{Line: 1, Column: 49}, Value: "A"},
+							},
+						},
+					},
+				},
+			}},
+		},
+		{
+			name:    "operator precedence",
+			program: "x = not a + b * c > 2 or d",
+			expect: &Program{Body: []Stmt{
+				&AssignStmt{
+					At:      Pos{Line: 1, Column: 1},
+					Op:      "=",
+					Targets: []Expr{&Ident{At: Pos{Line: 1, Column: 1}, Name: "x"}},
+					Value: &BinaryExpr{
+						At: Pos{Line: 1, Column: 5},
+						Op: "or",
+						X: &UnaryExpr{
+							At: Pos{Line: 1, Column: 5},
+							Op: "not",
+							X: &BinaryExpr{
+								At: Pos{Line: 1, Column: 9},
+								Op: ">",
+								X: &BinaryExpr{
+									At: Pos{Line: 1, Column: 9},
+									Op: "+",
+									X:  &Ident{At: Pos{Line: 1, Column: 9}, Name: "a"},
+									Y: &BinaryExpr{
+										At: Pos{Line: 1, Column: 13},
+										Op: "*",
+										X:  &Ident{At: Pos{Line: 1, Column: 13}, Name: "b"},
+										Y:  &Ident{At: Pos{Line: 1, Column: 17}, Name: "c"},
+									},
+								},
+								Y: &NumberLit{At: Pos{Line: 1, Column: 21}, Value: "2"},
+							},
+						},
+						Y: &Ident{At: Pos{Line: 1, Column: 26}, Name: "d"},
+					},
+				},
+			}},
+		},
+		{
+			name:    "import and function definition",
+			program: "from signalfx.detectors.against_periods import conditions as c\ndef f(x, y=1):\n    return x\n",
+			expect: &Program{Body: []Stmt{
+				&ImportStmt{
+					At:     Pos{Line: 1, Column: 1},
+					Module: "signalfx.detectors.against_periods",
+					Names: []*ImportName{
+						{
+							Path:  "conditions",
+							Alias: &Ident{At: Pos{Line: 1, Column: 62}, Name: "c"},
+							At:    Pos{Line: 1, Column: 48},
+						},
+					},
+				},
+				&FuncDef{
+					At:   Pos{Line: 2, Column: 1},
+					Name: &Ident{At: Pos{Line: 2, Column: 5}, Name: "f"},
+					Params: []*Param{
+						{Name: &Ident{At: Pos{Line: 2, Column: 7}, Name: "x"}},
+						{
+							Name:    &Ident{At: Pos{Line: 2, Column: 10}, Name: "y"},
+							Default: &NumberLit{At: Pos{Line: 2, Column: 12}, Value: "1"},
+						},
+					},
+					Body: []Stmt{
+						&ReturnStmt{
+							At:    Pos{Line: 3, Column: 5},
+							Value: &Ident{At: Pos{Line: 3, Column: 12}, Name: "x"},
+						},
+					},
+				},
+			}},
+		},
+		{
+			name:    "missing operand",
+			program: "A = 1 +\n",
+			errVal:  "line 1, column 8: unexpected newline",
+		},
+		{
+			name:    "unexpected indent",
+			program: "A = data('cpu')\n  B = A\n",
+			errVal:  "line 2, column 3: unexpected indent",
+		},
+		{
+			name:    "invalid assignment",
+			program: "data('cpu') = 1",
+			errVal:  "line 1, column 1: cannot assign to expression",
+		},
+		{
+			name:    "positional after keyword",
+			program: "data(metric='cpu', 'host')",
+			errVal:  "line 1, column 20: positional argument follows keyword argument",
+		},
+		{
+			name:    "repeated keyword",
+			program: "data('cpu', rollup='max', rollup='min')",
+			errVal:  "line 1, column 27: keyword argument \"rollup\" repeated",
+		},
+		{
+			name:    "missing block",
+			program: "def f():\nreturn 1\n",
+			errVal:  "line 2, column 1: expected an indented block",
+		},
+		{
+			name:    "missing colon",
+			program: "if x\n  pass\n",
+			errVal:  "line 1, column 5: expected \":\" but found newline",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			prog, err := Parse(tc.program)
+			if tc.errVal != "" {
+				assert.EqualError(t, err, tc.errVal, "Must match the expected error")
+				return
+			}
+			require.Nil(t, err, "Must not return an error")
+			assert.Equal(t, tc.expect, prog, "Must match the expected program")
+		})
+	}
+}
+
+func TestParseValidPrograms(t *testing.T) {
+	t.Parallel()
+
+	for _, program := range []string{
+		"signal = data('app.delay').max().publish('app delay')\ndetect(when(signal > 60, '5m')).publish('Processing old messages 5m')\n",
+		"\tA = data('cpu.utilization').mean(by=['sf_metric', 'sfx_realm']).publish(label='A');\n\tdetect(when(A > threshold(10), lasting='2m'), auto_resolve_after='3d').publish('CPU utilization is high')\n\t",
+		"logs(filter=field('message') == 'Transaction processed' and field('service.name') == 'paymentservice').publish()",
+		"G = data('spans.count', filter=filter('sf_error', 'false') and filter('sf_service', 'api'))\nT = data('spans.count', filter=filter('sf_service', 'api'))",
+		"from signalfx.detectors.against_periods import conditions\n\nlatency = data('service.latency').publish('latency')\nfire, clear = conditions.mean_std(\n  latency,\n  window_to_compare='15m',\n)\ndetect(fire, off=clear).publish('anomaly')\n",
+		"x = [a for a in range(3) if a > 1]\ny = {'a': 1, 'b': x[1:2], 'c': x[::-1]}\nz = lambda q, *r: q if q is not None else r\n",
+		"def scale(s, factor=2):\n    if factor > 1:\n        return s * factor\n    elif factor < 0:\n        return -s\n    else:\n        pass\n    return s\n",
+		"A = data('a'); B = data('b')\nA.publish(); (A / B).publish('ratio')\n",
+		"x = 1 \\\n  + 2\nx += 0x1F ** -1\n",
+		`s = """multi
+line""" + r'\d' + "joined" 'strings'`,
+	} {
+		_, err := Parse(program)
+		assert.Nil(t, err, "Must parse the program %q", program)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+import "fmt"
+
+type TokenKind int
+
+const (
+	TokenEOF TokenKind = iota
+	TokenNewline
+	TokenIndent
+	TokenDedent
+	TokenName
+	TokenKeyword
+	TokenNumber
+	TokenString
+	TokenOperator
+)
+
+func (k TokenKind) String() string {
+	switch k {
+	case TokenEOF:
+		return "end of program"
+	case TokenNewline:
+		return "newline"
+	case TokenIndent:
+		return "indent"
+	case TokenDedent:
+		return "dedent"
+	case TokenName:
+		return "name"
+	case TokenKeyword:
+		return "keyword"
+	case TokenNumber:
+		return "number"
+	case TokenString:
+		return "string"
+	case TokenOperator:
+		return "operator"
+	}
+	return "unknown"
+}
+
+// Token is a single lexical item of the program text.
+// For strings, the Value is the decoded content and
+// Raw holds the literal as written in the program.
+type Token struct {
+	Kind  TokenKind
+	Value string
+	Raw   string
+	Pos   Pos
+}
+
+func (t Token) String() string {
+	switch t.Kind {
+	case TokenEOF, TokenNewline, TokenIndent, TokenDedent:
+		return t.Kind.String()
+	case TokenString:
+		return fmt.Sprintf("string %s", t.Raw)
+	}
+	return fmt.Sprintf("%s %q", t.Kind, t.Value)
+}
+
+func (t Token) is(kind TokenKind, value string) bool {
+	return t.Kind == kind && t.Value == value
+}
+
+var keywords = map[string]struct{}{
+	"and":    {},
+	"as":     {},
+	"def":    {},
+	"elif":   {},
+	"else":   {},
+	"False":  {},
+	"for":    {},
+	"from":   {},
+	"if":     {},
+	"import": {},
+	"in":     {},
+	"is":     {},
+	"lambda": {},
+	"None":   {},
+	"not":    {},
+	"or":     {},
+	"pass":   {},
+	"return": {},
+	"True":   {},
+}
+
+// operators is ordered so that the longest operators are matched first.
+var operators = []string{
+	"**=", "//=", ">>=", "<<=",
+	"**", "//", "==", "!=", "<=", ">=", "<<", ">>", "->",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=",
+	"+", "-", "*", "/", "%", "<", ">", "=", "(", ")", "[", "]",
+	"{", "}", ",", ":", ".", ";", "@", "&", "|", "^", "~",
+}
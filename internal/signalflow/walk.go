@@ -0,0 +1,91 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalflow
+
+// Inspect traverses the syntax tree in depth first order,
+// fn is called for each node and the children are only
+// visited when fn returns true.
+func Inspect(node Node, fn func(Node) bool) {
+	if node == nil || !fn(node) {
+		return
+	}
+
+	stmts := func(body []Stmt) {
+		for _, s := range body {
+			Inspect(s, fn)
+		}
+	}
+	exprs := func(values ...Expr) {
+		for _, e := range values {
+			if e != nil {
+				Inspect(e, fn)
+			}
+		}
+	}
+	params := func(values []*Param) {
+		for _, p := range values {
+			exprs(p.Name, p.Default)
+		}
+	}
+
+	switch v := node.(type) {
+	case *Program:
+		stmts(v.Body)
+	case *ExprStmt:
+		exprs(v.X)
+	case *AssignStmt:
+		exprs(v.Targets...)
+		exprs(v.Value)
+	case *FuncDef:
+		exprs(v.Name)
+		params(v.Params)
+		stmts(v.Body)
+	case *ReturnStmt:
+		exprs(v.Value)
+	case *IfStmt:
+		exprs(v.Cond)
+		stmts(v.Body)
+		stmts(v.Else)
+	case *ForStmt:
+		exprs(v.Target, v.Iter)
+		stmts(v.Body)
+	case *ListLit:
+		exprs(v.Elts...)
+	case *TupleLit:
+		exprs(v.Elts...)
+	case *DictLit:
+		for i := range v.Keys {
+			exprs(v.Keys[i], v.Values[i])
+		}
+	case *UnaryExpr:
+		exprs(v.X)
+	case *BinaryExpr:
+		exprs(v.X, v.Y)
+	case *CondExpr:
+		exprs(v.Body, v.Cond, v.Else)
+	case *LambdaExpr:
+		params(v.Params)
+		exprs(v.Body)
+	case *CallExpr:
+		exprs(v.Func)
+		exprs(v.Args...)
+		for _, kw := range v.Keywords {
+			exprs(kw.Name, kw.Value)
+		}
+	case *AttrExpr:
+		exprs(v.X, v.Name)
+	case *IndexExpr:
+		exprs(v.X, v.Index)
+	case *SliceExpr:
+		exprs(v.Low, v.High, v.Step)
+	case *StarExpr:
+		exprs(v.X)
+	case *CompExpr:
+		for _, loop := range v.Loops {
+			exprs(loop.Target, loop.Iter)
+			exprs(loop.Ifs...)
+		}
+		exprs(v.Elt)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfext
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// IsConfigured reports if the attribute is set as part of the configuration,
+// unlike `GetOk`, values provided by `Default` or `DefaultFunc` are not considered set.
+func IsConfigured(data *schema.ResourceData, key string) bool {
+	v, diags := data.GetRawConfigAt(cty.GetAttrPath(key))
+	return !diags.HasError() && !v.IsNull()
+}
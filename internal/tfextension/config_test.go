@@ -0,0 +1,79 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfext
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsConfigured(t *testing.T) {
+	t.Parallel()
+
+	res := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"api_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SFX_API_URL", "https://api.signalfx.com"),
+			},
+			"realm": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		config cty.Value
+		key    string
+		expect bool
+	}{
+		{
+			name:   "no config",
+			config: cty.NilVal,
+			key:    "api_url",
+			expect: false,
+		},
+		{
+			name: "value set",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"api_url": cty.StringVal("https://api.us1.signalfx.com"),
+				"realm":   cty.NullVal(cty.String),
+			}),
+			key:    "api_url",
+			expect: true,
+		},
+		{
+			name: "value not set",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"api_url": cty.StringVal("https://api.us1.signalfx.com"),
+				"realm":   cty.NullVal(cty.String),
+			}),
+			key:    "realm",
+			expect: false,
+		},
+		{
+			name: "value unknown",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"api_url": cty.NullVal(cty.String),
+				"realm":   cty.UnknownVal(cty.String),
+			}),
+			key:    "realm",
+			expect: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := res.Data(&terraform.InstanceState{RawConfig: tc.config})
+			assert.Equal(t, tc.expect, IsConfigured(data, tc.key), "Must match the expected value")
+		})
+	}
+}
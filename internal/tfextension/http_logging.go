@@ -0,0 +1,188 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfext
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// HTTPLogFunc writes a single HTTP log entry.
+type HTTPLogFunc func(ctx context.Context, msg string, fields LogFields)
+
+// HTTPLogger logs the API requests made by the provider with any credentials redacted.
+//
+// Each entry includes the correlation ID shared by all attempts of a request,
+// the resource address set by [WithResourceAddress], the latency and the retry count
+// so that slow or failing requests can be traced back to a resource.
+type HTTPLogger struct {
+	logf    HTTPLogFunc
+	maxBody int
+}
+
+type HTTPLoggerOption func(l *HTTPLogger)
+
+// WithHTTPLogFunc replaces the default `tflog` subsystem
+// used to write entries with the provided function.
+func WithHTTPLogFunc(fn HTTPLogFunc) HTTPLoggerOption {
+	return func(l *HTTPLogger) {
+		l.logf = fn
+	}
+}
+
+// WithHTTPLogMaxBody sets the maximum number of bytes of a body that are logged,
+// larger bodies are replaced with their size. Defaults to 64 KiB.
+func WithHTTPLogMaxBody(size int) HTTPLoggerOption {
+	return func(l *HTTPLogger) {
+		l.maxBody = size
+	}
+}
+
+// NewHTTPLogger returns a logger that writes debug entries to the `tflog` subsystem.
+func NewHTTPLogger(subsystem string, opts ...HTTPLoggerOption) *HTTPLogger {
+	l := &HTTPLogger{
+		logf: func(ctx context.Context, msg string, fields LogFields) {
+			ctx = tflog.NewSubsystem(ctx, subsystem)
+			tflog.SubsystemDebug(ctx, subsystem, msg, fields)
+		},
+		maxBody: 64 << 10,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// PrintfHTTPLogFunc writes entries using the standard logger, it is used by
+// the SDK provider since most of its requests do not use a `tflog` context.
+func PrintfHTTPLogFunc(name string) HTTPLogFunc {
+	return func(_ context.Context, msg string, fields LogFields) {
+		buf, _ := json.Marshal(fields)
+		log.Printf("[DEBUG] %s: %s: %s", name, msg, buf)
+	}
+}
+
+type httpTrace struct {
+	id       string
+	attempts atomic.Int32
+}
+
+type httpTraceKey struct{}
+
+// Trace assigns the correlation ID shared by each attempt of a request and
+// logs the total latency and retry count once it completes.
+//
+// It is expected to wrap the retrying client, with [HTTPLogger.Wrap]
+// used for the transport of each attempt.
+func (l *HTTPLogger) Trace(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		trace := &httpTrace{id: rand.Text()}
+		req = req.WithContext(context.WithValue(req.Context(), httpTraceKey{}, trace))
+
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+
+		fields := l.fields(req, trace).
+			Duration("tf_http_latency", time.Since(start)).
+			Field("tf_http_retries", max(trace.attempts.Load()-1, 0)).
+			Error(err)
+		if resp != nil {
+			fields.Field("tf_http_res_status_code", resp.StatusCode)
+		}
+		l.logf(req.Context(), "Completed HTTP request", fields)
+
+		return resp, err
+	})
+}
+
+// Wrap logs each request sent by next and the response returned.
+func (l *HTTPLogger) Wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		trace, ok := req.Context().Value(httpTraceKey{}).(*httpTrace)
+		if !ok {
+			trace = &httpTrace{id: rand.Text()}
+		}
+		retries := trace.attempts.Add(1) - 1
+
+		fields := l.fields(req, trace).
+			Field("tf_http_op_type", "request").
+			Field("tf_http_retries", retries).
+			Field("tf_http_req_headers", RedactHeader(req.Header))
+		if body, err := l.readBody(&req.Body); err != nil {
+			fields.Error(err)
+		} else {
+			fields.Field("tf_http_req_body", body)
+		}
+		l.logf(req.Context(), "Sending HTTP request", fields)
+
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+
+		fields = l.fields(req, trace).
+			Field("tf_http_op_type", "response").
+			Field("tf_http_retries", retries).
+			Duration("tf_http_latency", time.Since(start))
+		if err != nil {
+			l.logf(req.Context(), "Failed HTTP request", fields.Error(err))
+			return nil, err
+		}
+
+		fields.Field("tf_http_res_status_code", resp.StatusCode).
+			Field("tf_http_res_headers", RedactHeader(resp.Header))
+		if body, err := l.readBody(&resp.Body); err != nil {
+			fields.Error(err)
+		} else {
+			fields.Field("tf_http_res_body", body)
+		}
+		l.logf(req.Context(), "Received HTTP response", fields)
+
+		return resp, nil
+	})
+}
+
+func (l *HTTPLogger) fields(req *http.Request, trace *httpTrace) LogFields {
+	fields := NewLogFields().
+		Field("tf_http_trans_id", trace.id).
+		Field("tf_http_req_method", req.Method).
+		Field("tf_http_req_uri", RedactURL(req.URL))
+	if addr := ResourceAddress(req.Context()); addr != "" {
+		fields.Field("tf_resource_address", addr)
+	}
+	return fields
+}
+
+// readBody returns the redacted body and replaces it so it can be read again.
+func (l *HTTPLogger) readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return "", err
+	}
+	if err := (*body).Close(); err != nil {
+		return "", err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	if len(data) > l.maxBody {
+		return fmt.Sprintf("<%d bytes not logged>", len(data)), nil
+	}
+	return RedactBody(data), nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
@@ -0,0 +1,154 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfext
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type logEntry struct {
+	msg    string
+	fields LogFields
+}
+
+func TestHTTPLogger(t *testing.T) {
+	t.Parallel()
+
+	var attempts int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id":"AAA","name":"slack","webhookUrl":"https://hooks.slack.com","secret":"abc"}`)
+	}))
+	t.Cleanup(s.Close)
+
+	var (
+		mu      sync.Mutex
+		entries []logEntry
+	)
+	l := NewHTTPLogger("signalfx", WithHTTPLogFunc(func(_ context.Context, msg string, fields LogFields) {
+		mu.Lock()
+		defer mu.Unlock()
+		entries = append(entries, logEntry{msg: msg, fields: fields})
+	}))
+
+	// The inner client stands in for the retrying client,
+	// making a second attempt when the first is unavailable.
+	inner := &http.Client{Transport: l.Wrap(http.DefaultTransport)}
+	client := &http.Client{Transport: l.Trace(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		for {
+			retry := req.Clone(req.Context())
+			retry.Body = io.NopCloser(strings.NewReader(`{"name":"slack","apiKey":"abc"}`))
+			resp, err := inner.Do(retry)
+			if err != nil || resp.StatusCode != http.StatusServiceUnavailable {
+				return resp, err
+			}
+			_ = resp.Body.Close()
+		}
+	}))}
+
+	ctx := WithResourceAddress(context.Background(), "signalfx_slack_integration", "AAA")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.URL+"/v2/integration/AAA", http.NoBody)
+	require.NoError(t, err, "Must create request")
+	req.Header.Set("X-SF-Token", "my-token")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err, "Must complete request")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "Must read the response body")
+	require.NoError(t, resp.Body.Close(), "Must close the response body")
+	assert.Contains(t, string(body), `"secret":"abc"`, "Must not modify the response body")
+
+	msgs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		msgs = append(msgs, e.msg)
+	}
+	require.Equal(t, []string{
+		"Sending HTTP request",
+		"Received HTTP response",
+		"Sending HTTP request",
+		"Received HTTP response",
+		"Completed HTTP request",
+	}, msgs, "Must match the expected log entries")
+
+	id := entries[0].fields["tf_http_trans_id"]
+	assert.NotEmpty(t, id, "Must set a correlation ID")
+	for i, e := range entries {
+		assert.Equal(t, id, e.fields["tf_http_trans_id"], "Must share the correlation ID for entry %d", i)
+		assert.Equal(t, "signalfx_slack_integration.AAA", e.fields["tf_resource_address"], "Must set the resource address for entry %d", i)
+		assert.Equal(t, http.MethodPut, e.fields["tf_http_req_method"], "Must set the method for entry %d", i)
+	}
+
+	assert.Equal(t, int32(0), entries[0].fields["tf_http_retries"], "Must not count the first attempt as a retry")
+	assert.Equal(t, int32(1), entries[2].fields["tf_http_retries"], "Must count the second attempt as a retry")
+	assert.Equal(t, int32(1), entries[4].fields["tf_http_retries"], "Must report the total retries")
+	assert.Equal(t, http.StatusServiceUnavailable, entries[1].fields["tf_http_res_status_code"], "Must report the attempt status")
+	assert.Equal(t, http.StatusOK, entries[4].fields["tf_http_res_status_code"], "Must report the final status")
+	assert.NotEmpty(t, entries[4].fields["tf_http_latency"], "Must report the total latency")
+
+	assert.Equal(t, RedactedValue, entries[0].fields["tf_http_req_headers"].(map[string]string)["X-Sf-Token"], "Must redact the token header")
+	assert.Equal(t, `{"apiKey":"REDACTED","name":"slack"}`, entries[0].fields["tf_http_req_body"], "Must redact the request body")
+	assert.Equal(t,
+		`{"id":"AAA","name":"slack","secret":"REDACTED","webhookUrl":"REDACTED"}`,
+		entries[3].fields["tf_http_res_body"],
+		"Must redact the response body",
+	)
+
+	for _, e := range entries {
+		for k, v := range e.fields {
+			if s, ok := v.(string); ok {
+				assert.NotContains(t, s, "my-token", "Must not log the token in field %q", k)
+			}
+		}
+	}
+}
+
+func TestHTTPLoggerWithoutTrace(t *testing.T) {
+	t.Parallel()
+
+	var entries []logEntry
+	l := NewHTTPLogger("signalfx",
+		WithHTTPLogMaxBody(4),
+		WithHTTPLogFunc(func(_ context.Context, msg string, fields LogFields) {
+			entries = append(entries, logEntry{msg: msg, fields: fields})
+		}),
+	)
+
+	client := &http.Client{Transport: l.Wrap(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"name":"example"}`)),
+			Request:    req,
+		}, nil
+	}))}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://api.signalfx.invalid/v2/team?token=abc", http.NoBody)
+	require.NoError(t, err, "Must create request")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err, "Must complete request")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "Must read the response body")
+	require.NoError(t, resp.Body.Close(), "Must close the response body")
+
+	assert.JSONEq(t, `{"name":"example"}`, string(body), "Must restore the response body")
+	require.Len(t, entries, 2, "Must log the request and response")
+	assert.NotEmpty(t, entries[0].fields["tf_http_trans_id"], "Must set a correlation ID")
+	assert.NotContains(t, entries[0].fields, "tf_resource_address", "Must not set an unknown resource address")
+	assert.Equal(t, "http://api.signalfx.invalid/v2/team?token=REDACTED", entries[0].fields["tf_http_req_uri"], "Must redact the query")
+	assert.Equal(t, "<18 bytes not logged>", entries[1].fields["tf_http_res_body"], "Must not log bodies over the limit")
+}
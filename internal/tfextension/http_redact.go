@@ -0,0 +1,185 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfext
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// RedactedValue replaces any sensitive value before it is logged or stored.
+const RedactedValue = "REDACTED"
+
+// sensitiveFields are the names, compared in lower case, of the
+// JSON fields and query parameters that hold credentials.
+//
+// Generic names such as "key" are not included since they are also
+// used by dimension and filter keys that must be kept as they are,
+// see [integrationFields] for how those are handled.
+var sensitiveFields = map[string]struct{}{
+	"accesstoken":    {},
+	"apikey":         {},
+	"appkey":         {},
+	"apitoken":       {},
+	"authtoken":      {},
+	"clientsecret":   {},
+	"integrationkey": {},
+	"password":       {},
+	"posturl":        {},
+	"privatekey":     {},
+	"projectkey":     {},
+	"secret":         {},
+	"secretkey":      {},
+	"sessiontoken":   {},
+	"token":          {},
+	"userpassword":   {},
+	"webhookurl":     {},
+}
+
+// sensitiveObjects are the names, compared in lower case, of the JSON
+// fields whose values all hold credentials, such as the headers sent by webhooks.
+var sensitiveObjects = map[string]struct{}{
+	"headers": {},
+}
+
+// integrationFields are the names, compared in lower case, of the JSON fields
+// that only hold credentials when the object is an integration payload,
+// which is identified by it also having one of the integrationMarkers.
+var (
+	integrationFields  = []string{"key"}
+	integrationMarkers = []string{"authmethod", "token"}
+)
+
+// sensitiveHeaders are the canonical names of the headers that hold credentials.
+var sensitiveHeaders = map[string]struct{}{
+	"Authorization":       {},
+	"Cookie":              {},
+	"Proxy-Authorization": {},
+	"Set-Cookie":          {},
+	"X-Sf-Token":          {},
+}
+
+// IsSensitiveField reports if the JSON field or query parameter is known to hold credentials.
+func IsSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	if _, ok := sensitiveFields[name]; ok {
+		return true
+	}
+	return strings.Contains(name, "secret") || strings.Contains(name, "password")
+}
+
+// RedactJSON replaces the string values of any sensitive fields within v,
+// which is expected to be the result of decoding JSON into an `any`.
+func RedactJSON(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		integration := isIntegrationPayload(val)
+		for k, field := range val {
+			if _, ok := field.(string); ok && (IsSensitiveField(k) || integration && slices.Contains(integrationFields, strings.ToLower(k))) {
+				val[k] = RedactedValue
+				continue
+			}
+			if _, ok := sensitiveObjects[strings.ToLower(k)]; ok {
+				val[k] = redactValues(field)
+				continue
+			}
+			val[k] = RedactJSON(field)
+		}
+		return val
+	case []any:
+		for i := range val {
+			val[i] = RedactJSON(val[i])
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// isIntegrationPayload reports if the object has any of the integrationMarkers,
+// so that the generic integrationFields it holds are treated as credentials.
+func isIntegrationPayload(obj map[string]any) bool {
+	for k := range obj {
+		if slices.Contains(integrationMarkers, strings.ToLower(k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValues replaces every string value within v.
+func redactValues(v any) any {
+	switch val := v.(type) {
+	case string:
+		return RedactedValue
+	case map[string]any:
+		for k, field := range val {
+			val[k] = redactValues(field)
+		}
+		return val
+	case []any:
+		for i := range val {
+			val[i] = redactValues(val[i])
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// RedactBody returns the body with any sensitive JSON fields redacted,
+// a body that is not JSON is replaced with its size since it can not be redacted.
+func RedactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("<%d bytes of non JSON content>", len(body))
+	}
+	data, err := json.Marshal(RedactJSON(v))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes of non JSON content>", len(body))
+	}
+	return string(data)
+}
+
+// RedactHeader returns a copy of the headers with each sensitive value redacted.
+func RedactHeader(h http.Header) map[string]string {
+	values := make(map[string]string, len(h))
+	for k, v := range h {
+		if _, ok := sensitiveHeaders[http.CanonicalHeaderKey(k)]; ok || IsSensitiveField(k) {
+			values[k] = RedactedValue
+			continue
+		}
+		values[k] = strings.Join(v, ", ")
+	}
+	return values
+}
+
+// RedactURL returns the url with the user info and
+// the values of any sensitive query parameters redacted.
+func RedactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	r := *u
+	if r.User != nil {
+		r.User = url.User(RedactedValue)
+	}
+	if r.RawQuery != "" {
+		q := r.Query()
+		for k := range q {
+			if IsSensitiveField(k) {
+				q.Set(k, RedactedValue)
+			}
+		}
+		r.RawQuery = q.Encode()
+	}
+	return r.String()
+}
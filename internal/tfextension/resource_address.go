@@ -0,0 +1,97 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfext
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type resourceAddressKey struct{}
+
+// WithResourceAddress returns a context that includes the address of the resource
+// making API requests so that it is included with each logged request.
+//
+// Terraform does not send the configured address to the provider,
+// so the address is the resource type followed by its ID once it is known.
+func WithResourceAddress(ctx context.Context, typeName, id string) context.Context {
+	addr := typeName
+	if id != "" {
+		addr += "." + id
+	}
+	return context.WithValue(ctx, resourceAddressKey{}, addr)
+}
+
+// ResourceAddress returns the address set by [WithResourceAddress].
+func ResourceAddress(ctx context.Context) string {
+	addr, _ := ctx.Value(resourceAddressKey{}).(string)
+	return addr
+}
+
+// ResourceAddressScoper is implemented by the provider meta so that the operations
+// that do not accept a context, and so make requests using [context.TODO],
+// can still include the resource address with each logged request.
+type ResourceAddressScoper interface {
+	// WithResourceAddressContext returns a copy of the meta whose requests
+	// include the resource address set within ctx.
+	WithResourceAddressContext(ctx context.Context) any
+}
+
+// NewResourceAddressTransport returns a [http.RoundTripper] that sets the address
+// on each request that does not already include a resource address.
+func NewResourceAddressTransport(addr string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if ResourceAddress(req.Context()) == "" {
+			req = req.WithContext(context.WithValue(req.Context(), resourceAddressKey{}, addr))
+		}
+		return next.RoundTrip(req)
+	})
+}
+
+// DecorateResourceAddress updates the operations of res to set the resource address
+// as part of the context. The operations that do not accept a context are passed
+// the meta returned by [ResourceAddressScoper] when the meta implements it.
+func DecorateResourceAddress(typeName string, res *schema.Resource) *schema.Resource {
+	if res == nil {
+		return nil
+	}
+
+	res.Create = wrapResourceAddress(typeName, res.Create)
+	res.Read = wrapResourceAddress(typeName, res.Read)
+	res.Update = wrapResourceAddress(typeName, res.Update)
+	res.Delete = wrapResourceAddress(typeName, res.Delete)
+
+	wrap := func(fn func(context.Context, *schema.ResourceData, any) diag.Diagnostics) func(context.Context, *schema.ResourceData, any) diag.Diagnostics {
+		if fn == nil {
+			return nil
+		}
+		return func(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+			return fn(WithResourceAddress(ctx, typeName, data.Id()), data, meta)
+		}
+	}
+
+	res.CreateContext = wrap(res.CreateContext)
+	res.ReadContext = wrap(res.ReadContext)
+	res.UpdateContext = wrap(res.UpdateContext)
+	res.DeleteContext = wrap(res.DeleteContext)
+	return res
+}
+
+func wrapResourceAddress[Func schema.CreateFunc | schema.ReadFunc | schema.UpdateFunc | schema.DeleteFunc](typeName string, fn Func) Func {
+	if fn == nil {
+		return nil
+	}
+	return func(data *schema.ResourceData, meta any) error {
+		if scoper, ok := meta.(ResourceAddressScoper); ok {
+			meta = scoper.WithResourceAddressContext(WithResourceAddress(context.Background(), typeName, data.Id()))
+		}
+		return fn(data, meta)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfext
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceAddress(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, ResourceAddress(context.Background()), "Must not have an address")
+	assert.Equal(t, "signalfx_team", ResourceAddress(WithResourceAddress(context.Background(), "signalfx_team", "")), "Must only use the type without an ID")
+	assert.Equal(t, "signalfx_team.AAA", ResourceAddress(WithResourceAddress(context.Background(), "signalfx_team", "AAA")), "Must include the ID")
+}
+
+func TestDecorateResourceAddress(t *testing.T) {
+	t.Parallel()
+
+	var actual string
+	res := DecorateResourceAddress("signalfx_team", &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		ReadContext: func(ctx context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+			actual = ResourceAddress(ctx)
+			return nil
+		},
+	})
+	require.Nil(t, res.CreateContext, "Must not set unset operations")
+
+	data := res.TestResourceData()
+	data.SetId("AAA")
+	assert.Empty(t, res.ReadContext(context.Background(), data, nil), "Must not return diagnostics")
+	assert.Equal(t, "signalfx_team.AAA", actual, "Must set the resource address")
+
+	assert.Nil(t, DecorateResourceAddress("signalfx_team", nil), "Must allow a nil resource")
+}
+
+type addressScoper struct {
+	addr string
+}
+
+func (addressScoper) WithResourceAddressContext(ctx context.Context) any {
+	return addressScoper{addr: ResourceAddress(ctx)}
+}
+
+func TestDecorateResourceAddressLegacy(t *testing.T) {
+	t.Parallel()
+
+	var actual any
+	res := DecorateResourceAddress("signalfx_team", &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Read: func(_ *schema.ResourceData, meta any) error {
+			actual = meta
+			return nil
+		},
+	})
+	require.Nil(t, res.Create, "Must not set unset operations")
+
+	data := res.TestResourceData()
+	data.SetId("AAA")
+	require.NoError(t, res.Read(data, addressScoper{}), "Must not error")
+	assert.Equal(t, addressScoper{addr: "signalfx_team.AAA"}, actual, "Must scope the meta to the resource address")
+
+	require.NoError(t, res.Read(data, "meta"), "Must not error")
+	assert.Equal(t, "meta", actual, "Must pass through a meta that can not be scoped")
+}
+
+func TestNewResourceAddressTransport(t *testing.T) {
+	t.Parallel()
+
+	var actual []string
+	rt := NewResourceAddressTransport("signalfx_team.AAA", roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		actual = append(actual, ResourceAddress(req.Context()))
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/team/AAA", nil)
+	_, err := rt.RoundTrip(req)
+	require.NoError(t, err, "Must not error")
+	assert.Empty(t, ResourceAddress(req.Context()), "Must not modify the original request")
+
+	_, err = rt.RoundTrip(req.WithContext(WithResourceAddress(req.Context(), "signalfx_team", "BBB")))
+	require.NoError(t, err, "Must not error")
+
+	assert.Equal(t, []string{"signalfx_team.AAA", "signalfx_team.BBB"}, actual, "Must only set the address when it is missing")
+}
@@ -6,6 +6,7 @@ package tftest
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
@@ -20,6 +21,7 @@ import (
 type AcceptanceHandler struct {
 	beforeAll func()
 	provider  *schema.Provider
+	api       http.Handler
 }
 
 // AcceptanceHandlerOption is used to supply additional values to a test case.
@@ -43,6 +45,15 @@ func WithAcceptanceBeforeAll(before func()) AcceptanceHandlerOption {
 	}
 }
 
+// WithAcceptanceFakeAPI runs the test against the provided handler instead of
+// a real organization, for example the stateful server from `internal/fakeapi`.
+// The environment variables are no longer required to run the test.
+func WithAcceptanceFakeAPI(api http.Handler) AcceptanceHandlerOption {
+	return func(ah *AcceptanceHandler) {
+		ah.api = api
+	}
+}
+
 func NewAcceptanceHandler(opts ...AcceptanceHandlerOption) *AcceptanceHandler {
 	ah := &AcceptanceHandler{
 		provider: &schema.Provider{
@@ -68,11 +79,13 @@ func (ah *AcceptanceHandler) Validate() (errs error) {
 
 func (ah *AcceptanceHandler) Test(t *testing.T, steps []resource.TestStep) {
 	var msgs []string
-	if _, set := os.LookupEnv("SFX_AUTH_TOKEN"); !set {
-		msgs = append(msgs, fmt.Sprintf("missing environment variable %q", "SFX_AUTH_TOKEN"))
-	}
-	if _, set := os.LookupEnv("SFX_API_URL"); !set {
-		msgs = append(msgs, fmt.Sprintf("missing environment variable %q", "SFX_API_URL"))
+	if ah.api == nil {
+		if _, set := os.LookupEnv("SFX_AUTH_TOKEN"); !set {
+			msgs = append(msgs, fmt.Sprintf("missing environment variable %q", "SFX_AUTH_TOKEN"))
+		}
+		if _, set := os.LookupEnv("SFX_API_URL"); !set {
+			msgs = append(msgs, fmt.Sprintf("missing environment variable %q", "SFX_API_URL"))
+		}
 	}
 	if len(msgs) != 0 {
 		t.Skip(
@@ -96,6 +109,10 @@ func (ah *AcceptanceHandler) Test(t *testing.T, steps []resource.TestStep) {
 	// See https://github.com/hashicorp/terraform-plugin-sdk/issues/1384 for more details.
 	t.Setenv("TF_ACC", "1")
 
+	if ah.api != nil {
+		ah.provider.ConfigureContextFunc = newFakeAPIConfigure(t, ah.api)
+	}
+
 	tc := resource.TestCase{
 		IsUnitTest: false,
 		ProviderFactories: map[string]func() (*schema.Provider, error){
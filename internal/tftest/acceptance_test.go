@@ -4,6 +4,7 @@
 package tftest
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -72,6 +73,7 @@ func TestAcceptanceHandlerTest(t *testing.T) {
 	for _, tc := range []struct {
 		name    string
 		env     map[string]string
+		opts    []AcceptanceHandlerOption
 		skipped bool
 	}{
 		{
@@ -87,6 +89,14 @@ func TestAcceptanceHandlerTest(t *testing.T) {
 			},
 			skipped: false,
 		},
+		{
+			name: "fake api set",
+			env:  map[string]string{},
+			opts: []AcceptanceHandlerOption{
+				WithAcceptanceFakeAPI(http.NotFoundHandler()),
+			},
+			skipped: false,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			CleanEnvVars(t)
@@ -95,11 +105,11 @@ func TestAcceptanceHandlerTest(t *testing.T) {
 				t.Setenv(k, v)
 			}
 
-			handler := NewAcceptanceHandler(
+			handler := NewAcceptanceHandler(append([]AcceptanceHandlerOption{
 				WithAcceptanceResources(map[string]*schema.Resource{
 					"nop": {},
 				}),
-			)
+			}, tc.opts...)...)
 
 			t.Cleanup(func() {
 				assert.Equal(t, tc.skipped, t.Skipped(), "Must have been skipped")
@@ -18,6 +18,27 @@ func CleanEnvVars(t *testing.T) {
 	for _, k := range []string{
 		"SFX_AUTH_TOKEN",
 		"SFX_API_URL",
+		"SFX_REALM",
+		"SFX_PROFILE",
+		"SFX_CUSTOM_APP_URL",
+		"SFX_EMAIL",
+		"SFX_PASSWORD",
+		"SFX_ORGANIZATION_ID",
+		"SFX_TIMEOUT_SECONDS",
+		"SFX_RETRY_MAX_ATTEMPTS",
+		"SFX_RETRY_WAIT_MIN_SECONDS",
+		"SFX_RETRY_WAIT_MAX_SECONDS",
+		"SFX_MAX_REQUESTS_PER_SECOND",
+		"SFX_MAX_CONCURRENT_REQUESTS",
+		"SFX_PROXY_URL",
+		"SFX_CA_CERT_FILE",
+		"SFX_CA_CERT_PEM",
+		"SFX_CLIENT_CERT",
+		"SFX_CLIENT_KEY",
+		"SFX_INSECURE_SKIP_VERIFY",
+		"SFX_FEATURE_PREVIEW",
+		"SFX_TAGS",
+		"SFX_TEAMS",
 	} {
 		if v, ok := os.LookupEnv(k); ok {
 			orig[k] = v
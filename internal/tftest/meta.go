@@ -48,6 +48,7 @@ func NewTestHTTPMockMeta(routes map[string]http.HandlerFunc) func(testing.TB) an
 			APIURL:       s.URL,
 			CustomAppURL: s.URL,
 			Client:       sfx,
+			HTTPClient:   s.Client(),
 		}
 	}
 }
@@ -70,3 +71,26 @@ func newAcceptanceConfigure(context.Context, *schema.ResourceData) (any, diag.Di
 	}
 	return meta, nil
 }
+
+// newFakeAPIConfigure starts a test server using the provided handler
+// that is used in place of a real organization for acceptance tests.
+func newFakeAPIConfigure(t testing.TB, api http.Handler) schema.ConfigureContextFunc {
+	s := httptest.NewServer(api)
+	t.Cleanup(s.Close)
+
+	return func(context.Context, *schema.ResourceData) (any, diag.Diagnostics) {
+		meta := &pmeta.Meta{
+			APIURL:       s.URL,
+			AuthToken:    t.Name(),
+			CustomAppURL: s.URL,
+			HTTPClient:   s.Client(),
+		}
+
+		meta.Client, _ = signalfx.NewClient(
+			meta.AuthToken,
+			signalfx.HTTPClient(s.Client()),
+			signalfx.APIUrl(meta.APIURL),
+		)
+		return meta, nil
+	}
+}
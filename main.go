@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 
 	internalframework "github.com/splunk-terraform/terraform-provider-signalfx/internal/framework"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/ratelimit"
 	"github.com/splunk-terraform/terraform-provider-signalfx/signalfx"
 )
 
@@ -45,7 +46,7 @@ func main() {
 		opts = append(opts, tf5server.WithManagedDebug())
 	}
 
-	if err = tf5server.Serve(ProviderRegistry, mux.ProviderServer, opts...); err != nil {
+	if err = tf5server.Serve(ProviderRegistry, ratelimit.WithSummaryOnStop(mux.ProviderServer), opts...); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -8,9 +8,12 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func handleIntegrationChange(err error, d *schema.ResourceData, in interface{}) bool {
@@ -40,3 +43,70 @@ func logIntegrationCreateRequest(out interface{}, serviceName string) {
 func logIntegrationUpdateRequest(out interface{}, serviceName string) {
 	logIntegrationData("[DEBUG] SignalFx: Update %s Integration Payload: %s", serviceName, out)
 }
+
+// withWriteOnlyCredentials adds a write only variant of each named credential,
+// `<name>_wo`, along with `<name>_wo_version` which is incremented to send a new value.
+// The write only value is never stored in the plan or state so a version is required
+// for Terraform to detect that the credential has been rotated.
+func withWriteOnlyCredentials(s map[string]*schema.Schema, names ...string) map[string]*schema.Schema {
+	for _, name := range names {
+		var (
+			field   = s[name]
+			wo      = name + "_wo"
+			version = name + "_wo_version"
+		)
+
+		for _, other := range s {
+			if slices.Contains(other.ConflictsWith, name) {
+				other.ConflictsWith = append(other.ConflictsWith, wo)
+			}
+		}
+
+		s[wo] = &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			WriteOnly:     true,
+			Sensitive:     true,
+			ConflictsWith: slices.Clone(field.ConflictsWith),
+			RequiredWith:  []string{version},
+			Description:   fmt.Sprintf("Write only variant of `%s` that is never stored in the plan or state, requires Terraform 1.11 or newer", name),
+		}
+		s[version] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			RequiredWith: []string{wo},
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  fmt.Sprintf("Version of `%s`, increment this value to update the credential", wo),
+		}
+
+		if field.Required {
+			field.Required, field.Optional = false, true
+			field.ExactlyOneOf = []string{name, wo}
+			s[wo].ExactlyOneOf = []string{name, wo}
+		} else {
+			field.ConflictsWith = append(field.ConflictsWith, wo)
+			s[wo].ConflictsWith = append(s[wo].ConflictsWith, name)
+		}
+	}
+	return s
+}
+
+// getIntegrationCredential returns the value of the named credential,
+// reading the write only variant from the configuration when it is used instead.
+func getIntegrationCredential(d *schema.ResourceData, name string) string {
+	if v, ok := d.GetOk(name); ok {
+		return v.(string)
+	}
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(name + "_wo"))
+	if diags.HasError() || !v.Type().Equals(cty.String) || v.IsNull() || !v.IsKnown() {
+		return ""
+	}
+	return v.AsString()
+}
+
+// usesWriteOnlyCredential reports if the named credential is managed by its write only variant,
+// in which case the value returned by the API must not be stored in state.
+func usesWriteOnlyCredential(d *schema.ResourceData, name string) bool {
+	_, ok := d.GetOk(name + "_wo_version")
+	return ok
+}
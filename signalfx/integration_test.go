@@ -6,9 +6,14 @@ package signalfx
 import (
 	"context"
 	"fmt"
+	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func testAccCreateCheckIntegrationResource(resourceName string) resource.TestCheckFunc {
@@ -49,3 +54,102 @@ func testAccCreateCheckDestroyIntegrationResource(resourceName string) resource.
 		return nil
 	}
 }
+
+func TestWithWriteOnlyCredentials(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		resource    *schema.Resource
+		credentials []string
+	}{
+		{name: "aws", resource: integrationAWSResource(), credentials: []string{"key"}},
+		{name: "azure", resource: integrationAzureResource(), credentials: []string{"secret_key"}},
+		{name: "jira", resource: integrationJiraResource(), credentials: []string{"api_token", "password"}},
+		{name: "opsgenie", resource: integrationOpsgenieResource(), credentials: []string{"api_key"}},
+		{name: "pagerduty", resource: integrationPagerDutyResource(), credentials: []string{"api_key"}},
+		{name: "service now", resource: integrationServiceNowResource(), credentials: []string{"password"}},
+		{name: "slack", resource: integrationSlackResource(), credentials: []string{"webhook_url"}},
+		{name: "victor ops", resource: integrationVictorOpsResource(), credentials: []string{"post_url"}},
+		{name: "webhook", resource: integrationWebhookResource(), credentials: []string{"shared_secret"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.NoError(t, tc.resource.InternalValidate(nil, true), "Must be a valid resource schema")
+			for _, name := range tc.credentials {
+				wo, ok := tc.resource.Schema[name+"_wo"]
+				require.True(t, ok, "Must define the write only credential %q", name)
+				assert.True(t, wo.WriteOnly, "Must not store %q in state", name+"_wo")
+				assert.True(t, wo.Sensitive, "Must mark %q as sensitive", name+"_wo")
+				assert.Contains(t, tc.resource.Schema, name+"_wo_version", "Must define the credential version")
+				assert.False(t, tc.resource.Schema[name].Required, "Must allow %q to be omitted", name)
+			}
+		})
+	}
+}
+
+func TestGetIntegrationCredential(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		state  *terraform.InstanceState
+		expect string
+	}{
+		{
+			name: "credential set",
+			state: &terraform.InstanceState{
+				Attributes: map[string]string{"api_key": "my-key"},
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"api_key":    cty.StringVal("my-key"),
+					"api_key_wo": cty.NullVal(cty.String),
+				}),
+			},
+			expect: "my-key",
+		},
+		{
+			name: "write only credential set",
+			state: &terraform.InstanceState{
+				Attributes: map[string]string{"api_key_wo_version": "1"},
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"api_key":    cty.NullVal(cty.String),
+					"api_key_wo": cty.StringVal("my-write-only-key"),
+				}),
+			},
+			expect: "my-write-only-key",
+		},
+		{
+			name: "no credential set",
+			state: &terraform.InstanceState{
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"api_key":    cty.NullVal(cty.String),
+					"api_key_wo": cty.NullVal(cty.String),
+				}),
+			},
+			expect: "",
+		},
+		{
+			name:   "no configuration",
+			state:  &terraform.InstanceState{},
+			expect: "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := integrationPagerDutyResource().Data(tc.state)
+			assert.Equal(t, tc.expect, getIntegrationCredential(d, "api_key"), "Must return the expected credential")
+		})
+	}
+}
+
+func TestUsesWriteOnlyCredential(t *testing.T) {
+	t.Parallel()
+
+	d := integrationWebhookResource().Data(&terraform.InstanceState{
+		Attributes: map[string]string{"shared_secret_wo_version": "2"},
+	})
+	assert.True(t, usesWriteOnlyCredential(d, "shared_secret"), "Must report the write only credential is used")
+	assert.False(t, usesWriteOnlyCredential(d, "missing"), "Must report the write only credential is not used")
+}
@@ -5,33 +5,24 @@ package signalfx
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"net"
-	"net/http"
 	"os"
 	"os/user"
-	"runtime"
-	"time"
 
-	"github.com/bgentry/go-netrc/netrc"
-	"github.com/hashicorp/go-retryablehttp"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/mitchellh/go-homedir"
-	sfx "github.com/signalfx/signalfx-go"
 
-	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/autoarchiveexemptmetric"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/autoarchivesettings"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/dimension"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/metric"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/organization"
-	"github.com/splunk-terraform/terraform-provider-signalfx/internal/feature"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/orgtoken"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/team"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
 	tfext "github.com/splunk-terraform/terraform-provider-signalfx/internal/tfextension"
-	"github.com/splunk-terraform/terraform-provider-signalfx/internal/track"
 	"github.com/splunk-terraform/terraform-provider-signalfx/version"
 )
 
@@ -43,52 +34,104 @@ var sfxProvider *schema.Provider
 
 type signalfxConfig = pmeta.Meta
 
-func Provider() *schema.Provider {
+func Provider(opts ...ProviderOption) *schema.Provider {
+	var po providerOptions
+	for _, opt := range opts {
+		opt(&po)
+	}
+
 	sfxProvider = &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"auth_token": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("SFX_AUTH_TOKEN", ""),
-				Description: "Splunk Observability Cloud auth token",
+				Description: "Splunk Observability Cloud auth token, can also be set with `SFX_AUTH_TOKEN`",
 			},
 			"api_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("SFX_API_URL", "https://api.signalfx.com"),
-				Description: "API URL for your Splunk Observability Cloud org, may include a realm",
+				Description: "API URL for your Splunk Observability Cloud org, may include a realm. Defaults to https://api.signalfx.com",
+			},
+			"realm": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"api_url"},
+				ValidateFunc:  validation.StringInSlice(pmeta.Realms, false),
+				Description:   "Realm of your Splunk Observability Cloud org, used to derive the API and application URLs. Conflicts with `api_url`",
 			},
 			"custom_app_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Deprecated:  "Remove the definition, the provider will automatically populate the custom app URL as needed",
-				DefaultFunc: schema.EnvDefaultFunc("SFX_CUSTOM_APP_URL", "https://app.signalfx.com"),
-				Description: "Application URL for your Splunk Observability Cloud org, often customized for organizations using SSO",
+				Description: "Application URL for your Splunk Observability Cloud org, often customized for organizations using SSO. Defaults to https://app.signalfx.com",
 			},
 			"timeout_seconds": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     120,
 				Description: "Timeout duration for a single HTTP call in seconds. Defaults to 120",
 			},
 			"retry_max_attempts": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     4,
 				Description: "Max retries for a single HTTP call. Defaults to 4",
 			},
 			"retry_wait_min_seconds": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     1,
 				Description: "Minimum retry wait for a single HTTP call in seconds. Defaults to 1",
 			},
 			"retry_wait_max_seconds": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     30,
 				Description: "Maximum retry wait for a single HTTP call in seconds. Defaults to 30",
 			},
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of API requests made per second, the rate is lowered while the API responds with `429 Too Many Requests`. Defaults to 0 which does not limit the rate",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of API requests made at the same time. Defaults to 0 which does not limit the number of requests",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the proxy used for API requests, which may include the proxy credentials. Defaults to the `HTTPS_PROXY` and `NO_PROXY` environment variables",
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "Path to a PEM encoded certificate authority bundle that is trusted along with the system certificates. Conflicts with `ca_cert_pem`",
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM encoded certificate authority bundle that is trusted along with the system certificates. Conflicts with `ca_cert_file`",
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key"},
+				Description:  "PEM encoded client certificate, or the path to one, used for mutual TLS. Requires `client_key`",
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_cert"},
+				Description:  "PEM encoded private key of the client certificate, or the path to one. Requires `client_cert`",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skips verifying the certificate of the API, only intended for testing. Defaults to false",
+			},
 			"email": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -113,6 +156,11 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "Required if the user is configured to be part of multiple organizations",
 			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the profile to read from the provider configuration files, can also be set with `SFX_PROFILE`",
+			},
 			"feature_preview": {
 				Type: schema.TypeMap,
 				Elem: &schema.Schema{
@@ -142,6 +190,8 @@ func Provider() *schema.Provider {
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"signalfx_dimension_values":      dataSourceDimensionValues(),
+			metric.MetricsDataSourceName:     metric.NewMetricsDataSource(),
+			metric.TimeSeriesDataSourceName:  metric.NewTimeSeriesDataSource(),
 			"signalfx_pagerduty_integration": dataSourcePagerDutyIntegration(),
 			organization.DataSourceName:      organization.NewDataSource(),
 		},
@@ -156,19 +206,23 @@ func Provider() *schema.Provider {
 			"signalfx_dashboard_group":                  dashboardGroupResource(),
 			"signalfx_data_link":                        dataLinkResource(),
 			"signalfx_detector":                         detectorResource(),
+			dimension.ResourceName:                      dimension.NewResource(),
 			"signalfx_event_feed_chart":                 eventFeedChartResource(),
 			"signalfx_gcp_integration":                  integrationGCPResource(),
 			"signalfx_heatmap_chart":                    heatmapChartResource(),
 			"signalfx_jira_integration":                 integrationJiraResource(),
 			"signalfx_list_chart":                       listChartResource(),
 			"signalfx_org_token":                        orgTokenResource(),
+			orgtoken.RotationResourceName:               orgtoken.NewRotationResource(),
 			"signalfx_opsgenie_integration":             integrationOpsgenieResource(),
+			organization.MemberResourceName:             organization.NewMemberResource(),
 			"signalfx_pagerduty_integration":            integrationPagerDutyResource(),
 			"signalfx_service_now_integration":          integrationServiceNowResource(),
 			"signalfx_slack_integration":                integrationSlackResource(),
 			"signalfx_single_value_chart":               singleValueChartResource(),
 			"signalfx_slo_chart":                        sloChartResource(),
 			"signalfx_team":                             teamResource(),
+			team.MembershipResourceName:                 team.NewMembershipResource(),
 			"signalfx_time_chart":                       timeChartResource(),
 			"signalfx_text_chart":                       textChartResource(),
 			"signalfx_victor_ops_integration":           integrationVictorOpsResource(),
@@ -177,151 +231,91 @@ func Provider() *schema.Provider {
 			"signalfx_log_timeline":                     logTimelineResource(),
 			"signalfx_table_chart":                      tableChartResource(),
 			"signalfx_metric_ruleset":                   metricRulesetResource(),
+			metric.ResourceName:                         metric.NewResource(),
 			"signalfx_slo":                              sloResource(),
 		},
-		ConfigureFunc: signalfxConfigure,
+		ProviderMetaSchema: pmeta.NewProviderMetaSchema(),
+		ConfigureFunc: func(data *schema.ResourceData) (any, error) {
+			return signalfxConfigure(data, po)
+		},
 	}
 
-	for _, res := range sfxProvider.ResourcesMap {
+	for name, res := range sfxProvider.ResourcesMap {
 		res = deprecatedMethodDecorator(res)
+		pmeta.DecorateProviderMeta(res)
+		tfext.DecorateResourceAddress(name, res)
 	}
 
-	for _, ds := range sfxProvider.DataSourcesMap {
+	for name, ds := range sfxProvider.DataSourcesMap {
 		ds = deprecatedMethodDecorator(ds)
+		tfext.DecorateResourceAddress(name, ds)
 	}
 
 	return sfxProvider
 }
 
-func signalfxConfigure(data *schema.ResourceData) (interface{}, error) {
-	config := signalfxConfig{
-		Email:          data.Get("email").(string),
-		Password:       data.Get("password").(string),
-		OrganizationID: data.Get("organization_id").(string),
-		Tags:           convert.SliceAll(data.Get("tags").([]any), convert.ToString),
-		Teams:          convert.SliceAll(data.Get("teams").([]any), convert.ToString),
-	}
-
-	// /etc/signalfx.conf has the lowest priority
-	if _, err := os.Stat(SystemConfigPath); err == nil {
-		err = readConfigFile(SystemConfigPath, &config)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// $HOME/.signalfx.conf second
-	// this additional variable is used for mocking purposes in tests
-	if HomeConfigPath == "" {
-		usr, err := user.Current()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get user environment %s", err.Error())
-		}
-		HomeConfigPath = usr.HomeDir + HomeConfigSuffix
-	}
-	if _, err := os.Stat(HomeConfigPath); err == nil {
-		err = readConfigFile(HomeConfigPath, &config)
-		if err != nil {
-			return nil, err
-		}
-	}
+func signalfxConfigure(data *schema.ResourceData, po providerOptions) (interface{}, error) {
+	// The lookups are ordered from lowest to highest priority:
+	// netrc, /etc/signalfx.conf then $HOME/.signalfx.conf
+	resolver := pmeta.NewResolver(pmeta.WithResolverLookups(
+		pmeta.NetrcMetaLookupFunc(os.Getenv("NETRC")),
+		configFileLookup(func() (string, error) { return SystemConfigPath, nil }),
+		configFileLookup(homeConfigPath),
+	))
 
-	// Use netrc next
-	err := readNetrcFile(&config)
+	config, err := resolver.Resolve(context.TODO(), pmeta.NewConfigFromResourceData(data))
 	if err != nil {
 		return nil, err
 	}
 
-	// provider is the top priority
-	if token, ok := data.GetOk("auth_token"); ok {
-		config.AuthToken = token.(string)
-	}
-
-	if url, ok := data.GetOk("api_url"); ok {
-		config.APIURL = url.(string)
-	}
-
-	if err = config.Validate(); err != nil {
-		return nil, err
-	}
-
-	if site, err := config.DetectCustomAPPURL(context.TODO()); err != nil {
-		if app, ok := data.GetOk("custom_app_url"); ok {
-			config.CustomAppURL = app.(string)
-		}
-	} else {
-		config.CustomAppURL = site
-	}
-
-	netTransport := logging.NewTransport("SignalFx", &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			Timeout: 5 * time.Second,
-		}).DialContext,
-		TLSHandshakeTimeout: 5 * time.Second,
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 100,
-	})
-
 	pv := version.ProviderVersion
 	providerUserAgent := fmt.Sprintf("Terraform/%s terraform-provider-signalfx/%s", sfxProvider.TerraformVersion, pv)
 
-	totalTimeoutSeconds := data.Get("timeout_seconds").(int)
-	retryMaxAttempts := data.Get("retry_max_attempts").(int)
-	retryWaitMinSeconds := data.Get("retry_wait_min_seconds").(int)
-	retryWaitMaxSeconds := data.Get("retry_wait_max_seconds").(int)
-	log.Printf("[DEBUG] SignalFx: HTTP Timeout is %d seconds", totalTimeoutSeconds)
-	log.Printf("[DEBUG] SignalFx: HTTP max retry attempts: %d", retryMaxAttempts)
-	log.Printf("[DEBUG] SignalFx: HTTP retry wait min is %d seconds", retryWaitMinSeconds)
-	log.Printf("[DEBUG] SignalFx: HTTP retry wait max is %d seconds", retryWaitMaxSeconds)
-
-	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = retryMaxAttempts
-	retryClient.RetryWaitMin = time.Second * time.Duration(int64(retryWaitMinSeconds))
-	retryClient.RetryWaitMax = time.Second * time.Duration(int64(retryWaitMaxSeconds))
-	retryClient.HTTPClient.Timeout = time.Second * time.Duration(int64(totalTimeoutSeconds))
-	retryClient.HTTPClient.Transport = netTransport
-	standardClient := retryClient.StandardClient()
+	// Most requests are made without a logging context,
+	// so the entries are written using the standard logger.
+	httpLog := tfext.NewHTTPLogger("signalfx", tfext.WithHTTPLogFunc(tfext.PrintfHTTPLogFunc("SignalFx")))
+	config.WrapTransport = po.wrapTransport
 
-	token, err := config.LoadSessionToken(context.Background())
+	diags, err := config.Finalize(context.TODO(), providerUserAgent, httpLog)
 	if err != nil {
 		return nil, err
 	}
-
-	client, err := sfx.NewClient(
-		token,
-		sfx.APIUrl(config.APIURL),
-		sfx.HTTPClient(standardClient),
-		sfx.UserAgent(providerUserAgent),
-	)
-	if err != nil {
-		return &config, err
+	if diags.HasError() {
+		return nil, diags.Err()
+	}
+	for _, d := range diags {
+		log.Printf("[WARN] SignalFx: feature_preview %q: %s", d.Feature, d.Detail)
 	}
 
-	config.Client = client
-
-	for feat, val := range data.Get("feature_preview").(map[string]any) {
-		err = pmeta.LoadPreviewRegistry(
-			context.TODO(),
-			config,
-		).Configure(context.TODO(), feat, val.(bool))
+	return config, nil
+}
 
+// configFileLookup reads the provider configuration file when it exists,
+// the path is loaded once the lookup is called so it can be replaced by tests.
+func configFileLookup(configPath func() (string, error)) pmeta.MetaLookupFunc {
+	return func(_ context.Context, config *signalfxConfig) error {
+		path, err := configPath()
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil
 		}
+		return readConfigFile(path, config)
 	}
+}
 
-	if gate, ok := pmeta.LoadPreviewRegistry(context.TODO(), config).Get(feature.PreviewProviderTracking); ok && gate.Enabled() {
-		tracking, err := track.ReadGitDetails(context.TODO())
+// homeConfigPath returns $HOME/.signalfx.conf,
+// HomeConfigPath is used instead when set for mocking purposes in tests.
+func homeConfigPath() (string, error) {
+	if HomeConfigPath == "" {
+		usr, err := user.Current()
 		if err != nil {
-			log.Printf("[INFO] Unable to load git details, skipping: %v", err)
-			tflog.Info(context.TODO(), "Unable to load git details, skipping", tfext.ErrorLogFields(err))
-		} else {
-			config.Tags = append(config.Tags, tracking.Tags()...)
+			return "", fmt.Errorf("failed to get user environment %s", err.Error())
 		}
+		HomeConfigPath = usr.HomeDir + HomeConfigSuffix
 	}
-
-	return &config, nil
+	return HomeConfigPath, nil
 }
 
 func readConfigFile(configPath string, config *signalfxConfig) error {
@@ -329,57 +323,15 @@ func readConfigFile(configPath string, config *signalfxConfig) error {
 	if err != nil {
 		return fmt.Errorf("failed to open config file. %s", err.Error())
 	}
-	err = json.Unmarshal(configFile, config)
-	if err != nil {
-		return fmt.Errorf("failed to parse config file. %s", err.Error())
-	}
-	return nil
-}
-
-func readNetrcFile(config *signalfxConfig) error {
-	// Inspired by https://github.com/hashicorp/terraform/blob/master/vendor/github.com/hashicorp/go-getter/netrc.go
-	// Get the netrc file path
-	path := os.Getenv("NETRC")
-	if path == "" {
-		filename := ".netrc"
-		if runtime.GOOS == "windows" {
-			filename = "_netrc"
-		}
-
-		var err error
-		path, err = homedir.Expand("~/" + filename)
-		if err != nil {
-			return err
-		}
-	}
-
-	// If the file is not a file, then do nothing
-	if fi, err := os.Stat(path); err != nil {
-		// File doesn't exist, do nothing
-		if os.IsNotExist(err) {
-			return nil
-		}
-
-		// Some other error!
-		return err
-	} else if fi.IsDir() {
-		// File is directory, ignore
+	err = pmeta.UnmarshalConfigFile(context.TODO(), configPath, configFile, config)
+	if errors.Is(err, pmeta.ErrProfileNotFound) {
+		// The profile can be defined in the other config file,
+		// config.Validate reports if it was not found in either.
+		log.Printf("[DEBUG] SignalFx: %s", err)
 		return nil
 	}
-
-	// Load up the netrc file
-	netRC, err := netrc.ParseFile(path)
 	if err != nil {
-		return fmt.Errorf("error parsing netrc file at %q: %s", path, err)
-	}
-
-	machine := netRC.FindMachine("api.signalfx.com")
-	if machine == nil {
-		// Machine not found, no problem
-		return nil
+		return fmt.Errorf("failed to parse config file. %s", err.Error())
 	}
-
-	// Set the auth token
-	config.AuthToken = machine.Password
 	return nil
 }
@@ -0,0 +1,20 @@
+// Copyright Splunk, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalfx
+
+import "net/http"
+
+type providerOptions struct {
+	wrapTransport func(http.RoundTripper) http.RoundTripper
+}
+
+type ProviderOption func(*providerOptions)
+
+// WithProviderTransportWrapper wraps the transport of the configured clients,
+// it is only intended for tests that record or replay the API interactions.
+func WithProviderTransportWrapper(wrap func(http.RoundTripper) http.RoundTripper) ProviderOption {
+	return func(po *providerOptions) {
+		po.wrapTransport = wrap
+	}
+}
@@ -4,10 +4,13 @@
 package signalfx
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
@@ -15,6 +18,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	sfx "github.com/signalfx/signalfx-go"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/cassette"
+	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/tftest"
 )
 
 var OldSystemConfigPath = SystemConfigPath
@@ -23,8 +30,19 @@ var OldHomeConfigPath = HomeConfigPath
 var testAccProviders map[string]*schema.Provider
 var testAccProvider *schema.Provider
 
+// activeCassette is set by [useCassette] so that the
+// acceptance tests record or replay their API interactions.
+var activeCassette atomic.Pointer[cassette.Transport]
+
+func wrapCassette(next http.RoundTripper) http.RoundTripper {
+	if tr := activeCassette.Load(); tr != nil {
+		return tr.Wrap(next)
+	}
+	return next
+}
+
 func init() {
-	testAccProvider = Provider()
+	testAccProvider = Provider(WithProviderTransportWrapper(wrapCassette))
 	testAccProviders = map[string]*schema.Provider{
 		"signalfx": testAccProvider,
 	}
@@ -53,8 +71,11 @@ func createTempConfigFile(tb testing.TB, content string, name string) (*os.File,
 }
 
 func newTestClient() *sfx.Client {
-	apiURL, _ := sfxProvider.Schema["api_url"].DefaultFunc()
-	client, _ := sfx.NewClient(os.Getenv("SFX_AUTH_TOKEN"), sfx.APIUrl(apiURL.(string)))
+	client, _ := sfx.NewClient(
+		os.Getenv("SFX_AUTH_TOKEN"),
+		sfx.APIUrl(cmp.Or(os.Getenv("SFX_API_URL"), pmeta.DefaultAPIURL)),
+		sfx.HTTPClient(&http.Client{Transport: wrapCassette(http.DefaultTransport)}),
+	)
 	return client
 }
 
@@ -89,6 +110,36 @@ func TestProviderConfigureFromNothing(t *testing.T) {
 	assert.Contains(t, diag[0].Summary, "missing auth token or email and password")
 }
 
+func TestProviderConfigureFromRealm(t *testing.T) {
+	defer resetGlobals()
+	SystemConfigPath = "filedoesnotexist"
+	HomeConfigPath = "filedoesnotexist"
+
+	old := os.Getenv("SFX_API_URL")
+	defer os.Setenv("SFX_API_URL", old)
+	os.Unsetenv("SFX_API_URL")
+
+	old = os.Getenv("SFX_CUSTOM_APP_URL")
+	defer os.Setenv("SFX_CUSTOM_APP_URL", old)
+	os.Unsetenv("SFX_CUSTOM_APP_URL")
+
+	raw := map[string]interface{}{
+		"auth_token": "XXX",
+		"realm":      "jp0",
+	}
+
+	rp := Provider()
+	diag := rp.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	meta := rp.Meta()
+	if meta == nil {
+		t.Fatalf("Expected metadata, got nil. err: %s", spew.Sdump(diag))
+	}
+	configuration := meta.(*signalfxConfig)
+	assert.Equal(t, "jp0", configuration.Realm)
+	assert.Equal(t, "https://api.jp0.observability.splunkcloud.com", configuration.APIURL)
+	assert.Equal(t, "https://app.jp0.observability.splunkcloud.com", configuration.CustomAppURL)
+}
+
 func TestProviderConfigureFromTerraform(t *testing.T) {
 	defer resetGlobals()
 	tmpfileSystem, err := createTempConfigFile(t, `{"useless_config":"foo","auth_token":"ZZZ"}`, "signalfx.conf")
@@ -251,11 +302,8 @@ func TestSignalFxConfigureFromHomeFile(t *testing.T) {
 
 func TestSignalFxConfigureFromNetrcFile(t *testing.T) {
 	defer resetGlobals()
-	tmpfileSystem, err := createTempConfigFile(t, `{"useless_config":"foo","auth_token":"ZZZ"}`, "signalfx.conf")
-	if err != nil {
-		t.Fatal(err.Error())
-	}
-	SystemConfigPath = tmpfileSystem.Name()
+	SystemConfigPath = "filedoesnotexist"
+	HomeConfigPath = "filedoesnotexist"
 	tmpfileHome, err := createTempConfigFile(t, `machine api.signalfx.com login auth_login password WWW`, ".netrc")
 	if err != nil {
 		t.Fatal(err.Error())
@@ -290,6 +338,33 @@ func TestSignalFxConfigureFromNetrcFile(t *testing.T) {
 	assert.Equal(t, "https://app.signalfx.com", configuration.CustomAppURL)
 }
 
+func TestSignalFxConfigureFileOverNetrcFile(t *testing.T) {
+	defer resetGlobals()
+	tmpfileSystem, err := createTempConfigFile(t, `{"useless_config":"foo","auth_token":"ZZZ"}`, "signalfx.conf")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	SystemConfigPath = tmpfileSystem.Name()
+	HomeConfigPath = "filedoesnotexist"
+	tmpfileNetrc, err := createTempConfigFile(t, `machine api.signalfx.com login auth_login password WWW`, ".netrc")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tftest.CleanEnvVars(t)
+	t.Setenv("NETRC", tmpfileNetrc.Name())
+	raw := make(map[string]interface{})
+
+	rp := Provider()
+	diag := rp.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	meta := rp.Meta()
+	if meta == nil {
+		t.Fatalf("Expected metadata, got nil. err: %s", spew.Sdump(diag))
+	}
+	configuration := meta.(*signalfxConfig)
+	assert.Equal(t, "ZZZ", configuration.AuthToken)
+}
+
 func TestSignalFxConfigureFromHomeFileOnly(t *testing.T) {
 	defer resetGlobals()
 	SystemConfigPath = "filedoesnotexist"
@@ -392,3 +467,31 @@ func TestReadConfigFileSuccess(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "XXX", config.AuthToken)
 }
+
+func TestReadConfigFileProfile(t *testing.T) {
+	config := signalfxConfig{}
+	config.SelectProfile("eu0")
+	tmpfile, err := createTempConfigFile(t, `{"profiles":{"us1":{"auth_token":"XXX"},"eu0":{"auth_token":"YYY","realm":"eu0"}}}`, "signalfx.conf")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	err = readConfigFile(tmpfile.Name(), &config)
+	assert.Nil(t, err)
+	assert.Equal(t, "YYY", config.AuthToken)
+	assert.Equal(t, "https://api.eu0.observability.splunkcloud.com", config.APIURL)
+}
+
+func TestReadConfigFileProfileNotFound(t *testing.T) {
+	config := signalfxConfig{}
+	config.SelectProfile("jp0")
+	tmpfile, err := createTempConfigFile(t, `{"profiles":{"us1":{"auth_token":"XXX"}}}`, "signalfx.conf")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	err = readConfigFile(tmpfile.Name(), &config)
+	assert.Nil(t, err)
+	assert.Empty(t, config.AuthToken)
+	assert.Error(t, config.Validate())
+}
@@ -30,7 +30,7 @@ func metricStreamsStateSupplier(int *integration.AwsCloudWatchIntegration) strin
 
 func integrationAWSResource() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withWriteOnlyCredentials(map[string]*schema.Schema{
 			"integration_id": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -262,7 +262,7 @@ func integrationAWSResource() *schema.Resource {
 					"The AWS account sending the Metric Streams and the AWS account in the Splunk Observability Cloud integration have to match." +
 					"Requires `use_metric_streams_sync` set to true to work.",
 			},
-		},
+		}, "key"),
 
 		Create: integrationAWSCreate,
 		Read:   integrationAWSRead,
@@ -333,7 +333,7 @@ func awsIntegrationAPIToTF(d *schema.ResourceData, aws *integration.AwsCloudWatc
 			return err
 		}
 	}
-	if aws.Key != "" {
+	if aws.Key != "" && !usesWriteOnlyCredential(d, "key") {
 		if err := d.Set("key", aws.Key); err != nil {
 			return err
 		}
@@ -459,7 +459,7 @@ func getPayloadAWSIntegration(d *schema.ResourceData) (*integration.AwsCloudWatc
 	} else if d.Get("token").(string) != "" {
 		aws.AuthMethod = integration.SECURITY_TOKEN
 		aws.Token = d.Get("token").(string)
-		aws.Key = d.Get("key").(string)
+		aws.Key = getIntegrationCredential(d, "key")
 	} else {
 		return nil, fmt.Errorf("Please specify one of `external_id` or `token` and `key`")
 	}
@@ -17,7 +17,7 @@ import (
 
 func integrationAzureResource() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withWriteOnlyCredentials(map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
@@ -143,7 +143,7 @@ func integrationAzureResource() *schema.Resource {
 				Optional:    true,
 				Description: "If enabled, Splunk Observability Cloud will collect datapoints using Azure Metrics Batch API. Consider this option if you are synchronizing high loads of data and you want to avoid throttling issues. Contrary to the default Metrics List API, Metrics Batch API is paid. Refer to Azure documentation for pricing info.",
 			},
-		},
+		}, "secret_key"),
 
 		Create: integrationAzureCreate,
 		Read:   integrationAzureRead,
@@ -270,7 +270,7 @@ func getPayloadAzureIntegration(d *schema.ResourceData) (*integration.AzureInteg
 		Enabled:               d.Get("enabled").(bool),
 		AppId:                 d.Get("app_id").(string),
 		AzureEnvironment:      integration.AzureEnvironment(strings.ToUpper(d.Get("environment").(string))),
-		SecretKey:             d.Get("secret_key").(string),
+		SecretKey:             getIntegrationCredential(d, "secret_key"),
 		TenantId:              d.Get("tenant_id").(string),
 		SyncGuestOsNamespaces: d.Get("sync_guest_os_namespaces").(bool),
 		ImportAzureMonitor:    &importAzureMonitor,
@@ -5,12 +5,16 @@ package signalfx
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/cassette"
 )
 
 const newDashConfig = `
@@ -451,9 +455,52 @@ func TestAccCreateUpdateDashboardGroup(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
+	mode, err := cassette.ModeFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode == cassette.ModeReplay {
+		// Not every acceptance test has a recorded cassette yet,
+		// those without one are skipped rather than calling the placeholder API.
+		if _, err := os.Stat(cassettePath(t)); errors.Is(err, os.ErrNotExist) {
+			t.Skipf("No cassette recorded for %s, run the test with %s=%s against a realm to record it", t.Name(), cassette.EnvMode, cassette.ModeRecord)
+		}
+		// Replayed interactions do not reach the API,
+		// so placeholder values are used to configure the provider.
+		if v := os.Getenv("SFX_AUTH_TOKEN"); v == "" {
+			t.Setenv("SFX_AUTH_TOKEN", "replay")
+		}
+		if v := os.Getenv("SFX_API_URL"); v == "" {
+			t.Setenv("SFX_API_URL", "https://api.replay.invalid")
+		}
+	}
 	if v := os.Getenv("SFX_AUTH_TOKEN"); v == "" {
 		t.Fatal("SFX_AUTH_TOKEN must be set for acceptance tests")
 	}
+	if mode != cassette.ModeDisabled {
+		useCassette(t, mode)
+	}
+}
+
+// cassettePath returns the cassette used by the test within `testdata/cassettes`.
+func cassettePath(t *testing.T) string {
+	return cassette.Path(filepath.Join("testdata", "cassettes"), t.Name())
+}
+
+// useCassette records or replays the API interactions made during the test
+// using a cassette stored within `testdata/cassettes`.
+func useCassette(t *testing.T, mode cassette.Mode) {
+	tr, err := cassette.New(cassettePath(t), mode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := activeCassette.Swap(tr)
+	t.Cleanup(func() {
+		activeCassette.Store(prev)
+		if err := tr.Save(); err != nil {
+			t.Error("Unable to save cassette:", err)
+		}
+	})
 }
 
 func testAccCheckDashboardGroupResourceExists(s *terraform.State) error {
@@ -21,7 +21,9 @@ import (
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/convert"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/definition/rule"
 	pmeta "github.com/splunk-terraform/terraform-provider-signalfx/internal/providermeta"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/signalflow"
 	"github.com/splunk-terraform/terraform-provider-signalfx/internal/visual"
 )
 
@@ -56,6 +58,7 @@ var (
 			},
 			Description: "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
 		},
+		"notification": common.NewNotificationBlockSchema("Typed notification destinations to use when an incident occurs, an alternative to `notifications` that can not be used together with it"),
 		"disabled": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -129,10 +132,13 @@ func detectorResource() *schema.Resource {
 				Description: "Name of the detector",
 			},
 			"program_text": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Signalflow program text for the detector. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
-				ValidateFunc: validation.StringLenBetween(1, 50000),
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Signalflow program text for the detector. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
+				ValidateDiagFunc: validation.AllDiag(
+					validation.ToDiagFunc(validation.StringLenBetween(1, 50000)),
+					check.ProgramText(),
+				),
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -307,6 +313,10 @@ func detectorResource() *schema.Resource {
 		},
 
 		CustomizeDiff: customdiff.If(validateProgramTextCondition, validateProgramText),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			rule.ValidateDetectLabels(),
+			check.VisualizationLabels(),
+		},
 
 		Create: detectorCreate,
 		Read:   detectorRead,
@@ -457,13 +467,13 @@ func getDetectorRule(tfRule map[string]any) (*detector.Rule, error) {
 		rule.Tip = val.(string)
 	}
 
-	if notifications, ok := tfRule["notifications"]; ok {
-		notify, err := common.NewNotificationList(notifications.([]any))
-		if err != nil {
-			return nil, err
-		}
-		rule.Notifications = notify
+	strs, _ := tfRule["notifications"].([]any)
+	blocks, _ := tfRule["notification"].([]any)
+	notify, err := common.NewNotificationListFromEither(strs, blocks)
+	if err != nil {
+		return nil, err
 	}
+	rule.Notifications = notify
 
 	reminder := convert.ToReminderNotification(tfRule)
 	if reminder != nil {
@@ -714,13 +724,17 @@ func detectorAPIToTF(d *schema.ResourceData, det *detector.Detector) error {
 		}
 	}
 
+	// Rules previously written with typed notification blocks
+	// are kept as blocks to avoid a diff between the two forms.
+	blocks := rule.NotificationBlocks(d.Get("rule"))
+
 	rules := make([]map[string]any, len(det.Rules))
 	for i, r := range det.Rules {
-		rule, err := getTfDetectorRule(r)
+		tfRule, err := getTfDetectorRule(r, blocks[rule.Key(r.DetectLabel, string(r.Severity))])
 		if err != nil {
 			return err
 		}
-		rules[i] = rule
+		rules[i] = tfRule
 	}
 	if err := d.Set("rule", rules); err != nil {
 		return err
@@ -729,21 +743,15 @@ func detectorAPIToTF(d *schema.ResourceData, det *detector.Detector) error {
 	return nil
 }
 
-func getTfDetectorRule(r *detector.Rule) (map[string]any, error) {
+func getTfDetectorRule(r *detector.Rule, blocks bool) (map[string]any, error) {
 	rule := make(map[string]any)
 	rule["severity"] = r.Severity
 	rule["detect_label"] = r.DetectLabel
 	rule["description"] = r.Description
 
-	notifications := make([]string, len(r.Notifications))
-	for i, not := range r.Notifications {
-		tfNot, err := common.NewNotificationStringFromAPI(not)
-		if err != nil {
-			return nil, err
-		}
-		notifications[i] = tfNot
+	if err := common.EncodeNotificationFields(rule, r.Notifications, blocks); err != nil {
+		return nil, err
 	}
-	rule["notifications"] = notifications
 	rule["disabled"] = r.Disabled
 	rule["parameterized_body"] = r.ParameterizedBody
 	rule["parameterized_subject"] = r.ParameterizedSubject
@@ -885,19 +893,10 @@ func resourceRuleHash(v any) int {
 	}
 
 	// Sort the notifications so that we generate a consistent hash
-	if v, ok := m["notifications"]; ok {
-		notifications := v.([]any)
-		s_notifications := make([]string, len(notifications))
-		for i, raw := range notifications {
-			if raw != nil {
-				s_notifications[i] = raw.(string)
-			}
-		}
-		sort.Strings(s_notifications)
-
-		for _, notification := range s_notifications {
-			buf.WriteString(fmt.Sprintf("%s-", notification))
-		}
+	strs, _ := m["notifications"].([]any)
+	blocks, _ := m["notification"].([]any)
+	for _, notification := range common.NotificationHashValues(strs, blocks) {
+		buf.WriteString(fmt.Sprintf("%s-", notification))
 	}
 
 	if states, ok := m["skip_clear_notification_states"].(*schema.Set); ok {
@@ -948,6 +947,10 @@ func validateProgramTextCondition(ctx context.Context, d *schema.ResourceDiff, m
 Validates the ProgramText and the list of rules.
 */
 func validateProgramText(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	// Catch the obvious mistakes offline before sending the detector to the API.
+	if err := signalflow.Validate(d.Get("program_text").(string)); err != nil {
+		return err
+	}
 
 	tfRules := d.Get("rule").(*schema.Set).List()
 	rulesList := make([]*detector.Rule, len(tfRules))
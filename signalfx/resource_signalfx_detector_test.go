@@ -328,7 +328,7 @@ func TestAccCreateUpdateDetector(t *testing.T) {
 			{
 				Config:      invalidRulesConfig,
 				PlanOnly:    true,
-				ExpectError: regexp.MustCompile("Unexpected status code"),
+				ExpectError: regexp.MustCompile("not published within program_text"),
 			},
 			// Check invalid AutoDetect customization
 			{
@@ -20,7 +20,7 @@ var validAuthMethod = regexp.MustCompile("^(UsernameAndPassword|EmailAndToken)$"
 
 func integrationJiraResource() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withWriteOnlyCredentials(map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
@@ -88,7 +88,7 @@ func integrationJiraResource() *schema.Resource {
 				Optional:    true,
 				Description: "Jira display name for the assignee",
 			},
-		},
+		}, "api_token", "password"),
 
 		Create: integrationJiraCreate,
 		Read:   integrationJiraRead,
@@ -184,10 +184,10 @@ func getPayloadJiraIntegration(d *schema.ResourceData) (*integration.JiraIntegra
 
 	if jira.AuthMethod == "UsernameAndPassword" {
 		jira.Username = d.Get("username").(string)
-		jira.Password = d.Get("password").(string)
+		jira.Password = getIntegrationCredential(d, "password")
 	} else {
 		jira.UserEmail = d.Get("user_email").(string)
-		jira.APIToken = d.Get("api_token").(string)
+		jira.APIToken = getIntegrationCredential(d, "api_token")
 	}
 
 	return jira, nil
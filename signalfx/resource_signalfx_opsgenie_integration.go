@@ -16,7 +16,7 @@ import (
 
 func integrationOpsgenieResource() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withWriteOnlyCredentials(map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
@@ -38,7 +38,7 @@ func integrationOpsgenieResource() *schema.Resource {
 				Optional:    true,
 				Description: "Opsgenie API URL for integration",
 			},
-		},
+		}, "api_key"),
 
 		Create: integrationOpsgenieCreate,
 		Read:   integrationOpsgenieRead,
@@ -55,7 +55,7 @@ func getOpsgeniePayloadIntegration(d *schema.ResourceData) *integration.Opsgenie
 		Type:    "Opsgenie",
 		Name:    d.Get("name").(string),
 		Enabled: d.Get("enabled").(bool),
-		ApiKey:  d.Get("api_key").(string),
+		ApiKey:  getIntegrationCredential(d, "api_key"),
 		ApiUrl:  d.Get("api_url").(string),
 	}
 }
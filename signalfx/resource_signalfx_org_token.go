@@ -140,13 +140,21 @@ func orgTokenResource() *schema.Resource {
 					Type:             schema.TypeString,
 					ValidateDiagFunc: check.Notification(),
 				},
-				Description: "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
+				ConflictsWith: []string{"notification"},
+				Description:   "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
 			},
+			"notification": orgTokenNotificationBlockSchema(),
 			"secret": &schema.Schema{
 				Type:      schema.TypeString,
 				Computed:  true,
 				Sensitive: true,
 			},
+			"exclude_secret_from_state": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Keeps the token secret out of state when set to `true`, use the `signalfx_org_token_secret` ephemeral resource to read the secret when it is needed. Defaults to `false`",
+			},
 		},
 
 		Create: orgTokenCreate,
@@ -235,13 +243,13 @@ func getPayloadOrgToken(d *schema.ResourceData) (*orgtoken.CreateUpdateTokenRequ
 		token.Limits = limits
 	}
 
-	if notifications, ok := d.GetOk("notifications"); ok {
-		notify, err := common.NewNotificationList(notifications.([]any))
-		if err != nil {
-			return nil, err
-		}
-		token.Notifications = notify
+	strs, _ := d.Get("notifications").([]any)
+	blocks, _ := d.Get("notification").([]any)
+	notify, err := common.NewNotificationListFromEither(strs, blocks)
+	if err != nil {
+		return nil, err
 	}
+	token.Notifications = notify
 
 	return token, nil
 }
@@ -335,19 +343,22 @@ func orgTokenAPIToTF(d *schema.ResourceData, t *orgtoken.Token) error {
 		}
 	}
 
-	notifications := make([]string, len(t.Notifications))
-	for i, not := range t.Notifications {
-		tfNot, err := common.NewNotificationStringFromAPI(not)
-		if err != nil {
-			return err
-		}
-		notifications[i] = tfNot
+	notifications := make(map[string]any)
+	if err := common.EncodeNotificationFields(notifications, t.Notifications, common.UsesNotificationBlocks(d.Get("notification"))); err != nil {
+		return err
+	}
+	if err := d.Set("notifications", notifications[common.NotificationStringField]); err != nil {
+		return err
 	}
-	if err := d.Set("notifications", notifications); err != nil {
+	if err := d.Set("notification", notifications[common.NotificationBlockField]); err != nil {
 		return err
 	}
 
-	if err := d.Set("secret", t.Secret); err != nil {
+	secret := t.Secret
+	if d.Get("exclude_secret_from_state").(bool) {
+		secret = ""
+	}
+	if err := d.Set("secret", secret); err != nil {
 		return err
 	}
 
@@ -394,3 +405,9 @@ func orgTokenDelete(d *schema.ResourceData, meta interface{}) error {
 
 	return config.Client.DeleteOrgToken(context.TODO(), d.Id())
 }
+
+func orgTokenNotificationBlockSchema() *schema.Schema {
+	s := common.NewNotificationBlockSchema("Typed notification destinations to use when an incident occurs, an alternative to `notifications`")
+	s.ConflictsWith = []string{"notifications"}
+	return s
+}
@@ -16,7 +16,7 @@ import (
 
 func integrationPagerDutyResource() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withWriteOnlyCredentials(map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
@@ -33,7 +33,7 @@ func integrationPagerDutyResource() *schema.Resource {
 				Description: "PagerDuty API key",
 				Sensitive:   true,
 			},
-		},
+		}, "api_key"),
 
 		Create: integrationPagerDutyCreate,
 		Read:   integrationPagerDutyRead,
@@ -80,7 +80,7 @@ func getPayloadPagerDutyIntegration(d *schema.ResourceData) (*integration.PagerD
 		Type:    "PagerDuty",
 		Name:    d.Get("name").(string),
 		Enabled: d.Get("enabled").(bool),
-		ApiKey:  d.Get("api_key").(string),
+		ApiKey:  getIntegrationCredential(d, "api_key"),
 	}, nil
 }
 
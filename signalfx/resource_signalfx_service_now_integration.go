@@ -27,7 +27,7 @@ const (
 
 func integrationServiceNowResource() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withWriteOnlyCredentials(map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -70,7 +70,7 @@ func integrationServiceNowResource() *schema.Resource {
 				Optional:    true,
 				Description: "A template that Observability Cloud uses to create the ServiceNow PUT JSON payloads when an alert is cleared in ServiceNow. Use this optional field to send the values of Observability Cloud alert properties to specific fields in ServiceNow. See API reference for details.",
 			},
-		},
+		}, "password"),
 
 		Create: integrationServiceNowCreate,
 		Read:   integrationServiceNowRead,
@@ -90,7 +90,7 @@ func getServiceNowIntegration(d *schema.ResourceData) *integration.ServiceNowInt
 		InstanceName: d.Get("instance_name").(string),
 		IssueType:    d.Get("issue_type").(string),
 		Username:     d.Get("username").(string),
-		Password:     d.Get("password").(string),
+		Password:     getIntegrationCredential(d, "password"),
 	}
 	if val, ok := d.GetOk("alert_triggered_payload_template"); ok {
 		snow.AlertTriggeredPayloadTemplate = val.(string)
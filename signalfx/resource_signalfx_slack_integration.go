@@ -16,7 +16,7 @@ import (
 
 func integrationSlackResource() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withWriteOnlyCredentials(map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
@@ -33,7 +33,7 @@ func integrationSlackResource() *schema.Resource {
 				Description: "Slack Webhook URL for integration",
 				Sensitive:   true,
 			},
-		},
+		}, "webhook_url"),
 
 		Create: integrationSlackCreate,
 		Read:   integrationSlackRead,
@@ -50,7 +50,7 @@ func getSlackPayloadIntegration(d *schema.ResourceData) *integration.SlackIntegr
 		Type:       "Slack",
 		Name:       d.Get("name").(string),
 		Enabled:    d.Get("enabled").(bool),
-		WebhookUrl: d.Get("webhook_url").(string),
+		WebhookUrl: getIntegrationCredential(d, "webhook_url"),
 	}
 }
 
@@ -17,6 +17,9 @@ import (
 	"github.com/signalfx/signalfx-go"
 	"github.com/signalfx/signalfx-go/detector"
 	"github.com/signalfx/signalfx-go/slo"
+
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/check"
+	"github.com/splunk-terraform/terraform-provider-signalfx/internal/common"
 )
 
 const (
@@ -80,7 +83,10 @@ func sloResource() *schema.Resource {
 							Required: true,
 							Description: "Signalflow program text for the SLO. More info at \"https://dev.splunk.com/observability/docs/signalflow\". " +
 								"We require this Signalflow program text to contain at least 2 data blocks - one for the total stream and one for the good stream, whose labels are specified by goodEventsLabel and totalEventsLabel",
-							ValidateFunc: validation.StringLenBetween(18, 50000),
+							ValidateDiagFunc: validation.AllDiag(
+								validation.ToDiagFunc(validation.StringLenBetween(18, 50000)),
+								check.ProgramText(),
+							),
 						},
 						goodEventsLabel: {
 							Type:         schema.TypeString,
@@ -602,7 +608,8 @@ func sloAPIToTF(sloTfResource *schema.ResourceData, sloApiObject *slo.SloObject)
 		return errSet
 	}
 
-	tfTargets, err := getTfTargets(sloApiObject)
+	priorTargets, _ := sloTfResource.Get(targetLabel).([]interface{})
+	tfTargets, err := getTfTargets(sloApiObject, priorTargets)
 	if err != nil {
 		return err
 	}
@@ -632,7 +639,7 @@ func getRequestBasedTerraformInput(sloInput *slo.RequestBasedSloInput) map[strin
 	return tfInput
 }
 
-func getTfTargets(sloApiObject *slo.SloObject) ([]map[string]interface{}, error) {
+func getTfTargets(sloApiObject *slo.SloObject, priorTargets []interface{}) ([]map[string]interface{}, error) {
 	tfTargets := make([]map[string]interface{}, len(sloApiObject.Targets))
 	for ind, apiTarget := range sloApiObject.Targets {
 		tfTarget := make(map[string]interface{})
@@ -649,7 +656,7 @@ func getTfTargets(sloApiObject *slo.SloObject) ([]map[string]interface{}, error)
 			return nil, fmt.Errorf("unsupported SLO target type: %s", apiTarget.Type)
 		}
 
-		tfAlertRules, err := getTfAlertRules(apiTarget.SloAlertRules)
+		tfAlertRules, err := getTfAlertRules(apiTarget.SloAlertRules, getPriorSloList(priorTargets, ind, alertRuleLabel))
 		if err != nil {
 			return nil, err
 		}
@@ -659,7 +666,7 @@ func getTfTargets(sloApiObject *slo.SloObject) ([]map[string]interface{}, error)
 	return tfTargets, nil
 }
 
-func getTfAlertRules(apiAlertRules []slo.SloAlertRule) (interface{}, error) {
+func getTfAlertRules(apiAlertRules []slo.SloAlertRule, priorAlertRules []interface{}) (interface{}, error) {
 	tfAlertRules := make([]map[string]interface{}, len(apiAlertRules))
 
 	// Since the API can return alert rules in any order, we need to sort here to avoid TF wanting to update a resource because the order has changed.
@@ -670,6 +677,7 @@ func getTfAlertRules(apiAlertRules []slo.SloAlertRule) (interface{}, error) {
 	for ind, apiAlertRule := range apiAlertRules {
 		tfAlertRule := make(map[string]interface{})
 		tfAlertRule[typeLabel] = apiAlertRule.Type
+		priorRules := getPriorSloAlertRules(priorAlertRules, string(apiAlertRule.Type))
 
 		switch apiAlertRule.Type {
 		case slo.BreachRule:
@@ -690,6 +698,7 @@ func getTfAlertRules(apiAlertRules []slo.SloAlertRule) (interface{}, error) {
 
 					return nil
 				},
+				priorRules,
 			)
 
 			if err != nil {
@@ -715,6 +724,7 @@ func getTfAlertRules(apiAlertRules []slo.SloAlertRule) (interface{}, error) {
 
 					return nil
 				},
+				priorRules,
 			)
 
 			if err != nil {
@@ -743,6 +753,7 @@ func getTfAlertRules(apiAlertRules []slo.SloAlertRule) (interface{}, error) {
 
 					return nil
 				},
+				priorRules,
 			)
 
 			if err != nil {
@@ -766,12 +777,19 @@ type RuleParametersProvider[Rule DetectorRuleType] func(rule Rule) []map[string]
 
 func getTfDetectorRules[Rule DetectorRuleType](alertRules []*Rule,
 	detectorRuleProvider DetectorRuleProvider[Rule],
-	ruleParametersProvider RuleParametersProvider[Rule]) ([]map[string]interface{}, error) {
+	ruleParametersProvider RuleParametersProvider[Rule],
+	priorRules []interface{}) ([]map[string]interface{}, error) {
 
 	tfDetectorRules := make([]map[string]interface{}, len(alertRules))
 
 	for ind, apiRule := range alertRules {
-		tfDetectorRule, err := getTfDetectorRule(detectorRuleProvider(*apiRule))
+		blocks := false
+		if ind < len(priorRules) {
+			if priorRule, ok := priorRules[ind].(map[string]interface{}); ok {
+				blocks = common.UsesNotificationBlocks(priorRule["notification"])
+			}
+		}
+		tfDetectorRule, err := getTfDetectorRule(detectorRuleProvider(*apiRule), blocks)
 		delete(tfDetectorRule, "detect_label") // We don't expect detect_label. The user can send it - but we will ignore it - so we remove it from the TF schema here
 
 		if err != nil {
@@ -784,3 +802,27 @@ func getTfDetectorRules[Rule DetectorRuleType](alertRules []*Rule,
 	}
 	return tfDetectorRules, nil
 }
+
+// getPriorSloList returns the nested list stored under key for the element at index,
+// it is used to keep the notification form previously written to state.
+func getPriorSloList(prior []interface{}, index int, key string) []interface{} {
+	if index >= len(prior) {
+		return nil
+	}
+	item, _ := prior[index].(map[string]interface{})
+	values, _ := item[key].([]interface{})
+	return values
+}
+
+// getPriorSloAlertRules returns the rules previously stored for the alert rule type,
+// the API can return alert rules in any order so they are matched by type.
+func getPriorSloAlertRules(priorAlertRules []interface{}, ruleType string) []interface{} {
+	for _, v := range priorAlertRules {
+		item, _ := v.(map[string]interface{})
+		if item[typeLabel] == ruleType {
+			rules, _ := item[ruleLabel].([]interface{})
+			return rules
+		}
+	}
+	return nil
+}
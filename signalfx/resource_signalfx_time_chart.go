@@ -67,10 +67,13 @@ func timeChartResource() *schema.Resource {
 				Description: "Name of the chart",
 			},
 			"program_text": &schema.Schema{
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Signalflow program text for the chart. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
-				ValidateFunc: validation.StringLenBetween(18, 50000),
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Signalflow program text for the chart. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
+				ValidateDiagFunc: validation.AllDiag(
+					validation.ToDiagFunc(validation.StringLenBetween(18, 50000)),
+					check.ProgramText(),
+				),
 			},
 			"description": &schema.Schema{
 				Type:        schema.TypeString,
@@ -463,6 +466,10 @@ func timeChartResource() *schema.Resource {
 			},
 		},
 
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			check.VisualizationLabels(),
+		},
+
 		Create: timechartCreate,
 		Read:   timechartRead,
 		Update: timechartUpdate,
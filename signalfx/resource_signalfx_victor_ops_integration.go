@@ -16,7 +16,7 @@ import (
 
 func integrationVictorOpsResource() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withWriteOnlyCredentials(map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
@@ -32,7 +32,7 @@ func integrationVictorOpsResource() *schema.Resource {
 				Optional:    true,
 				Description: "Opsgenie API URL for integration",
 			},
-		},
+		}, "post_url"),
 
 		Create: integrationVictorOpsCreate,
 		Read:   integrationVictorOpsRead,
@@ -49,7 +49,7 @@ func getVictorOpsPayloadIntegration(d *schema.ResourceData) *integration.VictorO
 		Type:    "VictorOps",
 		Name:    d.Get("name").(string),
 		Enabled: d.Get("enabled").(bool),
-		PostUrl: d.Get("post_url").(string),
+		PostUrl: getIntegrationCredential(d, "post_url"),
 	}
 }
 
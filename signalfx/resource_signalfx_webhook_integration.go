@@ -16,7 +16,7 @@ import (
 
 func integrationWebhookResource() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withWriteOnlyCredentials(map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
@@ -67,7 +67,7 @@ func integrationWebhookResource() *schema.Resource {
 				Optional:    true,
 				Description: "Template for the payload to be sent with the webhook request in JSON format",
 			},
-		},
+		}, "shared_secret"),
 
 		Create: integrationWebhookCreate,
 		Read:   integrationWebhookRead,
@@ -89,8 +89,8 @@ func getWebhookPayloadIntegration(d *schema.ResourceData) *integration.WebhookIn
 		PayloadTemplate: d.Get("payload_template").(string),
 	}
 
-	if val, ok := d.GetOk("shared_secret"); ok {
-		webhook.SharedSecret = val.(string)
+	if secret := getIntegrationCredential(d, "shared_secret"); secret != "" {
+		webhook.SharedSecret = secret
 	}
 
 	if val, ok := d.GetOk("headers"); ok {
@@ -134,8 +134,10 @@ func webhookIntegrationAPIToTF(d *schema.ResourceData, og *integration.WebhookIn
 	if err := d.Set("url", og.Url); err != nil {
 		return err
 	}
-	if err := d.Set("shared_secret", og.SharedSecret); err != nil {
-		return err
+	if !usesWriteOnlyCredential(d, "shared_secret") {
+		if err := d.Set("shared_secret", og.SharedSecret); err != nil {
+			return err
+		}
 	}
 	if err := d.Set("method", og.Method); err != nil {
 		return err